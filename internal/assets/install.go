@@ -16,6 +16,7 @@ import (
 	"cuelang.org/go/cue/parser"
 	"cuelang.org/go/mod/modconfig"
 	"cuelang.org/go/mod/modfile"
+	"github.com/grantcarthew/start/internal/config"
 	internalcue "github.com/grantcarthew/start/internal/cue"
 	"github.com/grantcarthew/start/internal/registry"
 )
@@ -380,17 +381,34 @@ func ModuleFromOrigin(origin string) string {
 // writeAssetToConfig writes the asset content to the config file.
 // If the asset already exists, it is updated in place (upsert).
 func writeAssetToConfig(configPath string, asset SearchResult, content ast.Expr, modulePath string) error {
-	// Read existing content if file exists
+	return WriteConfigEntry(configPath, asset.Category, asset.Name, content, "start assets add")
+}
+
+// WriteConfigEntry writes a single named entry under a category (e.g. "contexts")
+// in a CUE config file, preserving the rest of the file. If the entry already
+// exists, it is updated in place (upsert). A new file is given a header noting
+// the command that manages it.
+// The file is re-read and written under the config directory lock.
+func WriteConfigEntry(configPath, category, name string, content ast.Expr, managedBy string) error {
+	return config.UpdateFile(configPath, func(data []byte) ([]byte, error) {
+		return upsertConfigEntry(configPath, data, category, name, content, managedBy)
+	})
+}
+
+// upsertConfigEntry returns the config file content with the entry added or
+// replaced.
+func upsertConfigEntry(configPath string, data []byte, category, name string, content ast.Expr, managedBy string) ([]byte, error) {
 	var file *ast.File
-	if data, err := os.ReadFile(configPath); err == nil && len(data) > 0 {
+	if len(data) > 0 {
+		var err error
 		file, err = parser.ParseFile(configPath, data, parser.ParseComments)
 		if err != nil {
-			return fmt.Errorf("parsing config file: %w", err)
+			return nil, fmt.Errorf("parsing config file: %w", err)
 		}
 	}
 
 	assetField := &ast.Field{
-		Label: ast.NewStringLabel(asset.Name),
+		Label: ast.NewStringLabel(name),
 		Value: content,
 	}
 
@@ -400,27 +418,27 @@ func writeAssetToConfig(configPath string, asset SearchResult, content ast.Expr,
 			Elts: []ast.Decl{assetField},
 		}
 		categoryField := &ast.Field{
-			Label: ast.NewIdent(asset.Category),
+			Label: ast.NewIdent(category),
 			Value: categoryStruct,
 		}
 		ast.AddComment(categoryField, &ast.CommentGroup{
 			Doc: true,
 			List: []*ast.Comment{
 				{Text: "// start configuration"},
-				{Text: "// Managed by 'start assets add'"},
+				{Text: fmt.Sprintf("// Managed by '%s'", managedBy)},
 			},
 		})
 		file = &ast.File{Decls: []ast.Decl{categoryField}}
 	} else {
 		// Find or create category
-		catField := findCategoryField(file, asset.Category)
+		catField := findCategoryField(file, category)
 		if catField != nil {
 			catStruct, ok := catField.Value.(*ast.StructLit)
 			if !ok {
-				return fmt.Errorf("category %q is not a struct", asset.Category)
+				return nil, fmt.Errorf("category %q is not a struct", category)
 			}
 			// Upsert: update if exists, append if not
-			if existing := findAssetField(catStruct, asset.Name); existing != nil {
+			if existing := findAssetField(catStruct, name); existing != nil {
 				existing.Value = content
 			} else {
 				catStruct.Elts = append(catStruct.Elts, assetField)
@@ -431,7 +449,7 @@ func writeAssetToConfig(configPath string, asset SearchResult, content ast.Expr,
 				Elts: []ast.Decl{assetField},
 			}
 			categoryField := &ast.Field{
-				Label: ast.NewIdent(asset.Category),
+				Label: ast.NewIdent(category),
 				Value: categoryStruct,
 			}
 			file.Decls = append(file.Decls, categoryField)
@@ -440,9 +458,9 @@ func writeAssetToConfig(configPath string, asset SearchResult, content ast.Expr,
 
 	formatted, err := format.Node(file, format.Simplify())
 	if err != nil {
-		return fmt.Errorf("formatting config: %w", err)
+		return nil, fmt.Errorf("formatting config: %w", err)
 	}
-	return os.WriteFile(configPath, formatted, 0644)
+	return formatted, nil
 }
 
 // findCategoryField finds a top-level field in a CUE file by name.
@@ -482,37 +500,39 @@ func findAssetField(s *ast.StructLit, name string) *ast.Field {
 }
 
 // UpdateAssetInConfig replaces an existing asset entry in the config file.
+// The file is re-read and written under the config directory lock.
 func UpdateAssetInConfig(configPath, category, name string, newContent ast.Expr) error {
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("reading config file: %w", err)
-	}
+	return config.UpdateFile(configPath, func(data []byte) ([]byte, error) {
+		if data == nil {
+			return nil, fmt.Errorf("reading config file: %s does not exist", configPath)
+		}
 
-	file, err := parser.ParseFile(configPath, data, parser.ParseComments)
-	if err != nil {
-		return fmt.Errorf("parsing config file: %w", err)
-	}
+		file, err := parser.ParseFile(configPath, data, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("parsing config file: %w", err)
+		}
 
-	catField := findCategoryField(file, category)
-	if catField == nil {
-		return fmt.Errorf("asset %q not found in config", name)
-	}
+		catField := findCategoryField(file, category)
+		if catField == nil {
+			return nil, fmt.Errorf("asset %q not found in config", name)
+		}
 
-	catStruct, ok := catField.Value.(*ast.StructLit)
-	if !ok {
-		return fmt.Errorf("asset %q not found in config", name)
-	}
+		catStruct, ok := catField.Value.(*ast.StructLit)
+		if !ok {
+			return nil, fmt.Errorf("asset %q not found in config", name)
+		}
 
-	assetField := findAssetField(catStruct, name)
-	if assetField == nil {
-		return fmt.Errorf("asset %q not found in config", name)
-	}
+		assetField := findAssetField(catStruct, name)
+		if assetField == nil {
+			return nil, fmt.Errorf("asset %q not found in config", name)
+		}
 
-	assetField.Value = newContent
+		assetField.Value = newContent
 
-	formatted, err := format.Node(file, format.Simplify())
-	if err != nil {
-		return fmt.Errorf("formatting config: %w", err)
-	}
-	return os.WriteFile(configPath, formatted, 0644)
+		formatted, err := format.Node(file, format.Simplify())
+		if err != nil {
+			return nil, fmt.Errorf("formatting config: %w", err)
+		}
+		return formatted, nil
+	})
 }
@@ -1,9 +1,11 @@
 package assets
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"cuelang.org/go/cue"
@@ -271,6 +273,49 @@ func TestUpdateAssetInConfig_CategoryNotFound(t *testing.T) {
 	}
 }
 
+// TestWriteConfigEntry_Concurrent verifies that concurrent installs into the
+// same config file each see the previous writes, so no entry is lost.
+func TestWriteConfigEntry_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := cuecontext.New()
+	configPath := filepath.Join(t.TempDir(), "tasks.cue")
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		content := parseCUEStruct(t, fmt.Sprintf(`{prompt: "task %d"}`, i))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- WriteConfigEntry(configPath, "tasks", fmt.Sprintf("task-%d", i), content, "")
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("WriteConfigEntry: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := ctx.CompileBytes(data)
+	if err := v.Err(); err != nil {
+		t.Fatalf("result is not valid CUE: %v\n%s", err, data)
+	}
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("task-%d", i)
+		if !v.LookupPath(cue.MakePath(cue.Str("tasks"), cue.Str(name))).Exists() {
+			t.Errorf("%s missing from config:\n%s", name, data)
+		}
+	}
+}
+
 // TestWriteAssetToConfig_RoundTrip verifies that written config files have
 // correct CUE structure by parsing the output back and checking paths.
 func TestWriteAssetToConfig_RoundTrip(t *testing.T) {
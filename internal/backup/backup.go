@@ -0,0 +1,250 @@
+// Package backup keeps a bounded history of config file snapshots so that
+// commands which rewrite config can be undone.
+//
+// A mutating command captures the CUE files in the writable config
+// directories before it runs. Afterwards, the files it changed, created, or
+// deleted are saved as one numbered snapshot under the state directory.
+// Restoring a snapshot puts those files back as they were before the command.
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grantcarthew/start/internal/config"
+)
+
+// MaxSnapshots is the number of snapshots kept; older ones are pruned.
+const MaxSnapshots = 50
+
+// manifestFile is the snapshot metadata filename within a snapshot directory.
+const manifestFile = "snapshot.json"
+
+// File is a config file as it was before a command changed it.
+type File struct {
+	// Path is the absolute path of the config file.
+	Path string `json:"path"`
+	// Existed is false when the command created the file.
+	Existed bool `json:"existed"`
+	// Backup is the name of the saved copy within the snapshot directory.
+	Backup string `json:"backup,omitempty"`
+}
+
+// Snapshot is a saved set of files changed by one command.
+type Snapshot struct {
+	ID        int       `json:"id"`
+	Command   string    `json:"command"`
+	CreatedAt time.Time `json:"created_at"`
+	Files     []File    `json:"files"`
+}
+
+// DefaultDir returns the backup history directory, respecting XDG_STATE_HOME.
+// Defaults to ~/.local/state/start/backups.
+func DefaultDir() (string, error) {
+	base := os.Getenv("XDG_STATE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolving state directory: %w", err)
+		}
+		base = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(base, "start", "backups"), nil
+}
+
+// Capture holds the contents of the CUE files in a set of directories.
+type Capture struct {
+	dirs  []string
+	files map[string][]byte
+}
+
+// Begin captures the CUE files in dirs before a command runs. Directories
+// that do not exist yet are watched for files the command creates.
+func Begin(dirs ...string) (*Capture, error) {
+	c := &Capture{dirs: dirs}
+	files, err := readCUEFiles(dirs)
+	if err != nil {
+		return nil, err
+	}
+	c.files = files
+	return c, nil
+}
+
+// Commit saves the files that changed since Begin as a new snapshot in
+// backupDir, labelled with command, and prunes the history to MaxSnapshots.
+// Returns nil if nothing changed.
+func (c *Capture) Commit(backupDir, command string) (*Snapshot, error) {
+	after, err := readCUEFiles(c.dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for path, before := range c.files {
+		if now, ok := after[path]; !ok || !bytes.Equal(now, before) {
+			changed = append(changed, path)
+		}
+	}
+	for path := range after {
+		if _, ok := c.files[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	sort.Strings(changed)
+
+	snapshots, err := List(backupDir)
+	if err != nil {
+		return nil, err
+	}
+	id := 1
+	if len(snapshots) > 0 {
+		id = snapshots[0].ID + 1
+	}
+
+	snap := &Snapshot{ID: id, Command: command, CreatedAt: time.Now().UTC()}
+	dir := filepath.Join(backupDir, strconv.Itoa(id))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating backup: %w", err)
+	}
+	for i, path := range changed {
+		file := File{Path: path}
+		if before, ok := c.files[path]; ok {
+			file.Existed = true
+			file.Backup = fmt.Sprintf("%d-%s", i, filepath.Base(path))
+			if err := os.WriteFile(filepath.Join(dir, file.Backup), before, 0600); err != nil {
+				return nil, fmt.Errorf("writing backup: %w", err)
+			}
+		}
+		snap.Files = append(snap.Files, file)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding backup: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, manifestFile), append(data, '\n'), 0600); err != nil {
+		return nil, fmt.Errorf("writing backup: %w", err)
+	}
+
+	snapshots = append([]Snapshot{*snap}, snapshots...)
+	for _, old := range snapshots[min(len(snapshots), MaxSnapshots):] {
+		if err := os.RemoveAll(filepath.Join(backupDir, strconv.Itoa(old.ID))); err != nil {
+			return nil, fmt.Errorf("pruning backups: %w", err)
+		}
+	}
+	return snap, nil
+}
+
+// List returns the snapshots in backupDir, newest first. A missing directory
+// yields no snapshots. Entries without a readable manifest are skipped.
+func List(backupDir string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(backupDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading backups: %w", err)
+	}
+
+	var snapshots []Snapshot
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil || !entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(backupDir, entry.Name(), manifestFile))
+		if err != nil {
+			continue
+		}
+		var snap Snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snap)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID > snapshots[j].ID })
+	return snapshots, nil
+}
+
+// Restore undoes the snapshot with the given ID and every newer snapshot,
+// newest first, so the files return to their state before that command.
+// Restored snapshots are removed from the history and returned.
+func Restore(backupDir string, id int) ([]Snapshot, error) {
+	snapshots, err := List(backupDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored []Snapshot
+	for _, snap := range snapshots {
+		if snap.ID < id {
+			break
+		}
+		dir := filepath.Join(backupDir, strconv.Itoa(snap.ID))
+		for _, file := range snap.Files {
+			if err := restoreFile(dir, file); err != nil {
+				return restored, fmt.Errorf("restoring backup %d: %w", snap.ID, err)
+			}
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return restored, fmt.Errorf("removing backup %d: %w", snap.ID, err)
+		}
+		restored = append(restored, snap)
+		if snap.ID == id {
+			return restored, nil
+		}
+	}
+	return restored, fmt.Errorf("backup %d not found", id)
+}
+
+// restoreFile puts one file back from a snapshot directory. A file the
+// command created is removed.
+func restoreFile(dir string, file File) error {
+	if !file.Existed {
+		if err := os.Remove(file.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, file.Backup))
+	if err != nil {
+		return err
+	}
+	return config.WriteFile(file.Path, data)
+}
+
+// readCUEFiles reads the .cue files directly within dirs, keyed by path.
+func readCUEFiles(dirs []string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".cue") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", path, err)
+			}
+			files[path] = data
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,165 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestDefaultDir(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/state")
+	dir, err := DefaultDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("/tmp/state", "start", "backups"); dir != want {
+		t.Errorf("DefaultDir() = %q, want %q", dir, want)
+	}
+}
+
+func TestCommit(t *testing.T) {
+	t.Parallel()
+	configDir := t.TempDir()
+	backupDir := filepath.Join(t.TempDir(), "backups")
+	agents := filepath.Join(configDir, "agents.cue")
+	roles := filepath.Join(configDir, "roles.cue")
+	settings := filepath.Join(configDir, "settings.cue")
+	writeFile(t, agents, "agents: {}\n")
+	writeFile(t, settings, "settings: {}\n")
+	writeFile(t, filepath.Join(configDir, "notes.txt"), "ignored\n")
+
+	c, err := Begin(configDir, filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, agents, "agents: claude: {}\n")
+	writeFile(t, roles, "roles: {}\n")
+	writeFile(t, filepath.Join(configDir, "notes.txt"), "changed\n")
+
+	snap, err := c.Commit(backupDir, "start config add")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap == nil || snap.ID != 1 || snap.Command != "start config add" {
+		t.Fatalf("Commit() = %+v", snap)
+	}
+	if len(snap.Files) != 2 {
+		t.Fatalf("Files = %+v, want agents.cue and roles.cue", snap.Files)
+	}
+	if f := snap.Files[0]; f.Path != agents || !f.Existed {
+		t.Errorf("Files[0] = %+v, want existing agents.cue", f)
+	}
+	if f := snap.Files[1]; f.Path != roles || f.Existed {
+		t.Errorf("Files[1] = %+v, want created roles.cue", f)
+	}
+
+	// Nothing changed: no snapshot
+	c, err = Begin(configDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap, err := c.Commit(backupDir, "start config edit"); err != nil || snap != nil {
+		t.Errorf("unchanged Commit() = %+v, %v; want nil", snap, err)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	t.Parallel()
+	configDir := t.TempDir()
+	backupDir := filepath.Join(t.TempDir(), "backups")
+	agents := filepath.Join(configDir, "agents.cue")
+	roles := filepath.Join(configDir, "roles.cue")
+	writeFile(t, agents, "v1\n")
+
+	change := func(command string, edit func()) {
+		t.Helper()
+		c, err := Begin(configDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		edit()
+		if _, err := c.Commit(backupDir, command); err != nil {
+			t.Fatal(err)
+		}
+	}
+	change("one", func() { writeFile(t, agents, "v2\n") })
+	change("two", func() { writeFile(t, roles, "roles\n") })
+	change("three", func() { writeFile(t, agents, "v3\n") })
+
+	// Undo the latest command only
+	restored, err := Restore(backupDir, 3)
+	if err != nil || len(restored) != 1 {
+		t.Fatalf("Restore(3) = %+v, %v", restored, err)
+	}
+	if got := readFile(t, agents); got != "v2\n" {
+		t.Errorf("agents.cue = %q, want v2", got)
+	}
+
+	// Roll back to before the first command
+	restored, err = Restore(backupDir, 1)
+	if err != nil || len(restored) != 2 || restored[0].Command != "two" {
+		t.Fatalf("Restore(1) = %+v, %v", restored, err)
+	}
+	if got := readFile(t, agents); got != "v1\n" {
+		t.Errorf("agents.cue = %q, want v1", got)
+	}
+	if _, err := os.Stat(roles); !os.IsNotExist(err) {
+		t.Error("roles.cue should be removed: it was created by a restored command")
+	}
+	if snapshots, _ := List(backupDir); len(snapshots) != 0 {
+		t.Errorf("history = %+v, want empty", snapshots)
+	}
+
+	if _, err := Restore(backupDir, 7); err == nil || !strings.Contains(err.Error(), "backup 7 not found") {
+		t.Errorf("Restore(7) error = %v", err)
+	}
+}
+
+func TestCommit_PrunesHistory(t *testing.T) {
+	t.Parallel()
+	configDir := t.TempDir()
+	backupDir := filepath.Join(t.TempDir(), "backups")
+	path := filepath.Join(configDir, "agents.cue")
+
+	for i := 0; i < MaxSnapshots+3; i++ {
+		c, err := Begin(configDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, path, strings.Repeat("x", i+1))
+		if _, err := c.Commit(backupDir, "edit"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshots, err := List(backupDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != MaxSnapshots {
+		t.Fatalf("kept %d snapshots, want %d", len(snapshots), MaxSnapshots)
+	}
+	if snapshots[0].ID != MaxSnapshots+3 || snapshots[len(snapshots)-1].ID != 4 {
+		t.Errorf("kept IDs %d..%d, want %d..4", snapshots[0].ID, snapshots[len(snapshots)-1].ID, MaxSnapshots+3)
+	}
+}
@@ -44,7 +44,7 @@ Multiple queries can be provided to install several assets at once.
 By default, installs to global config (~/.config/start/).
 Use --local to install to project config (./.start/).`,
 		Args: cobra.MinimumNArgs(0),
-		RunE: runAssetsAdd,
+		RunE: withBackup(runAssetsAdd),
 	}
 
 	parent.AddCommand(addCmd)
@@ -108,7 +108,11 @@ func runAssetsAdd(cmd *cobra.Command, args []string) error {
 
 	// Fetch index
 	prog.Update("Fetching index...")
-	index, indexVersion, err := client.FetchIndex(ctx, resolveAssetsIndexPath())
+	indexPath, err := resolveAssetsIndexPath()
+	if err != nil {
+		return err
+	}
+	index, indexVersion, err := client.FetchIndex(ctx, indexPath)
 	if err != nil {
 		return fmt.Errorf("fetching index: %w", err)
 	}
@@ -123,8 +127,8 @@ func runAssetsAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(flags.Local)
-	scopeName := scopeString(flags.Local)
+	configDir := paths.Dir(flags.Scope())
+	scopeName := scopeString(flags.Scope())
 
 	// Load CUE config once for existence checks across all queries.
 	// On error with no CUE files (fresh install), cfg is a zero-value cue.Value;
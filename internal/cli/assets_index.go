@@ -67,6 +67,9 @@ func runAssetsIndex(cmd *cobra.Command, args []string) error {
 	flags := getFlags(cmd)
 	jsonFlag, _ := cmd.Flags().GetBool("json")
 	exportFlag, _ := cmd.Flags().GetBool("export")
+	if exportFlag && category != "" {
+		return fmt.Errorf("category filter cannot be used with --export")
+	}
 
 	// Create registry client
 	client, err := registry.NewClient()
@@ -79,7 +82,11 @@ func runAssetsIndex(cmd *cobra.Command, args []string) error {
 
 	// Resolve latest version
 	prog.Update("Fetching index...")
-	indexPath := registry.EffectiveIndexPath(resolveAssetsIndexPath())
+	configuredPath, err := resolveAssetsIndexPath()
+	if err != nil {
+		return err
+	}
+	indexPath := registry.EffectiveIndexPath(configuredPath)
 	resolvedPath, err := client.ResolveLatestVersion(ctx, indexPath)
 	if err != nil {
 		return fmt.Errorf("resolving index version: %w", err)
@@ -105,9 +112,6 @@ func runAssetsIndex(cmd *cobra.Command, args []string) error {
 
 	switch {
 	case exportFlag:
-		if category != "" {
-			return fmt.Errorf("category filter cannot be used with --export")
-		}
 		return printExportIndex(w, result.SourceDir)
 	case jsonFlag:
 		return printJSONIndex(w, result.SourceDir, client.Registry(), category)
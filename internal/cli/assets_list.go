@@ -146,11 +146,15 @@ func runAssetsList(cmd *cobra.Command, args []string) error {
 	// Check for updates if verbose
 	flags := getFlags(cmd)
 	if flags.Verbose {
+		indexPath, err := resolveAssetsIndexPath()
+		if err != nil {
+			return err
+		}
 		client, err := registry.NewClient()
 		if err == nil {
 			prog := tui.NewProgress(cmd.ErrOrStderr(), flags.Quiet)
 			prog.Update("Checking for updates...")
-			checkForUpdates(ctx, client, installed, resolveAssetsIndexPath())
+			checkForUpdates(ctx, client, installed, indexPath)
 			prog.Done()
 		}
 	}
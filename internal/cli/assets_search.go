@@ -94,7 +94,11 @@ func runAssetsSearch(cmd *cobra.Command, args []string) error {
 	defer prog.Done()
 
 	prog.Update("Fetching index...")
-	index, indexVersion, err := client.FetchIndex(ctx, resolveAssetsIndexPath())
+	indexPath, err := resolveAssetsIndexPath()
+	if err != nil {
+		return err
+	}
+	index, indexVersion, err := client.FetchIndex(ctx, indexPath)
 	if err != nil {
 		return fmt.Errorf("fetching index: %w", err)
 	}
@@ -47,7 +47,7 @@ With a query, updates only matching assets.
 Use --dry-run to preview what would be updated without applying changes.
 Use --force to re-fetch and update assets even when already at the latest version.`,
 		Args: cobra.MaximumNArgs(1),
-		RunE: runAssetsUpdate,
+		RunE: withBackup(runAssetsUpdate),
 	}
 
 	updateCmd.Flags().Bool("force", false, "Re-fetch even if already at latest version")
@@ -146,7 +146,11 @@ func runAssetsUpdate(cmd *cobra.Command, args []string) error {
 	defer prog.Done()
 
 	prog.Update("Checking for updates...")
-	index, indexVersion, err := client.FetchIndex(ctx, resolveAssetsIndexPath())
+	indexPath, err := resolveAssetsIndexPath()
+	if err != nil {
+		return err
+	}
+	index, indexVersion, err := client.FetchIndex(ctx, indexPath)
 	if err != nil {
 		return fmt.Errorf("fetching index: %w", err)
 	}
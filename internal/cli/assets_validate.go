@@ -151,7 +151,11 @@ func runAssetsValidate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Prerequisite 2: read assets_index setting
-	indexPath := registry.EffectiveIndexPath(resolveAssetsIndexPath())
+	configuredPath, err := resolveAssetsIndexPath()
+	if err != nil {
+		return err
+	}
+	indexPath := registry.EffectiveIndexPath(configuredPath)
 
 	// Prerequisite 3: derive git repo URL
 	cloneURL, err := validateDeriveRepoURL(indexPath)
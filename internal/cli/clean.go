@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/grantcarthew/start/internal/config"
+	"github.com/grantcarthew/start/internal/temp"
+	"github.com/grantcarthew/start/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// addCleanCommand adds the clean command to the parent command.
+func addCleanCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:     "clean",
+		GroupID: "utilities",
+		Short:   "Remove session temp files",
+		Long: `Remove per-session temp directories.
+
+Each start session copies file-based roles, contexts, and tasks that live
+outside the project into its own session directory so the agent can read
+them. Session directories are kept for the lifetime of the session and are
+garbage collected automatically once their agent has exited and they are older
+than settings.session_max_age (default 168h, 7 days).
+
+Session directories are stored under settings.temp_dir, or
+$XDG_RUNTIME_DIR/start, or the system temp directory. The legacy ./.start/temp
+directory written by earlier versions is removed as well.
+
+By default all session directories are removed, including those of running
+sessions. Use --older-than to keep recent sessions, and --dry-run to list
+what would be removed.
+
+Examples:
+  start clean                    Remove all session directories
+  start clean --older-than 24h   Remove sessions older than a day
+  start clean --dry-run          Preview without removing`,
+		Args: noArgsOrHelp,
+		RunE: runClean,
+	}
+
+	cmd.Flags().Duration("older-than", 0, "Only remove sessions older than this duration (e.g. 24h)")
+	parent.AddCommand(cmd)
+}
+
+// runClean removes session temp directories.
+func runClean(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	olderThan, _ := cmd.Flags().GetDuration("older-than")
+	if olderThan < 0 {
+		return fmt.Errorf("--older-than must not be negative")
+	}
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+
+	baseDir, err := sessionBaseDir(workingDir)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := temp.StaleSessions(baseDir, olderThan)
+	if err != nil {
+		return err
+	}
+
+	// Legacy in-project temp directory from before per-session workspaces.
+	legacyDir := temp.LegacyUTDDir(workingDir)
+	if _, err := os.Stat(legacyDir); err == nil {
+		sessions = append(sessions, temp.Session{Path: legacyDir})
+	}
+
+	flags := getFlags(cmd)
+	w := cmd.OutOrStdout()
+
+	if flags.DryRun {
+		printCleanList(w, "Would remove", sessions)
+		return nil
+	}
+
+	if err := temp.RemoveSessions(sessions); err != nil {
+		return err
+	}
+	if !flags.Quiet {
+		printCleanList(w, "Removed", sessions)
+	}
+	return nil
+}
+
+// sessionBaseDir resolves the session base directory from settings.temp_dir.
+func sessionBaseDir(workingDir string) (string, error) {
+	paths, err := config.ResolvePaths(workingDir)
+	if err != nil {
+		return "", fmt.Errorf("resolving config paths: %w", err)
+	}
+	settings, err := config.ResolveAllSettings(paths, config.ScopeMerged)
+	if err != nil {
+		return "", fmt.Errorf("loading settings: %w", err)
+	}
+	return temp.SessionBaseDir(settings["temp_dir"].Value), nil
+}
+
+// printCleanList prints the removed (or to-be-removed) directories.
+func printCleanList(w io.Writer, verb string, sessions []temp.Session) {
+	if len(sessions) == 0 {
+		_, _ = fmt.Fprintln(w, "No session directories to remove")
+		return
+	}
+
+	for _, s := range sessions {
+		_, _ = fmt.Fprintf(w, "%s %s", verb, s.Path)
+		if !s.ModTime.IsZero() {
+			_, _ = tui.ColorDim.Fprintf(w, " (%s old)", time.Since(s.ModTime).Round(time.Minute))
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setupCleanTest creates an isolated environment with two session directories
+// (one stale, one recent) and a legacy ./.start/temp directory.
+func setupCleanTest(t *testing.T) (oldSession, newSession, legacy string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	t.Setenv("XDG_RUNTIME_DIR", tmpDir)
+	chdir(t, tmpDir)
+
+	base := filepath.Join(tmpDir, "start")
+	oldSession = filepath.Join(base, "session-20200101000000-1")
+	newSession = filepath.Join(base, "session-20990101000000-2")
+	legacy = filepath.Join(tmpDir, ".start", "temp")
+	for _, dir := range []string{oldSession, newSession, legacy} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(oldSession, past, past); err != nil {
+		t.Fatal(err)
+	}
+	return oldSession, newSession, legacy
+}
+
+func runCleanCmd(t *testing.T, args ...string) string {
+	t.Helper()
+	cmd := NewRootCmd()
+	stdout := new(bytes.Buffer)
+	cmd.SetOut(stdout)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs(append([]string{"clean"}, args...))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("clean error = %v", err)
+	}
+	return stdout.String()
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestClean_DryRun(t *testing.T) {
+	oldSession, newSession, legacy := setupCleanTest(t)
+
+	output := runCleanCmd(t, "--dry-run")
+	for _, dir := range []string{oldSession, newSession, legacy} {
+		if !strings.Contains(output, "Would remove "+dir) {
+			t.Errorf("expected %s listed, got:\n%s", dir, output)
+		}
+		if !pathExists(dir) {
+			t.Errorf("%s removed during dry-run", dir)
+		}
+	}
+}
+
+func TestClean_OlderThan(t *testing.T) {
+	oldSession, newSession, legacy := setupCleanTest(t)
+
+	runCleanCmd(t, "--older-than", "24h")
+	if pathExists(oldSession) {
+		t.Error("stale session should be removed")
+	}
+	if !pathExists(newSession) {
+		t.Error("recent session should be kept")
+	}
+	if pathExists(legacy) {
+		t.Error("legacy .start/temp should be removed")
+	}
+}
+
+func TestClean_All(t *testing.T) {
+	oldSession, newSession, _ := setupCleanTest(t)
+
+	runCleanCmd(t)
+	if pathExists(oldSession) || pathExists(newSession) {
+		t.Error("all sessions should be removed")
+	}
+	if output := runCleanCmd(t); !strings.Contains(output, "No session directories") {
+		t.Errorf("expected nothing to remove, got:\n%s", output)
+	}
+}
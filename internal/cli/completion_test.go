@@ -118,3 +118,30 @@ func TestCompletionNoArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionSettings(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	chdir(t, t.TempDir())
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"config", "settings", ""}, []string{"secret_policy", "timeout", "edit"}},
+		{[]string{"config", "settings", "secret_policy", ""}, []string{"warn", "redact", "block"}},
+	}
+	for _, tt := range tests {
+		cmd := NewRootCmd()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetArgs(append([]string{"__complete"}, tt.args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("__complete %v: %v", tt.args, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(buf.String(), want+"\n") {
+				t.Errorf("__complete %v missing %q:\n%s", tt.args, want, buf.String())
+			}
+		}
+	}
+}
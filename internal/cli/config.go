@@ -33,7 +33,17 @@ Use --local to target project-specific configuration.`,
 	addConfigOrderCommand(configCmd)
 	addConfigSearchCommand(configCmd)
 	addConfigSettingsCommand(configCmd)
+	addConfigGetCommand(configCmd)
+	addConfigSetCommand(configCmd)
+	addConfigUnsetCommand(configCmd)
 	addConfigExportCommand(configCmd)
+	addConfigBlameCommand(configCmd)
+	addConfigImportCommand(configCmd)
+	addConfigHistoryCommand(configCmd)
+	addConfigUndoCommand(configCmd)
+	addConfigMigrateCommand(configCmd)
+	addConfigPackCommand(configCmd)
+	addConfigApplyCommand(configCmd)
 
 	parent.AddCommand(configCmd)
 }
@@ -49,7 +59,7 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 
 	w := cmd.OutOrStdout()
 	flags := getFlags(cmd)
-	local := flags.Local
+	scope := flags.Scope()
 
 	// Show config paths
 	paths, err := config.ResolvePaths("")
@@ -58,32 +68,18 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	}
 
 	_, _ = fmt.Fprintln(w)
-	_, _ = tui.ColorPaths.Fprintln(w, "Configuration Paths:")
-	globalStatus := "not found"
-	if paths.GlobalExists {
-		globalStatus = "exists"
-	}
-	localStatus := "not found"
-	if paths.LocalExists {
-		localStatus = "exists"
-	}
-	_, _ = tui.ColorDim.Fprintf(w, "  Global: ")
-	_, _ = fmt.Fprintf(w, "%s ", paths.Global)
-	_, _ = fmt.Fprintln(w, tui.Annotate("%s", globalStatus))
-	_, _ = tui.ColorDim.Fprintf(w, "  Local:  ")
-	_, _ = fmt.Fprintf(w, "%s ", paths.Local)
-	_, _ = fmt.Fprintln(w, tui.Annotate("%s", localStatus))
+	printConfigPaths(w, paths)
 
 	// Determine scope for listing
 	scopeLabel := "merged"
-	if local {
-		scopeLabel = "local"
+	if scope != config.ScopeMerged {
+		scopeLabel = scope.String()
 	}
 
 	stderr := cmd.ErrOrStderr()
 
 	// Settings
-	entries, err := config.ResolveAllSettings(paths, local)
+	entries, err := config.ResolveAllSettings(paths, scope)
 	if err != nil {
 		printWarning(stderr, "failed to load settings: %s", err)
 	}
@@ -97,7 +93,7 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Agents
-	agents, agentOrder, err := loadAgentsForScope(local)
+	agents, agentOrder, err := loadAgentsForScope(scope)
 	if err != nil {
 		printWarning(stderr, "failed to load agents: %s", err)
 	}
@@ -109,7 +105,7 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	_, _ = tui.ColorDim.Fprintf(w, ": %d\n", len(agents))
 	if len(agents) > 0 {
 		defaultAgent := ""
-		if cfg, err := loadConfigForScope(local); err == nil {
+		if cfg, err := loadConfigForScope(scope); err == nil {
 			defaultAgent = getDefaultAgentFromConfig(cfg)
 		}
 		for _, name := range agentOrder {
@@ -119,12 +115,12 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 				marker = tui.ColorInstalled.Sprint("→") + " "
 			}
 			_, _ = fmt.Fprintf(w, "  %s%s ", marker, name)
-			_, _ = fmt.Fprintln(w, tui.Annotate("%s", agent.Source))
+			_, _ = fmt.Fprintln(w, tui.Annotate("%s", itemSourceLabel(agent.Source, "", agent.Disabled)))
 		}
 	}
 
 	// Roles
-	roles, roleOrder, err := loadRolesForScope(local)
+	roles, roleOrder, err := loadRolesForScope(scope)
 	if err != nil {
 		printWarning(stderr, "failed to load roles: %s", err)
 	}
@@ -139,12 +135,12 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 		for _, name := range roleOrder {
 			role := roles[name]
 			_, _ = fmt.Fprintf(w, "    %s ", name)
-			_, _ = fmt.Fprintln(w, tui.Annotate("%s", role.Source))
+			_, _ = fmt.Fprintln(w, tui.Annotate("%s", itemSourceLabel(role.Source, "", role.Disabled)))
 		}
 	}
 
 	// Contexts
-	contexts, contextOrder, err := loadContextsForScope(local)
+	contexts, contextOrder, err := loadContextsForScope(scope)
 	if err != nil {
 		printWarning(stderr, "failed to load contexts: %s", err)
 	}
@@ -159,7 +155,7 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 		for _, name := range contextOrder {
 			ctx := contexts[name]
 			_, _ = fmt.Fprintf(w, "    %s ", name)
-			_, _ = fmt.Fprint(w, tui.Annotate("%s", ctx.Source))
+			_, _ = fmt.Fprint(w, tui.Annotate("%s", itemSourceLabel(ctx.Source, "", ctx.Disabled)))
 			if ctx.Required {
 				_, _ = fmt.Fprintf(w, " %s", tui.Bracket("required"))
 			}
@@ -176,7 +172,7 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Tasks
-	tasks, taskOrder, err := loadTasksForScope(local)
+	tasks, taskOrder, err := loadTasksForScope(scope)
 	if err != nil {
 		printWarning(stderr, "failed to load tasks: %s", err)
 	}
@@ -190,7 +186,7 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 		for _, name := range taskOrder {
 			task := tasks[name]
 			_, _ = fmt.Fprintf(w, "    %s ", name)
-			_, _ = fmt.Fprintln(w, tui.Annotate("%s", task.Source))
+			_, _ = fmt.Fprintln(w, tui.Annotate("%s", itemSourceLabel(task.Source, "", task.Disabled)))
 		}
 	}
 
@@ -6,31 +6,76 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/grantcarthew/start/internal/config"
+	"github.com/grantcarthew/start/internal/orchestration"
 	"github.com/grantcarthew/start/internal/tui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-// addConfigAddCommand adds the "config add [category]" command.
+// addFlagCategories lists the categories each category-specific add flag
+// applies to. Flags not listed apply to every category.
+var addFlagCategories = map[string][]string{
+	"bin":           {"agent"},
+	"model":         {"agent"},
+	"default-model": {"agent"},
+	"file":          {"role", "context", "task"},
+	"prompt":        {"role", "context", "task"},
+	"optional":      {"role"},
+	"required":      {"context"},
+	"default":       {"context"},
+	"role":          {"task"},
+}
+
+// addConfigAddCommand adds the "config add [category] [name]" command.
 func addConfigAddCommand(parent *cobra.Command) {
 	cmd := &cobra.Command{
-		Use:   "add [category]",
+		Use:   "add [category] [name]",
 		Short: "Add a new config item",
-		Long: `Add a new agent, role, context, or task interactively.
+		Long: `Add a new agent, role, context, or task.
 
 Provide a category (agent, role, context, task) to skip the category prompt.
 Plural aliases (agents, roles, contexts, tasks) are accepted.
 
-Always interactive — use 'start config open' to edit CUE files directly.`,
-		Args: cobra.MaximumNArgs(1),
-		RunE: runConfigAdd,
-	}
+Without a name, prompts for each field interactively. With a name, the item
+is built from flags without prompting, so it can be scripted. The same rules
+apply: roles, contexts, and tasks need exactly one of --file, --command, or
+--prompt, and agents need a command template (--command, or --bin for the
+default '<bin> {{.prompt}}').
+
+Agent flags:     --bin, --command, --model alias=id, --default-model
+Role flags:      --file, --command, --prompt, --optional
+Context flags:   --file, --command, --prompt, --required, --default
+Task flags:      --file, --command, --prompt, --role
+All categories:  --description, --tag
+
+Examples:
+  start config add role go-reviewer --file ./ROLE.md --tag go --optional
+  start config add context readme --file README.md --default
+  start config add agent claude --bin claude --model opus=claude-opus-4 --default-model opus
+  start config add task review --prompt "Review the staged changes" --role go-reviewer`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: withBackup(runConfigAdd),
+	}
+	cmd.Flags().String("description", "", "Item description")
+	cmd.Flags().StringSlice("tag", nil, "Tag (repeatable or comma-separated)")
+	cmd.Flags().String("file", "", "Content file path")
+	cmd.Flags().String("command", "", "Content command, or the agent command template")
+	cmd.Flags().String("prompt", "", "Inline prompt text")
+	cmd.Flags().Bool("optional", false, "Skip the role if its file is missing")
+	cmd.Flags().Bool("required", false, "Always include the context")
+	cmd.Flags().Bool("default", false, "Include the context by default")
+	cmd.Flags().String("role", "", "Role for the task")
+	cmd.Flags().String("bin", "", "Agent binary")
+	cmd.Flags().StringArray("model", nil, "Model alias as alias=model-id (repeatable)")
+	cmd.Flags().String("default-model", "", "Default model alias")
 	parent.AddCommand(cmd)
 }
 
-// runConfigAdd is the handler for "config add [category]".
+// runConfigAdd is the handler for "config add [category] [name]".
 func runConfigAdd(cmd *cobra.Command, args []string) error {
 	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
 		return err
@@ -38,12 +83,7 @@ func runConfigAdd(cmd *cobra.Command, args []string) error {
 
 	stdin := cmd.InOrStdin()
 	stdout := cmd.OutOrStdout()
-	local := getFlags(cmd).Local
-
-	// Always interactive
-	if !isTerminal(stdin) {
-		return fmt.Errorf("interactive add requires a terminal")
-	}
+	scope := getFlags(cmd).Scope()
 
 	category := ""
 	if len(args) > 0 {
@@ -53,6 +93,18 @@ func runConfigAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(args) > 1 || addFlagsChanged(cmd) {
+		name := ""
+		if len(args) > 1 {
+			name = args[1]
+		}
+		return configAddFromFlags(cmd, stdout, scope, category, name)
+	}
+
+	if !isTerminal(stdin) {
+		return fmt.Errorf("interactive add requires a terminal; pass a name and flags to add without prompting")
+	}
+
 	if category == "" {
 		_, _ = fmt.Fprintln(stdout)
 		_, _ = fmt.Fprintln(stdout, "Add:")
@@ -69,19 +121,137 @@ func runConfigAdd(cmd *cobra.Command, args []string) error {
 
 	switch category {
 	case "agent":
-		return configAgentAdd(stdin, stdout, local)
+		return configAgentAdd(stdin, stdout, scope)
+	case "role":
+		return configRoleAdd(stdin, stdout, scope)
+	case "context":
+		return configContextAdd(stdin, stdout, scope)
+	case "task":
+		return configTaskAdd(stdin, stdout, scope)
+	}
+	return nil
+}
+
+// addFlagsChanged reports whether any item field flag was set.
+func addFlagsChanged(cmd *cobra.Command) bool {
+	changed := false
+	cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+		changed = changed || f.Changed
+	})
+	return changed
+}
+
+// configAddFromFlags adds an item built from flags without prompting, applying
+// the same rules as the interactive flow.
+func configAddFromFlags(cmd *cobra.Command, stdout io.Writer, scope config.Scope, category, name string) error {
+	if category == "" {
+		return fmt.Errorf("category is required: start config add <category> <name> [flags]")
+	}
+	if name == "" {
+		return fmt.Errorf("%s name is required: start config add %s <name> [flags]", category, category)
+	}
+
+	var flagErr error
+	cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if cats, ok := addFlagCategories[f.Name]; ok && f.Changed && flagErr == nil && !slices.Contains(cats, category) {
+			flagErr = fmt.Errorf("--%s does not apply to %ss", f.Name, category)
+		}
+	})
+	if flagErr != nil {
+		return flagErr
+	}
+
+	flags := cmd.Flags()
+	description, _ := flags.GetString("description")
+	tags, _ := flags.GetStringSlice("tag")
+	file, _ := flags.GetString("file")
+	command, _ := flags.GetString("command")
+	prompt, _ := flags.GetString("prompt")
+
+	switch category {
+	case "agent":
+		bin, _ := flags.GetString("bin")
+		if command == "" && bin != "" {
+			command = bin + " {{.prompt}}"
+		}
+		if command == "" {
+			return fmt.Errorf("command template is required: use --command, or --bin for the default template")
+		}
+		if err := orchestration.ValidateCommandTemplate(command); err != nil {
+			return fmt.Errorf("invalid command template: %w", err)
+		}
+		modelArgs, _ := flags.GetStringArray("model")
+		models, err := parseModelAliases(modelArgs)
+		if err != nil {
+			return err
+		}
+		defaultModel, _ := flags.GetString("default-model")
+		if err := checkDefaultModel(defaultModel, models); err != nil {
+			return err
+		}
+		return saveNewAgent(stdout, scope, AgentConfig{
+			Name:         name,
+			Bin:          bin,
+			Command:      command,
+			DefaultModel: defaultModel,
+			Description:  description,
+			Models:       models,
+			Tags:         tags,
+		})
+	}
+
+	if err := checkContentSource(file, command, prompt); err != nil {
+		return fmt.Errorf("%w (use --file, --command, or --prompt)", err)
+	}
+
+	switch category {
 	case "role":
-		return configRoleAdd(stdin, stdout, local)
+		optional, _ := flags.GetBool("optional")
+		if optional && file == "" {
+			return fmt.Errorf("--optional requires --file")
+		}
+		return saveNewRole(stdout, scope, RoleConfig{
+			Name:        name,
+			Description: description,
+			File:        file,
+			Command:     command,
+			Prompt:      prompt,
+			Optional:    optional,
+			Tags:        tags,
+		})
 	case "context":
-		return configContextAdd(stdin, stdout, local)
+		required, _ := flags.GetBool("required")
+		isDefault, _ := flags.GetBool("default")
+		if required && isDefault {
+			return fmt.Errorf("--required and --default are mutually exclusive")
+		}
+		return saveNewContext(stdout, scope, ContextConfig{
+			Name:        name,
+			Description: description,
+			File:        file,
+			Command:     command,
+			Prompt:      prompt,
+			Required:    required,
+			Default:     isDefault,
+			Tags:        tags,
+		})
 	case "task":
-		return configTaskAdd(stdin, stdout, local)
+		role, _ := flags.GetString("role")
+		return saveNewTask(stdout, scope, TaskConfig{
+			Name:        name,
+			Description: description,
+			File:        file,
+			Command:     command,
+			Prompt:      prompt,
+			Role:        role,
+			Tags:        tags,
+		})
 	}
 	return nil
 }
 
 // configAgentAdd is the inner add logic for agents.
-func configAgentAdd(stdin io.Reader, stdout io.Writer, local bool) error {
+func configAgentAdd(stdin io.Reader, stdout io.Writer, scope config.Scope) error {
 	name, err := promptString(stdout, stdin, "Agent name", "")
 	if err != nil {
 		return err
@@ -95,9 +265,9 @@ func configAgentAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 		return err
 	}
 
-	defaultCmd := `"{{.prompt}}"`
+	defaultCmd := "{{.prompt}}"
 	if bin != "" {
-		defaultCmd = fmt.Sprintf(`%s "{{.prompt}}"`, bin)
+		defaultCmd = bin + " {{.prompt}}"
 	}
 	command, err := promptString(stdout, stdin, "Command template", defaultCmd)
 	if err != nil {
@@ -138,13 +308,21 @@ func configAgentAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 		Tags:         tags,
 	}
 
+	return saveNewAgent(stdout, scope, agent)
+}
+
+// saveNewAgent writes a new agent to the config for the scope. Returns an
+// error if the agent already exists there.
+func saveNewAgent(stdout io.Writer, scope config.Scope, agent AgentConfig) error {
+	name := agent.Name
+
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(local)
-	scopeName := scopeString(local)
+	configDir := paths.Dir(scope)
+	scopeName := scopeString(scope)
 
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("creating config directory: %w", err)
@@ -172,7 +350,7 @@ func configAgentAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 }
 
 // configRoleAdd is the inner add logic for roles.
-func configRoleAdd(stdin io.Reader, stdout io.Writer, local bool) error {
+func configRoleAdd(stdin io.Reader, stdout io.Writer, scope config.Scope) error {
 	name, err := promptString(stdout, stdin, "Role name", "")
 	if err != nil {
 		return err
@@ -191,22 +369,8 @@ func configRoleAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 		return err
 	}
 
-	sourceCount := 0
-	if file != "" {
-		sourceCount++
-	}
-	if command != "" {
-		sourceCount++
-	}
-	if prompt != "" {
-		sourceCount++
-	}
-
-	if sourceCount == 0 {
-		return fmt.Errorf("must specify one of: file, command, or prompt")
-	}
-	if sourceCount > 1 {
-		return fmt.Errorf("specify only one of: file, command, or prompt")
+	if err := checkContentSource(file, command, prompt); err != nil {
+		return err
 	}
 
 	var optional bool
@@ -236,13 +400,21 @@ func configRoleAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 		Tags:        tags,
 	}
 
+	return saveNewRole(stdout, scope, role)
+}
+
+// saveNewRole writes a new role to the config for the scope. Returns an
+// error if the role already exists there.
+func saveNewRole(stdout io.Writer, scope config.Scope, role RoleConfig) error {
+	name := role.Name
+
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(local)
-	scopeName := scopeString(local)
+	configDir := paths.Dir(scope)
+	scopeName := scopeString(scope)
 
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("creating config directory: %w", err)
@@ -270,7 +442,7 @@ func configRoleAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 }
 
 // configContextAdd is the inner add logic for contexts.
-func configContextAdd(stdin io.Reader, stdout io.Writer, local bool) error {
+func configContextAdd(stdin io.Reader, stdout io.Writer, scope config.Scope) error {
 	name, err := promptString(stdout, stdin, "Context name", "")
 	if err != nil {
 		return err
@@ -289,22 +461,8 @@ func configContextAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 		return err
 	}
 
-	sourceCount := 0
-	if file != "" {
-		sourceCount++
-	}
-	if command != "" {
-		sourceCount++
-	}
-	if prompt != "" {
-		sourceCount++
-	}
-
-	if sourceCount == 0 {
-		return fmt.Errorf("must specify one of: file, command, or prompt")
-	}
-	if sourceCount > 1 {
-		return fmt.Errorf("specify only one of: file, command, or prompt")
+	if err := checkContentSource(file, command, prompt); err != nil {
+		return err
 	}
 
 	var required, isDefault bool
@@ -345,13 +503,21 @@ func configContextAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 		Tags:        tags,
 	}
 
+	return saveNewContext(stdout, scope, ctx)
+}
+
+// saveNewContext writes a new context to the config for the scope. Returns an
+// error if the context already exists there.
+func saveNewContext(stdout io.Writer, scope config.Scope, ctx ContextConfig) error {
+	name := ctx.Name
+
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(local)
-	scopeName := scopeString(local)
+	configDir := paths.Dir(scope)
+	scopeName := scopeString(scope)
 
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("creating config directory: %w", err)
@@ -379,7 +545,7 @@ func configContextAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 }
 
 // configTaskAdd is the inner add logic for tasks.
-func configTaskAdd(stdin io.Reader, stdout io.Writer, local bool) error {
+func configTaskAdd(stdin io.Reader, stdout io.Writer, scope config.Scope) error {
 	name, err := promptString(stdout, stdin, "Task name", "")
 	if err != nil {
 		return err
@@ -398,22 +564,8 @@ func configTaskAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 		return err
 	}
 
-	sourceCount := 0
-	if file != "" {
-		sourceCount++
-	}
-	if command != "" {
-		sourceCount++
-	}
-	if prompt != "" {
-		sourceCount++
-	}
-
-	if sourceCount == 0 {
-		return fmt.Errorf("must specify one of: file, command, or prompt")
-	}
-	if sourceCount > 1 {
-		return fmt.Errorf("specify only one of: file, command, or prompt")
+	if err := checkContentSource(file, command, prompt); err != nil {
+		return err
 	}
 
 	role, err := promptString(stdout, stdin, "Role (optional)", "")
@@ -436,13 +588,21 @@ func configTaskAdd(stdin io.Reader, stdout io.Writer, local bool) error {
 		Tags:        tags,
 	}
 
+	return saveNewTask(stdout, scope, task)
+}
+
+// saveNewTask writes a new task to the config for the scope. Returns an
+// error if the task already exists there.
+func saveNewTask(stdout io.Writer, scope config.Scope, task TaskConfig) error {
+	name := task.Name
+
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(local)
-	scopeName := scopeString(local)
+	configDir := paths.Dir(scope)
+	scopeName := scopeString(scope)
 
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("creating config directory: %w", err)
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grantcarthew/start/internal/config"
+)
+
+// setupConfigFlagsTest points global config at an empty temp dir and changes
+// into it. Returns the global config dir.
+func setupConfigFlagsTest(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	chdir(t, tmpDir)
+	return filepath.Join(tmpDir, "start")
+}
+
+func runConfigCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	cmd := NewRootCmd()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader(""))
+	cmd.SetArgs(append([]string{"config"}, args...))
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestConfigAdd_Flags(t *testing.T) {
+	globalDir := setupConfigFlagsTest(t)
+
+	output, err := runConfigCmd(t, "add", "role", "go-reviewer", "--file", "./ROLE.md", "--tag", "go", "--tag", "review", "--optional")
+	if err != nil {
+		t.Fatalf("add role: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, `Added role "go-reviewer" to global config`) {
+		t.Errorf("output = %q", output)
+	}
+	roles, _, err := loadRolesFromDir(globalDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	role := roles["go-reviewer"]
+	if role.File != "./ROLE.md" || !role.Optional || strings.Join(role.Tags, ",") != "go,review" {
+		t.Errorf("role = %+v", role)
+	}
+
+	if output, err := runConfigCmd(t, "add", "agents", "claude", "--bin", "claude", "--model", "opus=claude-opus-4", "--default-model", "opus"); err != nil {
+		t.Fatalf("add agent: %v\n%s", err, output)
+	}
+	agents, _, err := loadAgentsFromDir(globalDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent := agents["claude"]
+	if agent.Command != "claude {{.prompt}}" || agent.DefaultModel != "opus" || agent.Models["opus"] != "claude-opus-4" {
+		t.Errorf("agent = %+v", agent)
+	}
+
+	if output, err := runConfigCmd(t, "add", "context", "rules", "--prompt", "Use tabs.", "--required"); err != nil {
+		t.Fatalf("add context: %v\n%s", err, output)
+	}
+	if output, err := runConfigCmd(t, "add", "task", "review", "--prompt", "Review it", "--role", "go-reviewer"); err != nil {
+		t.Fatalf("add task: %v\n%s", err, output)
+	}
+	tasks, _, err := loadTasksFromDir(globalDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tasks["review"].Role != "go-reviewer" {
+		t.Errorf("task = %+v", tasks["review"])
+	}
+}
+
+func TestConfigAdd_FlagsErrors(t *testing.T) {
+	setupConfigFlagsTest(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"missing name", []string{"add", "role", "--prompt", "x"}, "role name is required"},
+		{"missing category", []string{"add", "--prompt", "x"}, "category is required"},
+		{"no content source", []string{"add", "role", "r"}, "must specify one of"},
+		{"two content sources", []string{"add", "context", "c", "--file", "a.md", "--prompt", "x"}, "specify only one of"},
+		{"flag for other category", []string{"add", "role", "r", "--prompt", "x", "--required"}, "--required does not apply to roles"},
+		{"optional without file", []string{"add", "role", "r", "--prompt", "x", "--optional"}, "--optional requires --file"},
+		{"agent without command", []string{"add", "agent", "a"}, "command template is required"},
+		{"invalid command", []string{"add", "agent", "a", "--command", `a "{{.prompt}}"`}, "quoted placeholder"},
+		{"bad model", []string{"add", "agent", "a", "--bin", "a", "--model", "fast"}, "use alias=model-id"},
+		{"unknown default model", []string{"add", "agent", "a", "--bin", "a", "--model", "fast=x", "--default-model", "slow"}, `default model "slow" is not a model alias`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runConfigCmd(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Adding an existing item fails like the interactive flow
+	if _, err := runConfigCmd(t, "add", "role", "dup", "--prompt", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runConfigCmd(t, "add", "role", "dup", "--prompt", "y"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("duplicate add: error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "start", "agents.cue")); !os.IsNotExist(err) {
+		t.Error("failed agent adds should not write agents.cue")
+	}
+}
+
+func TestConfigAdd_Personal(t *testing.T) {
+	setupConfigFlagsTest(t)
+	cwd, _ := os.Getwd()
+	projectDir := filepath.Join(cwd, ".start")
+	personalDir := filepath.Join(projectDir, "local")
+
+	if output, err := runConfigCmd(t, "add", "role", "dev", "--prompt", "Team developer.", "--local"); err != nil {
+		t.Fatalf("add --local: %v\n%s", err, output)
+	}
+	output, err := runConfigCmd(t, "add", "role", "dev", "--prompt", "My developer.", "--personal")
+	if err != nil {
+		t.Fatalf("add --personal: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "to personal config") {
+		t.Errorf("output = %q", output)
+	}
+	if _, err := os.Stat(filepath.Join(personalDir, ".gitignore")); err != nil {
+		t.Errorf("personal overlay not gitignored: %v", err)
+	}
+
+	// The project file is untouched and the overlay wins when merged
+	roles, _, err := loadRolesFromDir(projectDir)
+	if err != nil || roles["dev"].Prompt != "Team developer." {
+		t.Errorf("project role = %+v, %v", roles["dev"], err)
+	}
+	merged, _, err := loadRolesForScope(config.ScopeMerged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged["dev"].Prompt != "My developer." || merged["dev"].Source != config.SourcePersonal {
+		t.Errorf("merged role = %+v", merged["dev"])
+	}
+
+	// Edits go to the layer that defines the item
+	if output, err := runConfigCmd(t, "edit", "dev", "--set", "description=Mine"); err != nil {
+		t.Fatalf("edit: %v\n%s", err, output)
+	}
+	roles, _, err = loadRolesFromDir(personalDir)
+	if err != nil || roles["dev"].Description != "Mine" {
+		t.Errorf("personal role after edit = %+v, %v", roles["dev"], err)
+	}
+}
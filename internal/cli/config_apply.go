@@ -0,0 +1,509 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	cueformat "cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+	"github.com/grantcarthew/start/internal/assets"
+	"github.com/grantcarthew/start/internal/config"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/diff"
+	"github.com/grantcarthew/start/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// Conflict resolutions for config apply.
+const (
+	conflictKeep    = "keep"
+	conflictReplace = "replace"
+	conflictRename  = "rename"
+)
+
+// applyItem is an item or setting from a bundle and what applying it does.
+type applyItem struct {
+	key      string // Top-level key, e.g. "agents"
+	category string // Singular category, e.g. "agent"
+	name     string
+	value    cue.Value // Loaded value from the bundle
+	expr     ast.Expr  // Source from the bundle, written as-is
+	current  ast.Expr  // Source in the target scope; nil if not defined there
+	path     string    // File the item is written to
+	same     bool      // The target already has the same value
+	action   string    // Resolution chosen for a conflict
+	newName  string    // Name a renamed item is written under
+}
+
+// conflict reports whether the target defines the item with a different
+// value.
+func (it *applyItem) conflict() bool {
+	return it.current != nil && !it.same
+}
+
+// writes reports whether applying the item changes the target config.
+func (it *applyItem) writes() bool {
+	if it.current == nil {
+		return true
+	}
+	return it.conflict() && (it.action == conflictReplace || it.action == conflictRename)
+}
+
+// addConfigApplyCommand adds the "config apply" command.
+func addConfigApplyCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "apply <bundle>",
+		Short: "Merge a config bundle into global or local config",
+		Long: `Merge the config from a bundle written by 'start config pack' into global
+config, or local with --local.
+
+The bundle's checksums are verified and its files are migrated to the current
+config format version first. A preview then lists each agent, role, context,
+task, and setting in the bundle:
+
+  +  new, added to the category file
+  =  already defined with the same value, skipped
+  !  conflict: defined with a different value
+
+For each conflict, keep the current value, replace it with the bundle's, or
+rename the bundle's item (settings cannot be renamed and are kept). In a
+terminal you are asked for each conflict; otherwise choose one resolution for
+all of them with --on-conflict. Renaming does not update references to the
+item elsewhere in the bundle.
+
+Use --dry-run to show the preview, with a diff for each conflict, and write
+nothing. Use --yes / -y to apply without confirmation. Changes can be
+reverted with 'start config undo'.`,
+		Example: `  start config apply team.tar.gz --dry-run
+  start config apply team.tar.gz
+  start config apply team.tar.gz --local --yes --on-conflict keep`,
+		Args: cobra.ExactArgs(1),
+		RunE: withBackup(runConfigApply),
+	}
+	cmd.Flags().String("on-conflict", "", "Resolve every conflict: keep, replace, or rename")
+	cmd.Flags().BoolP("yes", "y", false, "Apply without confirmation")
+	parent.AddCommand(cmd)
+}
+
+// runConfigApply handles the "config apply <bundle>" command.
+func runConfigApply(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+	flags := getFlags(cmd)
+	stdout := cmd.OutOrStdout()
+	stderr := cmd.ErrOrStderr()
+	stdin := cmd.InOrStdin()
+	file := args[0]
+
+	onConflict, _ := cmd.Flags().GetString("on-conflict")
+	switch onConflict {
+	case "", conflictKeep, conflictReplace, conflictRename:
+	default:
+		return fmt.Errorf("unknown --on-conflict %q: expected keep, replace, or rename", onConflict)
+	}
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("opening bundle: %w", err)
+	}
+	bundle, err := config.ReadBundle(f)
+	_ = f.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	items, bundleValue, err := loadBundleItems(bundle)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	schemas, err := fetchSchemas()
+	if err != nil {
+		printWarning(stderr, "schema validation skipped: %v", err)
+	} else if issues := schemas.Validate(bundleValue); len(issues) > 0 {
+		for _, issue := range issues {
+			_, _ = fmt.Fprintf(stderr, "  %s\n", issue.Error())
+		}
+		return fmt.Errorf("%s does not match the config schema; nothing applied", file)
+	}
+
+	paths, err := config.ResolvePaths("")
+	if err != nil {
+		return fmt.Errorf("resolving config paths: %w", err)
+	}
+	configDir := paths.Dir(flags.Scope())
+	taken, err := compareWithTarget(items, configDir)
+	if err != nil {
+		return err
+	}
+
+	scope := scopeString(flags.Scope())
+	_, _ = fmt.Fprintf(stdout, "Applying %s to %s config ", filepath.Base(file), scope)
+	_, _ = fmt.Fprintln(stdout, tui.Annotate("%s", shortenHome(configDir)))
+	printApplyPreview(stdout, items, flags.DryRun)
+	if flags.DryRun {
+		_, _ = fmt.Fprintln(stdout, "Dry run: no files written")
+		return nil
+	}
+
+	conflicts := 0
+	for _, it := range items {
+		if it.conflict() {
+			conflicts++
+		}
+	}
+	interactive := isTerminal(stdin)
+	reader := bufio.NewReader(stdin)
+	if conflicts > 0 {
+		switch {
+		case onConflict != "":
+			for _, it := range items {
+				if it.conflict() {
+					it.action = onConflict
+				}
+			}
+		case interactive:
+			if err := promptConflicts(stdout, reader, items); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%d of the bundle's entries conflict with %s config; choose a resolution with --on-conflict keep|replace|rename", conflicts, scope)
+		}
+	}
+	if err := chooseRenames(stdout, reader, items, taken, onConflict == ""); err != nil {
+		return err
+	}
+
+	changes := 0
+	for _, it := range items {
+		if it.writes() {
+			changes++
+		}
+	}
+	if changes == 0 {
+		_, _ = fmt.Fprintln(stdout, "Nothing to apply")
+		return nil
+	}
+	if !skipConfirm {
+		if !interactive {
+			return fmt.Errorf("--yes flag required in non-interactive mode")
+		}
+		answer, err := readAnswer(stdout, reader, fmt.Sprintf("Apply %d changes to %s config? %s", changes, scope, tui.Bracket("y/N")))
+		if err != nil {
+			return err
+		}
+		if answer = strings.ToLower(answer); answer != "y" && answer != "yes" {
+			_, _ = fmt.Fprintln(stdout, "Cancelled.")
+			return nil
+		}
+	}
+
+	// The target scope may have no config directory yet, e.g. --local in a
+	// project without .start/.
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("creating %s config directory: %w", scope, err)
+	}
+	for _, it := range items {
+		if !it.writes() {
+			continue
+		}
+		name := it.name
+		if it.action == conflictRename {
+			name = it.newName
+		}
+		if err := assets.WriteConfigEntry(it.path, it.key, name, it.expr, "start config apply"); err != nil {
+			return fmt.Errorf("writing %s %q: %w", it.category, name, err)
+		}
+		if flags.Quiet {
+			continue
+		}
+		switch it.action {
+		case conflictRename:
+			_, _ = fmt.Fprintf(stdout, "Renamed %s %q to %q ", it.category, it.name, it.newName)
+		case conflictReplace:
+			_, _ = fmt.Fprintf(stdout, "Replaced %s %q ", it.category, it.name)
+		default:
+			_, _ = fmt.Fprintf(stdout, "Added %s %q ", it.category, it.name)
+		}
+		_, _ = fmt.Fprintln(stdout, tui.Annotate("%s", filepath.Base(it.path)))
+	}
+
+	if _, err := internalcue.NewLoader().LoadSingle(configDir); err != nil {
+		return fmt.Errorf("%s config no longer loads after apply: %w\nRun 'start config undo' to revert", scope, err)
+	}
+	return nil
+}
+
+// loadBundleItems extracts a bundle to a temporary directory, migrates it to
+// the current config format version, and returns its items and settings with
+// their source, along with the loaded bundle config.
+func loadBundleItems(bundle config.Bundle) ([]*applyItem, cue.Value, error) {
+	dir, err := os.MkdirTemp("", "start-apply-")
+	if err != nil {
+		return nil, cue.Value{}, fmt.Errorf("creating temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	if err := bundle.Extract(dir); err != nil {
+		return nil, cue.Value{}, err
+	}
+	plan, err := config.PlanMigration(dir)
+	if err != nil {
+		return nil, cue.Value{}, err
+	}
+	if len(plan.Files) > 0 {
+		if err := config.ApplyMigration(plan); err != nil {
+			return nil, cue.Value{}, err
+		}
+	}
+
+	result, err := internalcue.NewLoader().Load([]string{dir})
+	if err != nil {
+		return nil, cue.Value{}, err
+	}
+	sources := itemSources{}
+	var items []*applyItem
+	for _, c := range blameCategories {
+		iter, err := result.Value.LookupPath(cue.ParsePath(c.key)).Fields()
+		if err != nil {
+			continue
+		}
+		for iter.Next() {
+			name := iter.Selector().Unquoted()
+			expr, err := sources.lookup(result, c.key, name, iter.Value())
+			if err != nil {
+				return nil, cue.Value{}, err
+			}
+			items = append(items, &applyItem{key: c.key, category: c.category, name: name, value: iter.Value(), expr: expr})
+		}
+	}
+	return items, result.Value, nil
+}
+
+// compareWithTarget records, for each item, its current source in configDir,
+// whether that matches the bundle, and the file the item is written to.
+// Returns the "key.name" of every item in the bundle or configDir.
+func compareWithTarget(items []*applyItem, configDir string) (map[string]bool, error) {
+	target, err := loadDirDefinitions(configDir)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool)
+	for _, c := range blameCategories {
+		for _, name := range target.DefinedNames(c.key) {
+			taken[c.key+"."+name] = true
+		}
+	}
+	sources := itemSources{}
+	for _, it := range items {
+		taken[it.key+"."+it.name] = true
+		it.path = filepath.Join(configDir, internalcue.ConfigFiles[it.key])
+		if !target.Value.Exists() {
+			continue
+		}
+		v := target.Value.LookupPath(cue.MakePath(cue.Str(it.key), cue.Str(it.name)))
+		if !v.Exists() {
+			continue
+		}
+		if it.current, err = sources.lookup(target, it.key, it.name, v); err != nil {
+			return nil, err
+		}
+		if defs := target.Definitions(it.key, it.name); len(defs) > 0 && defs[len(defs)-1].File != "" {
+			it.path = defs[len(defs)-1].File
+		}
+		it.same = sameValue(it.value, v)
+	}
+	return taken, nil
+}
+
+// itemSources reads item definitions from config files, parsing each file
+// once.
+type itemSources map[string]*ast.File
+
+// lookup returns the source of an item as written in the file that defines
+// it, so comments are kept. Items the file does not declare directly fall
+// back to the loaded value.
+func (s itemSources) lookup(result internalcue.LoadResult, key, name string, v cue.Value) (ast.Expr, error) {
+	if defs := result.Definitions(key, name); len(defs) > 0 {
+		if path := defs[len(defs)-1].File; path != "" {
+			f, ok := s[path]
+			if !ok {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return nil, fmt.Errorf("reading %s: %w", path, err)
+				}
+				if f, err = parser.ParseFile(path, data, parser.ParseComments); err != nil {
+					return nil, fmt.Errorf("parsing %s: %w", path, err)
+				}
+				s[path] = f
+			}
+			if field := internalcue.FindField(f, []string{key, name}); field != nil {
+				return field.Value, nil
+			}
+		}
+	}
+	expr, ok := v.Syntax(cue.Final()).(ast.Expr)
+	if !ok {
+		return nil, fmt.Errorf("converting %s.%s to CUE", key, name)
+	}
+	return expr, nil
+}
+
+// sameValue reports whether two config values hold the same data, ignoring
+// field order.
+func sameValue(a, b cue.Value) bool {
+	aj, aErr := a.MarshalJSON()
+	bj, bErr := b.MarshalJSON()
+	if aErr != nil || bErr != nil {
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	}
+	var av, bv any
+	if json.Unmarshal(aj, &av) != nil || json.Unmarshal(bj, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// printApplyPreview lists what applying each item does. With diffs, each
+// conflict is followed by a diff from the current value to the bundle's.
+func printApplyPreview(w io.Writer, items []*applyItem, diffs bool) {
+	if len(items) == 0 {
+		_, _ = fmt.Fprintln(w, "  (bundle has no config entries)")
+		return
+	}
+	endsWithDiff := false
+	for _, it := range items {
+		endsWithDiff = false
+		switch {
+		case it.current == nil:
+			_, _ = fmt.Fprintf(w, "  + %s %s\n", it.category, it.name)
+		case it.same:
+			_, _ = fmt.Fprintf(w, "  = %s %s ", it.category, it.name)
+			_, _ = fmt.Fprintln(w, tui.Annotate("unchanged"))
+		default:
+			_, _ = fmt.Fprintf(w, "  ! %s %s ", it.category, it.name)
+			_, _ = fmt.Fprintln(w, tui.Annotate("conflict, %s", filepath.Base(it.path)))
+			if diffs {
+				printItemDiff(w, it)
+				endsWithDiff = true
+			}
+		}
+	}
+	// Diffs end with a blank line already
+	if !endsWithDiff {
+		_, _ = fmt.Fprintln(w)
+	}
+}
+
+// printItemDiff prints a diff from the item's current value to the bundle's.
+func printItemDiff(w io.Writer, it *applyItem) {
+	printUnifiedDiff(w, diff.Unified("current", "bundle", formatItem(it.name, it.current), formatItem(it.name, it.expr), 3))
+}
+
+// formatItem formats an item as a CUE field.
+func formatItem(name string, expr ast.Expr) string {
+	b, err := cueformat.Node(&ast.Field{Label: ast.NewStringLabel(name), Value: expr})
+	if err != nil {
+		return fmt.Sprintf("%s: <%v>\n", name, err)
+	}
+	return string(b) + "\n"
+}
+
+// promptConflicts asks how to resolve each conflict.
+func promptConflicts(w io.Writer, r *bufio.Reader, items []*applyItem) error {
+	for _, it := range items {
+		if !it.conflict() {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "Conflict: %s %q ", it.category, it.name)
+		_, _ = fmt.Fprintln(w, tui.Annotate("%s", filepath.Base(it.path)))
+		printItemDiff(w, it)
+
+		choices, label := "kr", "Keep or replace?"
+		if it.key != internalcue.KeySettings {
+			choices, label = "krn", "Keep, replace, or rename?"
+		}
+		for it.action == "" {
+			answer, err := readAnswer(w, r, fmt.Sprintf("%s %s", label, tui.Bracket("%s", strings.Join(strings.Split(choices, ""), "/"))))
+			if err != nil {
+				return err
+			}
+			answer = strings.ToLower(answer)
+			switch {
+			case answer == "" || strings.HasPrefix(conflictKeep, answer):
+				it.action = conflictKeep
+			case strings.HasPrefix(conflictReplace, answer):
+				it.action = conflictReplace
+			case strings.ContainsRune(choices, 'n') && (answer == "n" || strings.HasPrefix(conflictRename, answer)):
+				it.action = conflictRename
+			default:
+				_, _ = fmt.Fprintf(w, "Enter one of: %s\n", strings.Join(strings.Split(choices, ""), ", "))
+			}
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// chooseRenames picks a name that is not taken for each item being renamed.
+// Settings cannot be renamed and are kept. With prompt, the user may change
+// the suggested name.
+func chooseRenames(w io.Writer, r *bufio.Reader, items []*applyItem, taken map[string]bool, prompt bool) error {
+	for _, it := range items {
+		if it.action != conflictRename {
+			continue
+		}
+		if it.key == internalcue.KeySettings {
+			it.action = conflictKeep
+			continue
+		}
+		name := freeName(it.key, it.name, taken)
+		for prompt {
+			answer, err := readAnswer(w, r, fmt.Sprintf("New name for %s %q %s", it.category, it.name, tui.Bracket("%s", name)))
+			if err != nil {
+				return err
+			}
+			if answer == "" {
+				break
+			}
+			if !taken[it.key+"."+answer] {
+				name = answer
+				break
+			}
+			_, _ = fmt.Fprintf(w, "%s %q already exists\n", it.category, answer)
+		}
+		it.newName = name
+		taken[it.key+"."+name] = true
+	}
+	return nil
+}
+
+// freeName returns name with the first numeric suffix not already taken.
+func freeName(key, name string, taken map[string]bool) string {
+	for i := 2; ; i++ {
+		candidate := name + "-" + strconv.Itoa(i)
+		if !taken[key+"."+candidate] {
+			return candidate
+		}
+	}
+}
+
+// readAnswer prints a prompt and returns the trimmed reply.
+func readAnswer(w io.Writer, r *bufio.Reader, prompt string) (string, error) {
+	_, _ = fmt.Fprintf(w, "%s ", prompt)
+	input, err := r.ReadString('\n')
+	if err != nil && (err != io.EOF || input == "") {
+		return "", fmt.Errorf("reading input: %w", err)
+	}
+	return strings.TrimSpace(input), nil
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue/ast"
+)
+
+func TestConfigPackApply(t *testing.T) {
+	globalDir := setupConfigFlagsTest(t)
+	localDir := filepath.Join(filepath.Dir(globalDir), ".start")
+	for _, dir := range []string{globalDir, localDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(filepath.Join(globalDir, "agents.cue"), `agents: claude: {
+	bin: "claude"
+	// Fast model
+	models: fast: {id: "claude-haiku-4"}
+}
+`)
+	writeFile(filepath.Join(globalDir, "roles.cue"), `roles: {
+	dev: prompt: "You are a team developer."
+	qa: prompt:  "You are a tester."
+}
+`)
+	writeFile(filepath.Join(localDir, "roles.cue"), `roles: {
+	dev: prompt: "You are a developer."
+	qa: prompt:  "You are a tester."
+}
+`)
+
+	bundlePath := filepath.Join(t.TempDir(), "team.tar.gz")
+	output, err := runConfigCmd(t, "pack", bundlePath)
+	if err != nil {
+		t.Fatalf("pack: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Packed 2 files from global config") || !strings.Contains(output, "SHA-256: ") {
+		t.Errorf("pack output = %q", output)
+	}
+
+	output, err = runConfigCmd(t, "apply", bundlePath, "--local", "--dry-run")
+	if err != nil {
+		t.Fatalf("apply --dry-run: %v\n%s", err, output)
+	}
+	for _, want := range []string{"+ agent claude", "= role qa", "! role dev", `+	prompt: "You are a team developer."`, "Dry run: no files written"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("dry run output missing %q:\n%s", want, output)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(localDir, "agents.cue")); !os.IsNotExist(err) {
+		t.Error("dry run should not write agents.cue")
+	}
+
+	if _, err := runConfigCmd(t, "apply", bundlePath, "--local", "--yes"); err == nil || !strings.Contains(err.Error(), "--on-conflict") {
+		t.Errorf("apply with conflicts and no terminal: error = %v", err)
+	}
+
+	output, err = runConfigCmd(t, "apply", bundlePath, "--local", "--yes", "--on-conflict", "rename")
+	if err != nil {
+		t.Fatalf("apply: %v\n%s", err, output)
+	}
+	for _, want := range []string{`Added agent "claude"`, `Renamed role "dev" to "dev-2"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("apply output missing %q:\n%s", want, output)
+		}
+	}
+
+	roles, _, err := loadRolesFromDir(localDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roles["dev"].Prompt != "You are a developer." || roles["dev-2"].Prompt != "You are a team developer." {
+		t.Errorf("roles after apply: dev = %q, dev-2 = %q", roles["dev"].Prompt, roles["dev-2"].Prompt)
+	}
+	agents, err := os.ReadFile(filepath.Join(localDir, "agents.cue"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The bundle is migrated before it is applied, and comments are kept.
+	if !strings.Contains(string(agents), `fast: "claude-haiku-4"`) || !strings.Contains(string(agents), "// Fast model") {
+		t.Errorf("agents.cue after apply:\n%s", agents)
+	}
+
+	output, err = runConfigCmd(t, "apply", bundlePath, "--local", "--yes", "--on-conflict", "keep")
+	if err != nil || !strings.Contains(output, "Nothing to apply") {
+		t.Errorf("second apply: %v\n%s", err, output)
+	}
+}
+
+func TestConfigApply_PromptConflicts(t *testing.T) {
+	t.Parallel()
+	items := []*applyItem{
+		{key: "roles", category: "role", name: "dev", expr: ast.NewString("a"), current: ast.NewString("b")},
+		{key: "settings", category: "setting", name: "timeout", expr: ast.NewLit(0, "60"), current: ast.NewLit(0, "30")},
+	}
+	var out bytes.Buffer
+	r := bufio.NewReader(strings.NewReader("x\nn\nr\nqa\nmy-dev\n"))
+	if err := promptConflicts(&out, r, items); err != nil {
+		t.Fatal(err)
+	}
+	if items[0].action != conflictRename || items[1].action != conflictReplace {
+		t.Fatalf("actions = %q, %q", items[0].action, items[1].action)
+	}
+	if !strings.Contains(out.String(), "Enter one of") {
+		t.Errorf("invalid answer not reported:\n%s", out.String())
+	}
+
+	taken := map[string]bool{"roles.dev": true, "roles.qa": true}
+	if err := chooseRenames(&out, r, items, taken, true); err != nil {
+		t.Fatal(err)
+	}
+	if items[0].newName != "my-dev" {
+		t.Errorf("newName = %q, want my-dev", items[0].newName)
+	}
+	if !strings.Contains(out.String(), `role "qa" already exists`) {
+		t.Errorf("taken name not reported:\n%s", out.String())
+	}
+}
+
+func TestConfigApply_MissingTargetDir(t *testing.T) {
+	globalDir := setupConfigFlagsTest(t)
+	localDir := filepath.Join(filepath.Dir(globalDir), ".start")
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(globalDir, "roles.cue"), []byte(`roles: dev: prompt: "You are a developer."
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "team.tar.gz")
+	if output, err := runConfigCmd(t, "pack", bundlePath); err != nil {
+		t.Fatalf("pack: %v\n%s", err, output)
+	}
+
+	// The project has no .start/ directory yet
+	output, err := runConfigCmd(t, "apply", bundlePath, "--local", "--dry-run")
+	if err != nil {
+		t.Fatalf("apply --dry-run: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "+ role dev") {
+		t.Errorf("dry run output missing the new role:\n%s", output)
+	}
+	if _, err := os.Stat(localDir); !os.IsNotExist(err) {
+		t.Error("dry run should not create the local config directory")
+	}
+
+	output, err = runConfigCmd(t, "apply", bundlePath, "--local", "--yes")
+	if err != nil {
+		t.Fatalf("apply: %v\n%s", err, output)
+	}
+	roles, _, err := loadRolesFromDir(localDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roles["dev"].Prompt != "You are a developer." {
+		t.Errorf("local dev role after apply = %q", roles["dev"].Prompt)
+	}
+}
@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/grantcarthew/start/internal/config"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// BlameEntry describes where an item or setting is defined.
+type BlameEntry struct {
+	Category   string          `json:"category"` // "setting", "agent", "role", "context", or "task"
+	Name       string          `json:"name"`
+	Status     string          `json:"status"` // "effective" or "disabled"
+	Definition BlameLocation   `json:"definition"`
+	Overridden []BlameLocation `json:"overridden,omitempty"` // Lower layers, highest first
+}
+
+// BlameLocation is a definition and the config layer it belongs to.
+type BlameLocation struct {
+	Source string `json:"source"` // Layer source, e.g. "global" or "local"
+	internalcue.Definition
+}
+
+// blameCategories are the top-level keys in blame output order.
+var blameCategories = []struct {
+	key      string
+	category string
+}{
+	{internalcue.KeySettings, "setting"},
+	{internalcue.KeyAgents, "agent"},
+	{internalcue.KeyRoles, "role"},
+	{internalcue.KeyContexts, "context"},
+	{internalcue.KeyTasks, "task"},
+}
+
+// addConfigBlameCommand adds the "config blame [name]" command.
+func addConfigBlameCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "blame [name]",
+		Short: "Show which file defines each item and setting",
+		Long: `Show the file and line that defines the effective value of each agent,
+role, context, task, and setting after merging all config layers.
+
+Definitions in lower layers that the effective one overrides (or merges into
+with merge: true) are listed beneath it. Items disabled with enabled: false
+are shown with the layer that disabled them. Settings set by a START_<KEY>
+environment variable or locked by policy are attributed to it, as in
+'start config settings'.
+
+With a name, shows only items and settings with that exact name.
+
+Examples:
+  start config blame              Show every item and setting
+  start config blame reviewer     Show where reviewer is defined
+  start config blame timeout      Show where the timeout setting is set
+  start config blame --json       Output as JSON`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runConfigBlame,
+	}
+	cmd.Flags().Bool("json", false, "Output as JSON")
+	parent.AddCommand(cmd)
+}
+
+// runConfigBlame prints the definition of each item and setting.
+func runConfigBlame(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	scope := getFlags(cmd).Scope()
+	result, err := loadConfig(scope)
+	if err != nil {
+		return err
+	}
+	overrides, err := settingOverrides(scope)
+	if err != nil {
+		return err
+	}
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	entries := collectBlameEntries(result, overrides, name)
+	if name != "" && len(entries) == 0 {
+		return fmt.Errorf("%q not found", name)
+	}
+
+	w := cmd.OutOrStdout()
+	if jsonFlag, _ := cmd.Flags().GetBool("json"); jsonFlag {
+		if entries == nil {
+			entries = []BlameEntry{}
+		}
+		if err := writeJSON(w, entries); err != nil {
+			return fmt.Errorf("writing JSON: %w", err)
+		}
+		return nil
+	}
+	printBlameEntries(w, entries)
+	return nil
+}
+
+// settingOverrides returns the settings whose effective value comes from a
+// START_<KEY> variable or a policy lock rather than a config file, as
+// resolved for 'config settings'. Only the merged scope has overrides.
+func settingOverrides(scope config.Scope) (map[string]BlameLocation, error) {
+	overrides := make(map[string]BlameLocation)
+	if scope != config.ScopeMerged {
+		return overrides, nil
+	}
+	paths, err := config.ResolvePaths("")
+	if err != nil {
+		return nil, fmt.Errorf("resolving config paths: %w", err)
+	}
+	settings, err := config.ResolveAllSettings(paths, scope)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := config.LoadPolicy(paths)
+	if err != nil {
+		return nil, err
+	}
+	for key, entry := range settings {
+		switch entry.Source {
+		case config.SourceEnv:
+			overrides[key] = BlameLocation{Source: entry.Source, Definition: internalcue.Definition{File: config.SettingEnvVar(key)}}
+		case config.SourcePolicy:
+			overrides[key] = BlameLocation{Source: entry.Source, Definition: internalcue.Definition{Dir: policy.Locked[key].Dir}}
+		}
+	}
+	return overrides, nil
+}
+
+// collectBlameEntries returns blame entries for every defined item and
+// setting, or only those named name when it is not empty. A setting in
+// overrides is attributed to it, and every file definition is overridden.
+func collectBlameEntries(result internalcue.LoadResult, overrides map[string]BlameLocation, name string) []BlameEntry {
+	sources := layerSources()
+	locate := func(d internalcue.Definition) BlameLocation {
+		return BlameLocation{Source: sources[d.Dir], Definition: d}
+	}
+
+	var entries []BlameEntry
+	for _, cat := range blameCategories {
+		names := result.DefinedNames(cat.key)
+		if cat.key == internalcue.KeySettings {
+			for key := range overrides {
+				if !slices.Contains(names, key) {
+					names = append(names, key)
+				}
+			}
+			sort.Strings(names)
+		}
+		for _, n := range names {
+			if name != "" && n != name {
+				continue
+			}
+			defs := result.Definitions(cat.key, n)
+			override, hasOverride := overrides[n]
+			if cat.key != internalcue.KeySettings {
+				hasOverride = false
+			}
+			if len(defs) == 0 && !hasOverride {
+				continue
+			}
+			entry := BlameEntry{
+				Category: cat.category,
+				Name:     n,
+				Status:   "effective",
+			}
+			if hasOverride {
+				entry.Definition = override
+			} else {
+				entry.Definition = locate(defs[len(defs)-1])
+				defs = defs[:len(defs)-1]
+			}
+			for i := len(defs) - 1; i >= 0; i-- {
+				entry.Overridden = append(entry.Overridden, locate(defs[i]))
+			}
+			if cat.key != internalcue.KeySettings &&
+				!result.Value.LookupPath(cue.MakePath(cue.Str(cat.key), cue.Str(n))).Exists() {
+				entry.Status = "disabled"
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// layerSources maps each config layer directory to its source label
+// (e.g. "global" or "local"). Returns an empty map if paths cannot be resolved.
+func layerSources() map[string]string {
+	sources := make(map[string]string)
+	paths, err := config.ResolvePaths("")
+	if err != nil {
+		return sources
+	}
+	for _, l := range paths.Layers(config.ScopeMerged) {
+		sources[l.Dir] = l.Source
+	}
+	return sources
+}
+
+// printBlameEntries prints entries grouped by category.
+func printBlameEntries(w io.Writer, entries []BlameEntry) {
+	if len(entries) == 0 {
+		_, _ = tui.ColorDim.Fprintln(w, "No configuration found")
+		return
+	}
+
+	width := 0
+	for _, e := range entries {
+		width = max(width, len(e.Name))
+	}
+
+	category := ""
+	for _, e := range entries {
+		if e.Category != category {
+			if category != "" {
+				_, _ = fmt.Fprintln(w)
+			}
+			category = e.Category
+			plural := category + "s"
+			_, _ = tui.CategoryColor(plural).Fprint(w, plural)
+			_, _ = fmt.Fprintln(w, "/")
+		}
+
+		status := e.Definition.Source
+		if e.Status == "disabled" {
+			status = "disabled by " + status
+		}
+		_, _ = fmt.Fprintf(w, "  %-*s  %s ", width, e.Name, shortenHome(e.Definition.Position()))
+		_, _ = fmt.Fprintln(w, tui.Annotate("%s", status))
+		for _, o := range e.Overridden {
+			_, _ = fmt.Fprintf(w, "  %s  ", strings.Repeat(" ", width))
+			_, _ = tui.ColorDim.Fprintf(w, "overrides %s ", shortenHome(o.Position()))
+			_, _ = fmt.Fprintln(w, tui.Annotate("%s", o.Source))
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grantcarthew/start/internal/config"
+)
+
+// setupBlameConfig creates a global gemini agent that the local config
+// overrides, and returns the global and local file paths.
+func setupBlameConfig(t *testing.T) (string, string) {
+	t.Helper()
+	dir := setupTestConfig(t)
+	globalDir := filepath.Join(dir, "config", "start")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Dir(globalDir))
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	globalFile := filepath.Join(globalDir, "agents.cue")
+	if err := os.WriteFile(globalFile,
+		[]byte("agents: gemini: {bin: \"gemini\", command: \"gemini\"}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	localFile := filepath.Join(dir, ".start", "gemini.cue")
+	if err := os.WriteFile(localFile,
+		[]byte("\nagents: gemini: {bin: \"gemini\", command: \"gemini --yolo\"}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return globalFile, localFile
+}
+
+func TestConfigBlame(t *testing.T) {
+	globalFile, localFile := setupBlameConfig(t)
+
+	buf := new(bytes.Buffer)
+	cmd := NewRootCmd()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"config", "blame", "gemini"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, shortenHome(localFile)+":2 (local)") {
+		t.Errorf("output missing local definition %s:2\ngot:\n%s", localFile, output)
+	}
+	if !strings.Contains(output, "overrides "+shortenHome(globalFile)+":1 (global)") {
+		t.Errorf("output missing overridden global definition %s:1\ngot:\n%s", globalFile, output)
+	}
+}
+
+func TestConfigBlame_JSON(t *testing.T) {
+	globalFile, localFile := setupBlameConfig(t)
+
+	buf := new(bytes.Buffer)
+	cmd := NewRootCmd()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"config", "blame", "gemini", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var entries []BlameEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1: %+v", len(entries), entries)
+	}
+	e := entries[0]
+	if e.Category != "agent" || e.Status != "effective" {
+		t.Errorf("entry = %+v, want effective agent", e)
+	}
+	if e.Definition.File != localFile || e.Definition.Line != 2 || e.Definition.Source != "local" {
+		t.Errorf("definition = %+v, want %s:2 (local)", e.Definition, localFile)
+	}
+	if len(e.Overridden) != 1 || e.Overridden[0].File != globalFile || e.Overridden[0].Source != "global" {
+		t.Errorf("overridden = %+v, want %s (global)", e.Overridden, globalFile)
+	}
+}
+
+func TestConfigBlame_NotFound(t *testing.T) {
+	setupTestConfig(t)
+
+	cmd := NewRootCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"config", "blame", "nonexistent"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `"nonexistent" not found`) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestConfigListJSON_DefinedAt(t *testing.T) {
+	_, localFile := setupBlameConfig(t)
+
+	buf := new(bytes.Buffer)
+	cmd := NewRootCmd()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"config", "list", "agent", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var items []ConfigListItem
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &items); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	for _, item := range items {
+		if item.Name == "gemini" {
+			if want := localFile + ":2"; item.DefinedAt != want {
+				t.Errorf("definedAt = %q, want %q", item.DefinedAt, want)
+			}
+			return
+		}
+	}
+	t.Errorf("gemini not in config list output:\n%s", buf.String())
+}
+
+func TestConfigBlame_EnvOverride(t *testing.T) {
+	dir := setupTestConfig(t)
+	localFile := filepath.Join(dir, ".start", "settings.cue")
+	if err := os.WriteFile(localFile, []byte("settings: timeout: 30\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("START_TIMEOUT", "90")
+
+	buf := new(bytes.Buffer)
+	cmd := NewRootCmd()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"config", "blame", "timeout", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var entries []BlameEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1: %+v", len(entries), entries)
+	}
+	e := entries[0]
+	if e.Definition.Source != config.SourceEnv || e.Definition.File != "START_TIMEOUT" {
+		t.Errorf("definition = %+v, want START_TIMEOUT", e.Definition)
+	}
+	if len(e.Overridden) != 1 || e.Overridden[0].File != localFile {
+		t.Errorf("overridden = %+v, want %s", e.Overridden, localFile)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/grantcarthew/start/internal/config"
+	"github.com/grantcarthew/start/internal/orchestration"
+	"github.com/grantcarthew/start/internal/suggest"
 	"github.com/grantcarthew/start/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -16,16 +19,30 @@ import (
 func addConfigEditCommand(parent *cobra.Command) {
 	cmd := &cobra.Command{
 		Use:   "edit [query]",
-		Short: "Edit a config item interactively",
-		Long: `Edit an agent, role, context, or task interactively.
+		Short: "Edit a config item",
+		Long: `Edit an agent, role, context, or task.
 
 Search by name across all categories. If multiple items match, a numbered
 menu is presented. With no argument, prompts interactively for category and item.
 
-Always interactive — use 'start config open' to edit CUE files directly.`,
+Use --set field=value to change fields without prompting. The query must
+match a single item. Fields use their config names:
+  All categories:  description, tags (comma-separated)
+  Agents:          bin, command, default_model, models.<alias>, instructions_file
+  Roles:           file, command, prompt, optional
+  Contexts:        file, command, prompt, required, default
+  Tasks:           file, command, prompt, role
+An empty value clears a field. Setting one of file, command, or prompt clears
+the other two. The result is checked with the same rules as interactive edits.
+
+Examples:
+  start config edit claude --set default_model=opus
+  start config edit claude --set models.fast=claude-haiku-4 --set default_model=fast
+  start config edit go-reviewer --set file=./ROLE.md --set tags=go,review`,
 		Args: cobra.MaximumNArgs(1),
-		RunE: runConfigEdit,
+		RunE: withBackup(runConfigEdit),
 	}
+	cmd.Flags().StringArray("set", nil, "Set a field as field=value without prompting (repeatable)")
 	parent.AddCommand(cmd)
 }
 
@@ -37,18 +54,23 @@ func runConfigEdit(cmd *cobra.Command, args []string) error {
 
 	stdin := cmd.InOrStdin()
 	stdout := cmd.OutOrStdout()
-	local := getFlags(cmd).Local
+	scope := getFlags(cmd).Scope()
+	sets, _ := cmd.Flags().GetStringArray("set")
+
+	if len(sets) > 0 && len(args) == 0 {
+		return fmt.Errorf("--set requires the name of the item to edit")
+	}
 
 	if len(args) == 0 {
 		if !isTerminal(stdin) {
 			return fmt.Errorf("interactive edit requires a terminal")
 		}
-		return runConfigEditInteractive(stdin, stdout, local)
+		return runConfigEditInteractive(stdin, stdout, scope)
 	}
 
 	_, _ = fmt.Fprintln(stdout)
 	query := args[0]
-	matches, err := searchAllConfigCategories(query, local)
+	matches, err := searchAllConfigCategories(query, scope)
 	if err != nil {
 		return err
 	}
@@ -60,6 +82,8 @@ func runConfigEdit(cmd *cobra.Command, args []string) error {
 	var selected configMatch
 	if len(matches) == 1 {
 		selected = matches[0]
+	} else if len(sets) > 0 {
+		return fmt.Errorf("ambiguous query %q matches multiple items — use an exact name", query)
 	} else {
 		if !isTerminal(stdin) {
 			return fmt.Errorf("ambiguous query %q matches multiple items — use an exact name", query)
@@ -70,15 +94,19 @@ func runConfigEdit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(sets) > 0 {
+		return configEditSet(stdout, scope, selected.Category, selected.Name, sets)
+	}
+
 	if !isTerminal(stdin) {
 		return fmt.Errorf("editing %q requires a terminal — use 'start config open' to edit the CUE file directly", selected.Name)
 	}
 
-	return configEditByCategory(stdin, stdout, local, selected.Category, selected.Name)
+	return configEditByCategory(stdin, stdout, scope, selected.Category, selected.Name)
 }
 
 // runConfigEditInteractive prompts for category then item, then edits.
-func runConfigEditInteractive(stdin io.Reader, stdout io.Writer, local bool) error {
+func runConfigEditInteractive(stdin io.Reader, stdout io.Writer, scope config.Scope) error {
 	_, _ = fmt.Fprintln(stdout)
 	_, _ = fmt.Fprintln(stdout, "Edit:")
 	category, err := promptSelectCategory(stdout, stdin, allConfigCategories)
@@ -86,7 +114,7 @@ func runConfigEditInteractive(stdin io.Reader, stdout io.Writer, local bool) err
 		return err
 	}
 
-	names, err := loadNamesForCategory(category, local)
+	names, err := loadNamesForCategory(category, scope)
 	if err != nil {
 		return err
 	}
@@ -102,33 +130,33 @@ func runConfigEditInteractive(stdin io.Reader, stdout io.Writer, local bool) err
 		return err
 	}
 
-	return configEditByCategory(stdin, stdout, local, singular, selected)
+	return configEditByCategory(stdin, stdout, scope, singular, selected)
 }
 
 // configEditByCategory dispatches to the appropriate category edit function.
-func configEditByCategory(stdin io.Reader, stdout io.Writer, local bool, category, name string) error {
+func configEditByCategory(stdin io.Reader, stdout io.Writer, scope config.Scope, category, name string) error {
 	switch category {
 	case "agent":
-		return configAgentEdit(stdin, stdout, local, name)
+		return configAgentEdit(stdin, stdout, scope, name)
 	case "role":
-		return configRoleEdit(stdin, stdout, local, name)
+		return configRoleEdit(stdin, stdout, scope, name)
 	case "context":
-		return configContextEdit(stdin, stdout, local, name)
+		return configContextEdit(stdin, stdout, scope, name)
 	case "task":
-		return configTaskEdit(stdin, stdout, local, name)
+		return configTaskEdit(stdin, stdout, scope, name)
 	}
 	return fmt.Errorf("unknown category %q", category)
 }
 
 // configAgentEdit is the inner edit logic for agents.
-func configAgentEdit(stdin io.Reader, stdout io.Writer, local bool, name string) error {
+func configAgentEdit(stdin io.Reader, stdout io.Writer, scope config.Scope, name string) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
 	// Resolve from all scopes so we find the agent regardless of which dir it lives in.
-	allAgents, _, err := loadAgentsForScope(local)
+	allAgents, _, err := loadAgentsForScope(scope)
 	if err != nil {
 		return fmt.Errorf("loading agents: %w", err)
 	}
@@ -138,7 +166,7 @@ func configAgentEdit(stdin io.Reader, stdout io.Writer, local bool, name string)
 	}
 
 	// Edit in the dir where the agent actually lives.
-	configDir := paths.Dir(agent.Source == "local")
+	configDir := paths.SourceDir(agent.Source)
 	agentPath := filepath.Join(configDir, "agents.cue")
 	dirAgents, _, err := loadAgentsFromDir(configDir)
 	if err != nil {
@@ -203,13 +231,13 @@ func configAgentEdit(stdin io.Reader, stdout io.Writer, local bool, name string)
 }
 
 // configRoleEdit is the inner edit logic for roles.
-func configRoleEdit(stdin io.Reader, stdout io.Writer, local bool, name string) error {
+func configRoleEdit(stdin io.Reader, stdout io.Writer, scope config.Scope, name string) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	allRoles, _, err := loadRolesForScope(local)
+	allRoles, _, err := loadRolesForScope(scope)
 	if err != nil {
 		return fmt.Errorf("loading roles: %w", err)
 	}
@@ -218,7 +246,7 @@ func configRoleEdit(stdin io.Reader, stdout io.Writer, local bool, name string)
 		return err
 	}
 
-	configDir := paths.Dir(role.Source == "local")
+	configDir := paths.SourceDir(role.Source)
 	rolePath := filepath.Join(configDir, "roles.cue")
 	dirRoles, order, err := loadRolesFromDir(configDir)
 	if err != nil {
@@ -307,13 +335,13 @@ func configRoleEdit(stdin io.Reader, stdout io.Writer, local bool, name string)
 }
 
 // configContextEdit is the inner edit logic for contexts.
-func configContextEdit(stdin io.Reader, stdout io.Writer, local bool, name string) error {
+func configContextEdit(stdin io.Reader, stdout io.Writer, scope config.Scope, name string) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	allContexts, _, err := loadContextsForScope(local)
+	allContexts, _, err := loadContextsForScope(scope)
 	if err != nil {
 		return fmt.Errorf("loading contexts: %w", err)
 	}
@@ -322,7 +350,7 @@ func configContextEdit(stdin io.Reader, stdout io.Writer, local bool, name strin
 		return err
 	}
 
-	configDir := paths.Dir(ctx.Source == "local")
+	configDir := paths.SourceDir(ctx.Source)
 	contextPath := filepath.Join(configDir, "contexts.cue")
 	dirContexts, order, err := loadContextsFromDir(configDir)
 	if err != nil {
@@ -420,13 +448,13 @@ func configContextEdit(stdin io.Reader, stdout io.Writer, local bool, name strin
 }
 
 // configTaskEdit is the inner edit logic for tasks.
-func configTaskEdit(stdin io.Reader, stdout io.Writer, local bool, name string) error {
+func configTaskEdit(stdin io.Reader, stdout io.Writer, scope config.Scope, name string) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	allTasks, _, err := loadTasksForScope(local)
+	allTasks, _, err := loadTasksForScope(scope)
 	if err != nil {
 		return fmt.Errorf("loading tasks: %w", err)
 	}
@@ -435,7 +463,7 @@ func configTaskEdit(stdin io.Reader, stdout io.Writer, local bool, name string)
 		return err
 	}
 
-	configDir := paths.Dir(task.Source == "local")
+	configDir := paths.SourceDir(task.Source)
 	taskPath := filepath.Join(configDir, "tasks.cue")
 	dirTasks, _, err := loadTasksFromDir(configDir)
 	if err != nil {
@@ -505,3 +533,286 @@ func configTaskEdit(stdin io.Reader, stdout io.Writer, local bool, name string)
 	_, _ = fmt.Fprintf(stdout, "\nUpdated task %q\n", resolvedName)
 	return nil
 }
+
+// fieldAssignment is a field=value pair given with --set.
+type fieldAssignment struct {
+	field string
+	value string
+}
+
+// editFields lists the fields --set accepts for each category.
+var editFields = map[string][]string{
+	"agent":   {"description", "tags", "bin", "command", "default_model", "models.<alias>", "instructions_file"},
+	"role":    {"description", "tags", "file", "command", "prompt", "optional"},
+	"context": {"description", "tags", "file", "command", "prompt", "required", "default"},
+	"task":    {"description", "tags", "file", "command", "prompt", "role"},
+}
+
+// parseFieldAssignments parses --set values of the form field=value.
+func parseFieldAssignments(sets []string) ([]fieldAssignment, error) {
+	assignments := make([]fieldAssignment, 0, len(sets))
+	for _, set := range sets {
+		field, value, ok := strings.Cut(set, "=")
+		field = strings.TrimSpace(field)
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid --set %q: use field=value", set)
+		}
+		assignments = append(assignments, fieldAssignment{field: field, value: value})
+	}
+	return assignments, nil
+}
+
+// configEditSet applies --set assignments to an item and writes it back to
+// the config directory it lives in, without prompting.
+func configEditSet(stdout io.Writer, scope config.Scope, category, name string, sets []string) error {
+	assignments, err := parseFieldAssignments(sets)
+	if err != nil {
+		return err
+	}
+
+	paths, err := config.ResolvePaths("")
+	if err != nil {
+		return fmt.Errorf("resolving config paths: %w", err)
+	}
+
+	var resolvedName string
+	switch category {
+	case "agent":
+		allAgents, _, err := loadAgentsForScope(scope)
+		if err != nil {
+			return fmt.Errorf("loading agents: %w", err)
+		}
+		var agent AgentConfig
+		resolvedName, agent, err = resolveInstalledName(allAgents, "agent", name)
+		if err != nil {
+			return err
+		}
+		configDir := paths.SourceDir(agent.Source)
+		dirAgents, _, err := loadAgentsFromDir(configDir)
+		if err != nil {
+			return fmt.Errorf("loading agents: %w", err)
+		}
+		for _, a := range assignments {
+			if err := setAgentField(&agent, a.field, a.value); err != nil {
+				return err
+			}
+		}
+		if agent.Command == "" {
+			return fmt.Errorf("command template is required")
+		}
+		if err := orchestration.ValidateCommandTemplate(agent.Command); err != nil {
+			return fmt.Errorf("invalid command template: %w", err)
+		}
+		if err := checkDefaultModel(agent.DefaultModel, agent.Models); err != nil {
+			return err
+		}
+		dirAgents[resolvedName] = agent
+		if err := writeAgentsFile(filepath.Join(configDir, "agents.cue"), dirAgents); err != nil {
+			return fmt.Errorf("writing agents file: %w", err)
+		}
+
+	case "role":
+		allRoles, _, err := loadRolesForScope(scope)
+		if err != nil {
+			return fmt.Errorf("loading roles: %w", err)
+		}
+		var role RoleConfig
+		resolvedName, role, err = resolveInstalledName(allRoles, "role", name)
+		if err != nil {
+			return err
+		}
+		configDir := paths.SourceDir(role.Source)
+		dirRoles, order, err := loadRolesFromDir(configDir)
+		if err != nil {
+			return fmt.Errorf("loading roles: %w", err)
+		}
+		for _, a := range assignments {
+			var err error
+			switch a.field {
+			case "description", "tags", "file", "command", "prompt":
+				setContentField(a.field, a.value, &role.Description, &role.Tags, &role.File, &role.Command, &role.Prompt)
+			case "optional":
+				role.Optional, err = parseFieldBool(a.field, a.value)
+			default:
+				err = unknownFieldError(category, a.field)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := checkContentSource(role.File, role.Command, role.Prompt); err != nil {
+			return err
+		}
+		if role.Optional && role.File == "" {
+			return fmt.Errorf("optional applies only to roles with a file")
+		}
+		dirRoles[resolvedName] = role
+		if err := writeRolesFile(filepath.Join(configDir, "roles.cue"), dirRoles, order); err != nil {
+			return fmt.Errorf("writing roles file: %w", err)
+		}
+
+	case "context":
+		allContexts, _, err := loadContextsForScope(scope)
+		if err != nil {
+			return fmt.Errorf("loading contexts: %w", err)
+		}
+		var ctx ContextConfig
+		resolvedName, ctx, err = resolveInstalledName(allContexts, "context", name)
+		if err != nil {
+			return err
+		}
+		configDir := paths.SourceDir(ctx.Source)
+		dirContexts, order, err := loadContextsFromDir(configDir)
+		if err != nil {
+			return fmt.Errorf("loading contexts: %w", err)
+		}
+		for _, a := range assignments {
+			var err error
+			switch a.field {
+			case "description", "tags", "file", "command", "prompt":
+				setContentField(a.field, a.value, &ctx.Description, &ctx.Tags, &ctx.File, &ctx.Command, &ctx.Prompt)
+			case "required":
+				ctx.Required, err = parseFieldBool(a.field, a.value)
+			case "default":
+				ctx.Default, err = parseFieldBool(a.field, a.value)
+			default:
+				err = unknownFieldError(category, a.field)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := checkContentSource(ctx.File, ctx.Command, ctx.Prompt); err != nil {
+			return err
+		}
+		dirContexts[resolvedName] = ctx
+		if err := writeContextsFile(filepath.Join(configDir, "contexts.cue"), dirContexts, order); err != nil {
+			return fmt.Errorf("writing contexts file: %w", err)
+		}
+
+	case "task":
+		allTasks, _, err := loadTasksForScope(scope)
+		if err != nil {
+			return fmt.Errorf("loading tasks: %w", err)
+		}
+		var task TaskConfig
+		resolvedName, task, err = resolveInstalledName(allTasks, "task", name)
+		if err != nil {
+			return err
+		}
+		configDir := paths.SourceDir(task.Source)
+		dirTasks, _, err := loadTasksFromDir(configDir)
+		if err != nil {
+			return fmt.Errorf("loading tasks: %w", err)
+		}
+		for _, a := range assignments {
+			var err error
+			switch a.field {
+			case "description", "tags", "file", "command", "prompt":
+				setContentField(a.field, a.value, &task.Description, &task.Tags, &task.File, &task.Command, &task.Prompt)
+			case "role":
+				task.Role = a.value
+			default:
+				err = unknownFieldError(category, a.field)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := checkContentSource(task.File, task.Command, task.Prompt); err != nil {
+			return err
+		}
+		dirTasks[resolvedName] = task
+		if err := writeTasksFile(filepath.Join(configDir, "tasks.cue"), dirTasks); err != nil {
+			return fmt.Errorf("writing tasks file: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unknown category %q", category)
+	}
+
+	_, _ = fmt.Fprintf(stdout, "Updated %s %q\n", category, resolvedName)
+	return nil
+}
+
+// setAgentField sets a single agent field from a --set assignment. An empty
+// value removes a model alias.
+func setAgentField(agent *AgentConfig, field, value string) error {
+	if alias, ok := strings.CutPrefix(field, "models."); ok {
+		if alias == "" {
+			return fmt.Errorf("invalid field %q: use models.<alias>", field)
+		}
+		if value == "" {
+			delete(agent.Models, alias)
+			return nil
+		}
+		if agent.Models == nil {
+			agent.Models = make(map[string]string)
+		}
+		agent.Models[alias] = value
+		return nil
+	}
+
+	switch field {
+	case "description":
+		agent.Description = value
+	case "tags":
+		agent.Tags = splitTags(value)
+	case "bin":
+		agent.Bin = value
+	case "command":
+		agent.Command = value
+	case "default_model":
+		agent.DefaultModel = value
+	case "instructions_file":
+		agent.Instructions = value
+	default:
+		return unknownFieldError("agent", field)
+	}
+	return nil
+}
+
+// setContentField sets a field shared by roles, contexts, and tasks. Setting
+// a content source clears the other two, as choosing one interactively does.
+func setContentField(field, value string, description *string, tags *[]string, file, command, prompt *string) {
+	switch field {
+	case "description":
+		*description = value
+	case "tags":
+		*tags = splitTags(value)
+	case "file", "command", "prompt":
+		if value != "" {
+			*file, *command, *prompt = "", "", ""
+		}
+		switch field {
+		case "file":
+			*file = value
+		case "command":
+			*command = value
+		case "prompt":
+			*prompt = value
+		}
+	}
+}
+
+// parseFieldBool parses a boolean --set value.
+func parseFieldBool(field, value string) (bool, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: use true or false", field, value)
+	}
+	return b, nil
+}
+
+// unknownFieldError reports a --set field the category does not have,
+// suggesting the closest known field.
+func unknownFieldError(category, field string) error {
+	known := editFields[category]
+	msg := fmt.Sprintf("unknown %s field %q", category, field)
+	if s := suggest.Closest(field, known); s != "" {
+		msg += fmt.Sprintf("; did you mean %q?", s)
+	} else {
+		msg += fmt.Sprintf("; expected one of: %s", strings.Join(known, ", "))
+	}
+	return fmt.Errorf("%s", msg)
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigEdit_Set(t *testing.T) {
+	globalDir := setupConfigFlagsTest(t)
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"agents.cue":   `agents: claude: {bin: "claude", command: "claude", models: {opus: "claude-opus-4"}}`,
+		"roles.cue":    `roles: reviewer: {file: "./ROLE.md", optional: true}`,
+		"contexts.cue": `contexts: rules: prompt: "Use tabs."`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(globalDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := runConfigCmd(t, "edit", "claude", "--set", "models.fast=claude-haiku-4", "--set", "default_model=fast", "--set", "models.opus=")
+	if err != nil {
+		t.Fatalf("edit agent: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, `Updated agent "claude"`) {
+		t.Errorf("output = %q", output)
+	}
+	agents, _, err := loadAgentsFromDir(globalDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent := agents["claude"]
+	if agent.DefaultModel != "fast" || agent.Models["fast"] != "claude-haiku-4" || agent.Models["opus"] != "" {
+		t.Errorf("agent = %+v", agent)
+	}
+
+	if output, err := runConfigCmd(t, "edit", "reviewer", "--set", "prompt=Review carefully", "--set", "optional=false", "--set", "tags=go,review"); err != nil {
+		t.Fatalf("edit role: %v\n%s", err, output)
+	}
+	roles, _, err := loadRolesFromDir(globalDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	role := roles["reviewer"]
+	if role.File != "" || role.Prompt != "Review carefully" || role.Optional || len(role.Tags) != 2 {
+		t.Errorf("setting prompt should replace the file source: %+v", role)
+	}
+
+	if output, err := runConfigCmd(t, "edit", "rules", "--set", "default=true"); err != nil {
+		t.Fatalf("edit context: %v\n%s", err, output)
+	}
+	contexts, _, err := loadContextsFromDir(globalDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contexts["rules"].Default {
+		t.Errorf("context = %+v", contexts["rules"])
+	}
+}
+
+func TestConfigEdit_SetErrors(t *testing.T) {
+	globalDir := setupConfigFlagsTest(t)
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `agents: claude: {bin: "claude", command: "claude", models: {opus: "claude-opus-4"}}
+roles: reviewer: prompt: "Review."
+`
+	if err := os.WriteFile(filepath.Join(globalDir, "config.cue"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"no name", []string{"edit", "--set", "bin=x"}, "--set requires the name"},
+		{"bad assignment", []string{"edit", "claude", "--set", "bin"}, "use field=value"},
+		{"unknown field", []string{"edit", "claude", "--set", "defualt_model=opus"}, `did you mean "default_model"?`},
+		{"empty command", []string{"edit", "claude", "--set", "command="}, "command template is required"},
+		{"quoted placeholder", []string{"edit", "claude", "--set", `command=claude "{{.prompt}}"`}, "quoted placeholder"},
+		{"unknown default model", []string{"edit", "claude", "--set", "default_model=fast"}, "not a model alias"},
+		{"clear content source", []string{"edit", "reviewer", "--set", "prompt="}, "must specify one of"},
+		{"bad bool", []string{"edit", "reviewer", "--set", "optional=maybe"}, "use true or false"},
+		{"optional without file", []string{"edit", "reviewer", "--set", "optional=true"}, "optional applies only to roles with a file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runConfigCmd(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	after, err := os.ReadFile(filepath.Join(globalDir, "config.cue"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != content {
+		t.Errorf("failed edits should not change config:\n%s", after)
+	}
+}
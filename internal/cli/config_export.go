@@ -1,11 +1,16 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"cuelang.org/go/cue"
+	cueyaml "cuelang.org/go/encoding/yaml"
 	"github.com/grantcarthew/start/internal/config"
 	"github.com/spf13/cobra"
 )
@@ -20,10 +25,24 @@ func addConfigExportCommand(parent *cobra.Command) {
 Provide a category (agent, role, context, task, setting) to export a single
 file, or omit it to export all config files. Plural aliases are accepted.
 
-Use --local to target project-specific configuration (.start/).`,
+Use --local to target project-specific configuration (.start/).
+
+Use --format json or --format yaml to export configuration as data instead
+of CUE text. Data exports contain the effective configuration after merging
+all layers; use --local or --global to export a single scope. The output can
+be read back with 'start config import --format <format> <file>'.
+
+Examples:
+  start config export                       Print global CUE files
+  start config export agent --local         Print local agents.cue
+  start config export --format json         Effective config as JSON
+  start config export role --format yaml    Effective roles as YAML
+  start config export --format json --global > config.json`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: runConfigExport,
 	}
+	cmd.Flags().String("format", "cue", "Output format: cue, json, or yaml")
+	cmd.Flags().Bool("global", false, "Export global configuration only (json and yaml formats)")
 	parent.AddCommand(cmd)
 }
 
@@ -33,32 +52,118 @@ func runConfigExport(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	flags := getFlags(cmd)
-	local := flags.Local
+	scope := flags.Scope()
 	w := cmd.OutOrStdout()
 
+	format, _ := cmd.Flags().GetString("format")
+	switch format {
+	case "cue":
+	case "json", "yaml":
+		return exportConfigData(cmd, w, format, args)
+	default:
+		return fmt.Errorf("unknown format %q: expected cue, json, or yaml", format)
+	}
+
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(local)
+	configDir := paths.Dir(scope)
 	exists := paths.GlobalExists
-	if local {
+	switch scope {
+	case config.ScopeLocal:
 		exists = paths.LocalExists
+	case config.ScopePersonal:
+		exists = paths.PersonalExists
 	}
 	if !exists {
 		return fmt.Errorf("no configuration directory found at %s; run start to set up", configDir)
 	}
 
 	if len(args) > 0 {
-		return exportSingleCategory(w, local, args[0])
+		return exportSingleCategory(w, scope, args[0])
 	}
 	return printCueFiles(w, configDir)
 }
 
+// exportConfigData outputs the loaded configuration for the selected scope
+// as JSON or YAML. With a category argument, only that top-level key is
+// exported.
+func exportConfigData(cmd *cobra.Command, w io.Writer, format string, args []string) error {
+	scope, err := showScopeFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	var key string
+	if len(args) > 0 {
+		key, err = categoryConfigKey(args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadConfig(scope)
+	if err != nil {
+		return err
+	}
+
+	v := cfg.Value
+	if key != "" {
+		v = v.LookupPath(cue.ParsePath(key))
+		if !v.Exists() {
+			return fmt.Errorf("no %s configured", key)
+		}
+		// Wrap the category so the output can be imported as-is.
+		v = v.Context().CompileString("{}").FillPath(cue.ParsePath(key), v)
+	}
+
+	data, err := encodeConfigData(v, format)
+	if err != nil {
+		return err
+	}
+	_, _ = w.Write(data)
+	return nil
+}
+
+// encodeConfigData encodes a concrete config value as indented JSON or YAML.
+func encodeConfigData(v cue.Value, format string) ([]byte, error) {
+	if format == "yaml" {
+		data, err := cueyaml.Encode(v)
+		if err != nil {
+			return nil, fmt.Errorf("encoding YAML: %w", err)
+		}
+		return data, nil
+	}
+
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return nil, fmt.Errorf("encoding JSON: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// categoryConfigKey maps a category argument (singular or plural) to its
+// top-level config key.
+func categoryConfigKey(category string) (string, error) {
+	singular := strings.TrimSuffix(strings.ToLower(category), "s")
+	for _, c := range blameCategories {
+		if c.category == singular {
+			return c.key, nil
+		}
+	}
+	return "", fmt.Errorf("unknown category %q: expected agent, role, context, task, or setting", category)
+}
+
 // exportSingleCategory outputs a single CUE config file (no header).
-func exportSingleCategory(w io.Writer, local bool, category string) error {
-	path, err := resolveConfigOpenPath(local, category)
+func exportSingleCategory(w io.Writer, scope config.Scope, category string) error {
+	path, err := resolveConfigOpenPath(scope, category)
 	if err != nil {
 		return err
 	}
@@ -321,3 +321,52 @@ func TestConfigExport_SkipsNonCueFiles(t *testing.T) {
 		t.Error("expected agents.cue in output")
 	}
 }
+
+func TestConfigExport_DataFormats(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	chdir(t, tmpDir)
+
+	globalDir := filepath.Join(tmpDir, "start")
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `agents: claude: {bin: "claude", command: "claude"}
+roles: dev: prompt: "Be careful."
+`
+	if err := os.WriteFile(filepath.Join(globalDir, "config.cue"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"json all", []string{"--format", "json"}, "{\n  \"agents\": {\n    \"claude\": {\n      \"bin\": \"claude\",\n      \"command\": \"claude\"\n    }\n  },\n  \"roles\": {\n    \"dev\": {\n      \"prompt\": \"Be careful.\"\n    }\n  }\n}\n"},
+		{"yaml category", []string{"role", "--format", "yaml", "--global"}, "roles:\n  dev:\n    prompt: Be careful.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewRootCmd()
+			stdout := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(append([]string{"config", "export"}, tt.args...))
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("output =\n%s\nwant\n%s", stdout.String(), tt.want)
+			}
+		})
+	}
+
+	cmd := NewRootCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"config", "export", "--format", "toml"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("expected unknown format error, got %v", err)
+	}
+}
@@ -19,10 +19,10 @@ import (
 
 // loadForScope loads entities from the appropriate scope using a generic merge strategy.
 // Returns the entity map, names in definition order, and any error.
-// Order: global entries first (in definition order), then local entries (in definition order).
-// Local entries override global entries with the same name but retain their global position.
+// Order: entries from each layer, lowest priority first (in definition order).
+// Later layers override earlier entries with the same name but retain their earlier position.
 func loadForScope[T any](
-	localOnly bool,
+	scope config.Scope,
 	loadFromDir func(string) (map[string]T, []string, error),
 	setSource func(*T, string),
 ) (map[string]T, []string, error) {
@@ -35,48 +35,22 @@ func loadForScope[T any](
 	var order []string
 	seen := make(map[string]bool)
 
-	if localOnly {
-		if paths.LocalExists {
-			localItems, localOrder, err := loadFromDir(paths.Local)
-			if err != nil && !os.IsNotExist(err) {
-				return nil, nil, err
-			}
-			for _, name := range localOrder {
-				item := localItems[name]
-				setSource(&item, "local")
-				items[name] = item
-				order = append(order, name)
-			}
+	// Layers from lowest to highest priority; later layers override
+	for _, layer := range paths.Layers(scope) {
+		layerItems, layerOrder, err := loadFromDir(layer.Dir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, nil, err
 		}
-	} else {
-		if paths.GlobalExists {
-			globalItems, globalOrder, err := loadFromDir(paths.Global)
-			if err != nil && !os.IsNotExist(err) {
-				return nil, nil, err
-			}
-			for _, name := range globalOrder {
-				item := globalItems[name]
-				setSource(&item, "global")
-				items[name] = item
+		for _, name := range layerOrder {
+			item := layerItems[name]
+			setSource(&item, layer.Source)
+			items[name] = item
+			// Only add to order if not already present from an earlier layer
+			if !seen[name] {
 				order = append(order, name)
 				seen[name] = true
 			}
 		}
-		if paths.LocalExists {
-			localItems, localOrder, err := loadFromDir(paths.Local)
-			if err != nil && !os.IsNotExist(err) {
-				return nil, nil, err
-			}
-			for _, name := range localOrder {
-				item := localItems[name]
-				setSource(&item, "local")
-				items[name] = item
-				// Only add to order if not already present from global
-				if !seen[name] {
-					order = append(order, name)
-				}
-			}
-		}
 	}
 
 	return items, order, nil
@@ -334,16 +308,18 @@ func promptTags(w io.Writer, r io.Reader, current []string, showCurrent bool) ([
 		return nil, nil
 	}
 
-	// Parse comma-separated tags
+	return splitTags(input), nil
+}
+
+// splitTags parses a comma-separated tag list, dropping empty entries.
+func splitTags(value string) []string {
 	var tags []string
-	for _, t := range strings.Split(input, ",") {
-		t = strings.TrimSpace(t)
-		if t != "" {
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
 			tags = append(tags, t)
 		}
 	}
-
-	return tags, nil
+	return tags
 }
 
 // promptModelsAdd prompts for adding model aliases in the add flow (no existing models).
@@ -451,6 +427,59 @@ func promptModelsEdit(w io.Writer, reader *bufio.Reader, current map[string]stri
 	return result, nil
 }
 
+// parseModelAliases parses alias=model-id pairs as accepted by the
+// interactive model prompt. Returns nil for no pairs.
+func parseModelAliases(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		alias, modelID, ok := strings.Cut(pair, "=")
+		alias = strings.TrimSpace(alias)
+		modelID = strings.TrimSpace(modelID)
+		if !ok || alias == "" || modelID == "" {
+			return nil, fmt.Errorf("invalid model %q: use alias=model-id", pair)
+		}
+		result[alias] = modelID
+	}
+	return result, nil
+}
+
+// checkDefaultModel checks that a default model is one of the model aliases,
+// as the interactive prompt requires when aliases are defined.
+func checkDefaultModel(defaultModel string, models map[string]string) error {
+	if defaultModel == "" || len(models) == 0 {
+		return nil
+	}
+	if _, ok := models[defaultModel]; ok {
+		return nil
+	}
+	aliases := make([]string, 0, len(models))
+	for alias := range models {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return fmt.Errorf("default model %q is not a model alias: expected one of %s", defaultModel, strings.Join(aliases, ", "))
+}
+
+// checkContentSource checks that exactly one of file, command, or prompt is set.
+func checkContentSource(file, command, prompt string) error {
+	sourceCount := 0
+	for _, s := range []string{file, command, prompt} {
+		if s != "" {
+			sourceCount++
+		}
+	}
+	if sourceCount == 0 {
+		return fmt.Errorf("must specify one of: file, command, or prompt")
+	}
+	if sourceCount > 1 {
+		return fmt.Errorf("specify only one of: file, command, or prompt")
+	}
+	return nil
+}
+
 // readModelAliases reads alias=model-id pairs from the reader until an empty line.
 func readModelAliases(w io.Writer, reader *bufio.Reader) (map[string]string, error) {
 	result := make(map[string]string)
@@ -567,18 +596,18 @@ func extractTags(val cue.Value) []string {
 // confirmMultiRemoval prompts the user to confirm removal of one or more config entities.
 // For a single name it mirrors the old single-item prompt. For multiple names it lists
 // them all and asks once. Requires --yes flag in non-interactive mode.
-func confirmMultiRemoval(w io.Writer, r io.Reader, entityType string, names []string, local bool) (bool, error) {
+func confirmMultiRemoval(w io.Writer, r io.Reader, entityType string, names []string, scope config.Scope) (bool, error) {
 	isTTY := isTerminal(r)
 
 	if !isTTY {
 		return false, fmt.Errorf("--yes flag required in non-interactive mode")
 	}
 
-	scope := scopeString(local)
+	scopeName := scopeString(scope)
 	if len(names) == 1 {
-		_, _ = fmt.Fprintf(w, "Remove %s %q from %s config? %s ", entityType, names[0], scope, tui.Bracket("y/N"))
+		_, _ = fmt.Fprintf(w, "Remove %s %q from %s config? %s ", entityType, names[0], scopeName, tui.Bracket("y/N"))
 	} else {
-		_, _ = fmt.Fprintf(w, "Remove the following %ss from %s config?\n", entityType, scope)
+		_, _ = fmt.Fprintf(w, "Remove the following %ss from %s config?\n", entityType, scopeName)
 		for _, name := range names {
 			_, _ = fmt.Fprintf(w, "  - %s\n", name)
 		}
@@ -598,12 +627,13 @@ func confirmMultiRemoval(w io.Writer, r io.Reader, entityType string, names []st
 	return true, nil
 }
 
-// scopeString returns "local" or "global" based on the flag.
-func scopeString(local bool) string {
-	if local {
-		return "local"
+// scopeString returns the name of the config written for a scope: "local",
+// "personal", or "global" for the merged scope.
+func scopeString(scope config.Scope) string {
+	if scope == config.ScopeMerged {
+		return "global"
 	}
-	return "global"
+	return scope.String()
 }
 
 // scoreAndSortNames scores each map key against the compiled patterns and
@@ -861,10 +891,10 @@ type configMatch struct {
 // searchAllConfigCategories searches all four config categories for a query string.
 // Returns all matches across categories tagged with their category name.
 // Zero matches in a category is not an error; the returned slice may be empty.
-func searchAllConfigCategories(query string, local bool) ([]configMatch, error) {
+func searchAllConfigCategories(query string, scope config.Scope) ([]configMatch, error) {
 	var results []configMatch
 
-	agents, _, err := loadAgentsForScope(local)
+	agents, _, err := loadAgentsForScope(scope)
 	if err != nil {
 		return nil, fmt.Errorf("loading agents: %w", err)
 	}
@@ -874,7 +904,7 @@ func searchAllConfigCategories(query string, local bool) ([]configMatch, error)
 		}
 	}
 
-	roles, _, err := loadRolesForScope(local)
+	roles, _, err := loadRolesForScope(scope)
 	if err != nil {
 		return nil, fmt.Errorf("loading roles: %w", err)
 	}
@@ -884,7 +914,7 @@ func searchAllConfigCategories(query string, local bool) ([]configMatch, error)
 		}
 	}
 
-	contexts, _, err := loadContextsForScope(local)
+	contexts, _, err := loadContextsForScope(scope)
 	if err != nil {
 		return nil, fmt.Errorf("loading contexts: %w", err)
 	}
@@ -894,7 +924,7 @@ func searchAllConfigCategories(query string, local bool) ([]configMatch, error)
 		}
 	}
 
-	tasks, _, err := loadTasksForScope(local)
+	tasks, _, err := loadTasksForScope(scope)
 	if err != nil {
 		return nil, fmt.Errorf("loading tasks: %w", err)
 	}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+
+	"github.com/grantcarthew/start/internal/config"
 )
 
 func TestResolveInstalledName(t *testing.T) {
@@ -563,7 +565,7 @@ func TestConfirmMultiRemoval_SingleItem(t *testing.T) {
 	w := &bytes.Buffer{}
 	// Non-TTY reader returns error, so just test the single-item prompt format
 	// via the non-TTY path returning the expected error.
-	_, err := confirmMultiRemoval(w, strings.NewReader(""), "task", []string{"my-task"}, false)
+	_, err := confirmMultiRemoval(w, strings.NewReader(""), "task", []string{"my-task"}, config.ScopeMerged)
 	if err == nil {
 		t.Fatal("expected non-TTY error")
 	}
@@ -574,7 +576,7 @@ func TestConfirmMultiRemoval_SingleItem(t *testing.T) {
 
 func TestConfirmMultiRemoval_MultipleItems_NonTTY(t *testing.T) {
 	w := &bytes.Buffer{}
-	_, err := confirmMultiRemoval(w, strings.NewReader(""), "role", []string{"role-a", "role-b"}, false)
+	_, err := confirmMultiRemoval(w, strings.NewReader(""), "role", []string{"role-a", "role-b"}, config.ScopeMerged)
 	if err == nil {
 		t.Fatal("expected non-TTY error")
 	}
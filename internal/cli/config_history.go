@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/grantcarthew/start/internal/backup"
+	"github.com/grantcarthew/start/internal/config"
+	"github.com/grantcarthew/start/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// addConfigHistoryCommand adds the "config history" command.
+func addConfigHistoryCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List config backups made by earlier commands",
+		Long: `List the backups taken before commands that changed config files.
+
+Commands that rewrite config (config add, edit, remove, order, set, unset,
+import, settings, migrate, apply, and assets add and update) save the files
+they change before changing them. Backups are kept in ~/.local/state/start/backups/
+(or $XDG_STATE_HOME/start/backups/); the newest ` + strconv.Itoa(backup.MaxSnapshots) + ` are kept.
+
+Use 'start config undo' to restore them.`,
+		Args: cobra.NoArgs,
+		RunE: runConfigHistory,
+	}
+	cmd.Flags().Bool("json", false, "Output as JSON")
+	parent.AddCommand(cmd)
+}
+
+// addConfigUndoCommand adds the "config undo [id]" command.
+func addConfigUndoCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "undo [id]",
+		Short: "Restore config files from a backup",
+		Long: `Restore the config files changed by the most recent command.
+
+With an id from 'start config history', undo that command and every command
+after it, newest first, returning the files to their state before it ran.
+Restored backups are removed from the history.`,
+		Example: `  start config undo       Undo the last config change
+  start config undo 12    Undo change 12 and everything after it`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runConfigUndo,
+	}
+	parent.AddCommand(cmd)
+}
+
+// runConfigHistory lists the backup history, newest first.
+func runConfigHistory(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	dir, err := backup.DefaultDir()
+	if err != nil {
+		return err
+	}
+	snapshots, err := backup.List(dir)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if jsonFlag, _ := cmd.Flags().GetBool("json"); jsonFlag {
+		if snapshots == nil {
+			snapshots = []backup.Snapshot{}
+		}
+		return writeJSON(w, snapshots)
+	}
+	if len(snapshots) == 0 {
+		_, _ = fmt.Fprintln(w, "No config backups")
+		return nil
+	}
+	for _, snap := range snapshots {
+		printSnapshot(w, snap)
+	}
+	return nil
+}
+
+// runConfigUndo restores the latest backup, or the given one and every
+// newer backup.
+func runConfigUndo(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	id := 0
+	if len(args) > 0 {
+		var err error
+		if id, err = strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("invalid backup id %q; run 'start config history' to list backups", args[0])
+		}
+	}
+
+	dir, err := backup.DefaultDir()
+	if err != nil {
+		return err
+	}
+	snapshots, err := backup.List(dir)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		return fmt.Errorf("no config backups to undo")
+	}
+	if id == 0 {
+		id = snapshots[0].ID
+	}
+
+	restored, err := backup.Restore(dir, id)
+	w := cmd.OutOrStdout()
+	if !getFlags(cmd).Quiet {
+		for _, snap := range restored {
+			_, _ = fmt.Fprintf(w, "Undid %s\n", snapshotTitle(snap))
+			for _, file := range snap.Files {
+				_, _ = fmt.Fprintf(w, "  %s\n", shortenHome(file.Path))
+			}
+		}
+	}
+	return err
+}
+
+// printSnapshot prints one backup with the files it saved.
+func printSnapshot(w io.Writer, snap backup.Snapshot) {
+	_, _ = tui.ColorDim.Fprintf(w, "%4d  ", snap.ID)
+	_, _ = fmt.Fprintf(w, "%s %s\n", snap.Command, tui.Annotate("%s", snap.CreatedAt.Local().Format("2006-01-02 15:04:05")))
+	for _, file := range snap.Files {
+		note := ""
+		if !file.Existed {
+			note = " " + tui.Annotate("created")
+		}
+		_, _ = fmt.Fprintf(w, "        %s%s\n", shortenHome(file.Path), note)
+	}
+}
+
+// snapshotTitle returns "#id (command)" for messages.
+func snapshotTitle(snap backup.Snapshot) string {
+	return fmt.Sprintf("#%d (%s)", snap.ID, snap.Command)
+}
+
+// withBackup wraps a command that rewrites config files. The CUE files in
+// the global and local config directories are captured before it runs, and
+// those it changed are saved to the backup history afterwards, whether or
+// not it succeeded. Backup failures are reported as warnings.
+func withBackup(run func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		paths, err := config.ResolvePaths("")
+		if err != nil {
+			runErr := run(cmd, args)
+			printWarning(cmd.ErrOrStderr(), "config backup skipped: %v", err)
+			return runErr
+		}
+		dirs := append([]string{paths.Global}, paths.LocalLayers...)
+		for _, dir := range []string{paths.Local, paths.Personal} {
+			if !slices.Contains(dirs, dir) {
+				dirs = append(dirs, dir)
+			}
+		}
+
+		capture, captureErr := backup.Begin(dirs...)
+		runErr := run(cmd, args)
+
+		stderr := cmd.ErrOrStderr()
+		if captureErr != nil {
+			printWarning(stderr, "config backup skipped: %v", captureErr)
+			return runErr
+		}
+		backupDir, err := backup.DefaultDir()
+		if err == nil {
+			command := strings.TrimSpace(cmd.CommandPath() + " " + strings.Join(args, " "))
+			_, err = capture.Commit(backupDir, command)
+		}
+		if err != nil {
+			printWarning(stderr, "config backup failed: %v", err)
+		}
+		return runErr
+	}
+}
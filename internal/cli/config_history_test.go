@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigUndo(t *testing.T) {
+	globalDir := setupConfigPathTest(t)
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	agentsPath := filepath.Join(globalDir, "agents.cue")
+
+	if _, err := runConfigCmd(t, "undo"); err == nil || !strings.Contains(err.Error(), "no config backups") {
+		t.Errorf("undo with no history: error = %v", err)
+	}
+
+	steps := [][]string{
+		{"set", "agents.claude.models.fast", "claude-haiku-4"},
+		{"get", "agents.claude.bin"},
+		{"add", "role", "dev", "--prompt", "You are a developer."},
+		{"settings", "timeout", "300"},
+	}
+	for _, args := range steps {
+		if output, err := runConfigCmd(t, args...); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, output)
+		}
+	}
+
+	output, err := runConfigCmd(t, "history")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	for _, want := range []string{"start config settings timeout 300", "start config add role dev", "roles.cue", "created", "start config set agents.claude.models.fast"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("history missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "config get") {
+		t.Errorf("history should not list commands that changed nothing:\n%s", output)
+	}
+
+	// Undo the settings change only
+	output, err = runConfigCmd(t, "undo")
+	if err != nil || !strings.Contains(output, "Undid #3 (start config settings timeout 300)") {
+		t.Fatalf("undo: %v\n%s", err, output)
+	}
+	if _, err := os.Stat(filepath.Join(globalDir, "settings.cue")); !os.IsNotExist(err) {
+		t.Error("undo should remove settings.cue created by the settings command")
+	}
+
+	// Roll back to before the first change
+	if output, err := runConfigCmd(t, "undo", "1"); err != nil {
+		t.Fatalf("undo 1: %v\n%s", err, output)
+	}
+	agents, err := os.ReadFile(agentsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(agents) != configPathAgents {
+		t.Errorf("agents.cue not restored:\n%s", agents)
+	}
+	if _, err := os.Stat(filepath.Join(globalDir, "roles.cue")); !os.IsNotExist(err) {
+		t.Error("undo should remove roles.cue created by config add")
+	}
+	if output, _ := runConfigCmd(t, "history"); !strings.Contains(output, "No config backups") {
+		t.Errorf("history after undo = %q", output)
+	}
+}
+
+func TestConfigUndo_NothingChanged(t *testing.T) {
+	setupConfigPathTest(t)
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	// A rejected edit restores the file itself, so nothing is recorded
+	if _, err := runConfigCmd(t, "set", "agents.claude.models", "5"); err == nil {
+		t.Fatal("expected type mismatch")
+	}
+	if output, _ := runConfigCmd(t, "history", "--json"); strings.TrimSpace(output) != "[]" {
+		t.Errorf("history = %q, want empty", output)
+	}
+
+	if _, err := runConfigCmd(t, "undo", "x"); err == nil || !strings.Contains(err.Error(), "invalid backup id") {
+		t.Errorf("undo x: error = %v", err)
+	}
+}
@@ -0,0 +1,435 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/cuecontext"
+	cueformat "cuelang.org/go/cue/format"
+	cuejson "cuelang.org/go/encoding/json"
+	cueyaml "cuelang.org/go/encoding/yaml"
+	"github.com/grantcarthew/start/internal/assets"
+	"github.com/grantcarthew/start/internal/config"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/suggest"
+	"github.com/grantcarthew/start/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// instructionFile is an agent instruction file that config import recognises.
+type instructionFile struct {
+	Path string // Path relative to the working directory
+	Name string // Config entry name
+	Tool string // Tool the file is written for
+}
+
+// knownInstructionFiles lists the instruction files config import looks for,
+// in the order they are offered.
+var knownInstructionFiles = []instructionFile{
+	{Path: "AGENTS.md", Name: "agents-md", Tool: "Agent"},
+	{Path: "CLAUDE.md", Name: "claude-md", Tool: "Claude"},
+	{Path: "GEMINI.md", Name: "gemini-md", Tool: "Gemini"},
+	{Path: ".cursorrules", Name: "cursorrules", Tool: "Cursor"},
+	{Path: filepath.Join(".github", "copilot-instructions.md"), Name: "copilot-instructions", Tool: "GitHub Copilot"},
+}
+
+// addConfigImportCommand adds the "config import" command.
+func addConfigImportCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import agent instruction files or JSON/YAML config",
+		Long: `Import existing agent instruction files, or config data from a JSON or
+YAML file.
+
+Without a file argument, scan the working directory for agent instruction
+files and add them to local config.
+
+Recognised files:
+  AGENTS.md
+  CLAUDE.md
+  GEMINI.md
+  .cursorrules
+  .github/copilot-instructions.md
+
+Each file becomes a default context in .start/contexts.cue that reads the file,
+so its instructions are included in every prompt. Use --role to import the
+files as roles in .start/roles.cue instead.
+
+The generated entries are previewed before you choose which to import.
+Files already imported (an entry with the same name exists in local config)
+are skipped. Use --yes / -y to import every file found without prompting.
+
+With a file argument, import agents, roles, contexts, tasks, and settings
+from a JSON or YAML document shaped like the config (as written by
+'start config export --format json'). Use - to read from stdin. The format is
+taken from the file extension, or set with --format. Every entry is checked
+against the asset schemas before anything is written; if the schemas cannot
+be fetched, a warning is shown and the entries are imported unchecked.
+
+Each entry is written to the config file that already defines it, or to the
+file for its category (agents.cue, roles.cue, ...). Existing entries with the
+same name are replaced. Entries go to global config, or local with --local.
+
+Examples:
+  start config import --yes                       Import instruction files
+  start config import config.json                 Import JSON into global config
+  start config export --format yaml > start.yaml
+  start config import --local start.yaml          Import YAML into local config
+  other-tool | start config import --format json -`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: withBackup(runConfigImport),
+	}
+	cmd.Flags().Bool("role", false, "Import files as roles instead of contexts")
+	cmd.Flags().BoolP("yes", "y", false, "Import all files found without prompting")
+	cmd.Flags().String("format", "", "Format of the import file: json or yaml")
+	parent.AddCommand(cmd)
+}
+
+// runConfigImport is the handler for "config import".
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if len(args) > 0 {
+		return runConfigImportData(cmd, args[0], format)
+	}
+	if format != "" {
+		return fmt.Errorf("--format requires a file argument")
+	}
+
+	stdin := cmd.InOrStdin()
+	stdout := cmd.OutOrStdout()
+	asRole, _ := cmd.Flags().GetBool("role")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	quiet := getFlags(cmd).Quiet
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+	paths, err := config.ResolvePaths(workingDir)
+	if err != nil {
+		return fmt.Errorf("resolving config paths: %w", err)
+	}
+
+	category, fileName := internalcue.KeyContexts, "contexts.cue"
+	if asRole {
+		category, fileName = internalcue.KeyRoles, "roles.cue"
+	}
+	existing, err := localEntryNames(paths.Local, asRole)
+	if err != nil {
+		return err
+	}
+	entityType := strings.TrimSuffix(category, "s")
+
+	found := findInstructionFiles(workingDir)
+	var candidates []instructionFile
+	for _, f := range found {
+		if existing[f.Name] {
+			if !quiet {
+				_, _ = fmt.Fprintf(stdout, "Skipping %s: %s %q already exists in local config\n", f.Path, entityType, f.Name)
+			}
+			continue
+		}
+		candidates = append(candidates, f)
+	}
+	if len(candidates) == 0 {
+		if len(found) == 0 {
+			_, _ = fmt.Fprintln(stdout, "No agent instruction files found")
+		}
+		return nil
+	}
+
+	configPath := filepath.Join(paths.Local, fileName)
+	if !skipConfirm {
+		if !isTerminal(stdin) {
+			return fmt.Errorf("--yes flag required in non-interactive mode")
+		}
+		candidates, err = selectInstructionFiles(stdout, stdin, entityType, configPath, candidates, asRole)
+		if err != nil || len(candidates) == 0 {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(paths.Local, 0755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	for _, f := range candidates {
+		if err := assets.WriteConfigEntry(configPath, category, f.Name, instructionEntry(f, asRole), "start config import"); err != nil {
+			return fmt.Errorf("writing %s %q: %w", entityType, f.Name, err)
+		}
+		if !quiet {
+			_, _ = fmt.Fprintf(stdout, "Imported %s as %s %q\n", f.Path, entityType, f.Name)
+		}
+	}
+	if !quiet {
+		_, _ = fmt.Fprintf(stdout, "Config: %s\n", configPath)
+	}
+	return nil
+}
+
+// localEntryNames returns the names of the roles or contexts already defined
+// in the local config directory, which may not exist yet.
+func localEntryNames(dir string, roles bool) (map[string]bool, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	if roles {
+		items, _, err := loadRolesFromDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("loading existing roles: %w", err)
+		}
+		return keySet(items), nil
+	}
+	items, _, err := loadContextsFromDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("loading existing contexts: %w", err)
+	}
+	return keySet(items), nil
+}
+
+// findInstructionFiles returns the known instruction files present in dir.
+func findInstructionFiles(dir string) []instructionFile {
+	var found []instructionFile
+	for _, f := range knownInstructionFiles {
+		info, err := os.Stat(filepath.Join(dir, f.Path))
+		if err == nil && info.Mode().IsRegular() {
+			found = append(found, f)
+		}
+	}
+	return found
+}
+
+// instructionEntry builds the config entry for an imported instruction file.
+// Contexts are marked default so the instructions are included in every prompt.
+func instructionEntry(f instructionFile, asRole bool) ast.Expr {
+	fields := []any{
+		ast.NewIdent("description"), ast.NewString(fmt.Sprintf("%s instructions from %s", f.Tool, filepath.ToSlash(f.Path))),
+		ast.NewIdent("file"), ast.NewString(filepath.ToSlash(f.Path)),
+	}
+	if !asRole {
+		fields = append(fields, ast.NewIdent("default"), ast.NewBool(true))
+	}
+	return ast.NewStruct(fields...)
+}
+
+// selectInstructionFiles previews the entries each file would generate and
+// prompts the user to choose which to import. Returns nil if the user cancels.
+func selectInstructionFiles(w io.Writer, r io.Reader, entityType, configPath string, files []instructionFile, asRole bool) ([]instructionFile, error) {
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprint(w, "Preview ")
+	_, _ = fmt.Fprintln(w, tui.Annotate("%s", configPath))
+	printSeparator(w)
+	for _, f := range files {
+		field := &ast.Field{Label: ast.NewStringLabel(f.Name), Value: instructionEntry(f, asRole)}
+		b, err := cueformat.Node(field)
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s %q: %w", entityType, f.Name, err)
+		}
+		_, _ = fmt.Fprintf(w, "%s\n", b)
+	}
+	printSeparator(w)
+	_, _ = fmt.Fprintln(w)
+
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.Name
+	}
+	selected, err := promptSelectFromList(w, r, "instruction file", "", names)
+	if err != nil || len(selected) == 0 {
+		return nil, err
+	}
+
+	var result []instructionFile
+	for _, f := range files {
+		if slices.Contains(selected, f.Name) {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+// runConfigImportData imports config entries from a JSON or YAML file, or
+// stdin when file is "-".
+func runConfigImportData(cmd *cobra.Command, file, format string) error {
+	stdout := cmd.OutOrStdout()
+	stderr := cmd.ErrOrStderr()
+	flags := getFlags(cmd)
+
+	if format == "" {
+		format = importFormatFromPath(file)
+		if format == "" {
+			return fmt.Errorf("cannot tell the format of %q; use --format json or --format yaml", file)
+		}
+	}
+
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+
+	v, err := decodeConfigData(file, data, format)
+	if err != nil {
+		return err
+	}
+
+	schemas, err := fetchSchemas()
+	if err != nil {
+		printWarning(stderr, "schema validation skipped: %v", err)
+	} else if issues := schemas.Validate(v); len(issues) > 0 {
+		for _, issue := range issues {
+			_, _ = fmt.Fprintf(stderr, "  %s\n", issue.Error())
+		}
+		return fmt.Errorf("%s does not match the config schema; nothing imported", file)
+	}
+
+	paths, err := config.ResolvePaths("")
+	if err != nil {
+		return fmt.Errorf("resolving config paths: %w", err)
+	}
+	configDir := paths.Dir(flags.Scope())
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	existing, err := loadDirDefinitions(configDir)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, c := range blameCategories {
+		iter, err := v.LookupPath(cue.ParsePath(c.key)).Fields()
+		if err != nil {
+			continue
+		}
+		for iter.Next() {
+			name := iter.Selector().Unquoted()
+			expr, ok := iter.Value().Syntax(cue.Final(), cue.Concrete(true)).(ast.Expr)
+			if !ok {
+				return fmt.Errorf("converting %s.%s to CUE", c.key, name)
+			}
+
+			configPath := filepath.Join(configDir, internalcue.ConfigFiles[c.key])
+			if defs := existing.Definitions(c.key, name); len(defs) > 0 && defs[len(defs)-1].File != "" {
+				configPath = defs[len(defs)-1].File
+			}
+			if err := assets.WriteConfigEntry(configPath, c.key, name, expr, "start config import"); err != nil {
+				return fmt.Errorf("writing %s %q: %w", c.category, name, err)
+			}
+			count++
+			if !flags.Quiet {
+				_, _ = fmt.Fprintf(stdout, "Imported %s %q ", c.category, name)
+				_, _ = fmt.Fprintln(stdout, tui.Annotate("%s", filepath.Base(configPath)))
+			}
+		}
+	}
+
+	if count == 0 {
+		_, _ = fmt.Fprintf(stdout, "No config entries found in %s\n", file)
+	} else if !flags.Quiet {
+		_, _ = fmt.Fprintf(stdout, "Config: %s\n", configDir)
+	}
+	return nil
+}
+
+// importFormatFromPath returns the import format implied by a file extension,
+// or "" if the extension is not recognised.
+func importFormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	}
+	return ""
+}
+
+// decodeConfigData parses JSON or YAML config data into a concrete CUE value.
+// Top-level keys other than the config categories are rejected.
+func decodeConfigData(name string, data []byte, format string) (cue.Value, error) {
+	cctx := cuecontext.New()
+
+	var v cue.Value
+	switch format {
+	case "json":
+		expr, err := cuejson.Extract(name, data)
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("parsing %s: %w", name, err)
+		}
+		v = cctx.BuildExpr(expr)
+	case "yaml":
+		f, err := cueyaml.Extract(name, data)
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("parsing %s: %w", name, err)
+		}
+		v = cctx.BuildFile(f)
+	default:
+		return cue.Value{}, fmt.Errorf("unknown format %q: expected json or yaml", format)
+	}
+	if err := v.Validate(cue.Concrete(true)); err != nil {
+		return cue.Value{}, fmt.Errorf("parsing %s: %w", name, err)
+	}
+
+	iter, err := v.Fields()
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("%s: expected an object with config categories", name)
+	}
+	var keys []string
+	for _, c := range blameCategories {
+		keys = append(keys, c.key)
+	}
+	for iter.Next() {
+		key := iter.Selector().Unquoted()
+		// The config format version is included by export but is not imported.
+		if slices.Contains(keys, key) || key == "version" {
+			continue
+		}
+		msg := fmt.Sprintf("%s: unknown top-level key %q", name, key)
+		if s := suggest.Closest(key, keys); s != "" {
+			msg += fmt.Sprintf("; did you mean %q?", s)
+		}
+		return cue.Value{}, fmt.Errorf("%s", msg)
+	}
+	return v, nil
+}
+
+// loadDirDefinitions loads the config in dir to find where its entries are
+// defined. A missing directory, or one without config files, has no
+// definitions.
+func loadDirDefinitions(dir string) (internalcue.LoadResult, error) {
+	hasFiles, err := internalcue.HasCUEFiles(dir)
+	if os.IsNotExist(err) {
+		return internalcue.LoadResult{}, nil
+	}
+	if err != nil || !hasFiles {
+		return internalcue.LoadResult{}, err
+	}
+	result, err := internalcue.NewLoader().Load([]string{dir})
+	if err != nil {
+		return internalcue.LoadResult{}, fmt.Errorf("loading %s: %w", dir, err)
+	}
+	return result, nil
+}
+
+// keySet returns the keys of m as a set.
+func keySet[T any](m map[string]T) map[string]bool {
+	set := make(map[string]bool, len(m))
+	for k := range m {
+		set[k] = true
+	}
+	return set
+}
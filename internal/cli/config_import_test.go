@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupImportProject creates a project with the given instruction files and
+// changes into it.
+func setupImportProject(t *testing.T, files ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("# Instructions\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chdir(t, dir)
+	return dir
+}
+
+func runImport(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	cmd := NewRootCmd()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(append([]string{"config", "import"}, args...))
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestConfigImport_Contexts(t *testing.T) {
+	dir := setupImportProject(t, "AGENTS.md", "CLAUDE.md", ".github/copilot-instructions.md")
+
+	output, err := runImport(t, "--yes")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, `Imported CLAUDE.md as context "claude-md"`) {
+		t.Errorf("output missing import line:\n%s", output)
+	}
+
+	contexts, order, err := loadContextsFromDir(filepath.Join(dir, ".start"))
+	if err != nil {
+		t.Fatalf("loading contexts: %v", err)
+	}
+	want := []string{"agents-md", "claude-md", "copilot-instructions"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if ctx := contexts["copilot-instructions"]; ctx.File != ".github/copilot-instructions.md" || !ctx.Default {
+		t.Errorf("copilot-instructions = %+v, want default context reading .github/copilot-instructions.md", ctx)
+	}
+
+	// A second import skips files that are already configured
+	output, err = runImport(t, "--yes")
+	if err != nil {
+		t.Fatalf("second import: %v", err)
+	}
+	if strings.Contains(output, "Imported") || !strings.Contains(output, "already exists") {
+		t.Errorf("second import should skip existing entries:\n%s", output)
+	}
+}
+
+func TestConfigImport_Roles(t *testing.T) {
+	dir := setupImportProject(t, "GEMINI.md")
+
+	if output, err := runImport(t, "--yes", "--role"); err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, output)
+	}
+
+	roles, _, err := loadRolesFromDir(filepath.Join(dir, ".start"))
+	if err != nil {
+		t.Fatalf("loading roles: %v", err)
+	}
+	if role, ok := roles["gemini-md"]; !ok || role.File != "GEMINI.md" {
+		t.Errorf("roles = %+v, want gemini-md reading GEMINI.md", roles)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".start", "contexts.cue")); !os.IsNotExist(err) {
+		t.Error("--role should not write contexts.cue")
+	}
+}
+
+func TestConfigImport_NoFiles(t *testing.T) {
+	setupImportProject(t)
+
+	output, err := runImport(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "No agent instruction files found") {
+		t.Errorf("output = %q", output)
+	}
+}
+
+func TestConfigImport_NonInteractiveRequiresYes(t *testing.T) {
+	setupImportProject(t, "AGENTS.md")
+
+	if _, err := runImport(t); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("expected --yes error, got: %v", err)
+	}
+}
+
+func TestSelectInstructionFiles(t *testing.T) {
+	t.Parallel()
+	files := []instructionFile{knownInstructionFiles[0], knownInstructionFiles[1]}
+
+	var w bytes.Buffer
+	selected, err := selectInstructionFiles(&w, strings.NewReader("2\n"), "context", ".start/contexts.cue", files, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(selected) != 1 || selected[0].Name != "claude-md" {
+		t.Errorf("selected = %+v, want claude-md", selected)
+	}
+	if !strings.Contains(w.String(), `file:        "CLAUDE.md"`) {
+		t.Errorf("preview missing generated entry:\n%s", w.String())
+	}
+}
+
+// setupDataImport creates empty global and local config locations with the
+// registry disabled so schema validation is skipped.
+func setupDataImport(t *testing.T) string {
+	t.Helper()
+	dir := setupImportProject(t)
+	t.Setenv("CUE_REGISTRY", "::invalid")
+	return dir
+}
+
+func TestConfigImport_DataRoundTrip(t *testing.T) {
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			dir := setupDataImport(t)
+			input := filepath.Join(dir, "config."+format)
+			data := `{"agents": {"claude": {"bin": "claude", "command": "claude {{.prompt}}"}}, "roles": {"dev": {"prompt": "Be careful.", "tags": ["go"]}}, "settings": {"default_agent": "claude"}}`
+			if format == "yaml" {
+				data = "agents:\n  claude:\n    bin: claude\n    command: claude {{.prompt}}\nroles:\n  dev:\n    prompt: Be careful.\n    tags: [go]\nsettings:\n  default_agent: claude\n"
+			}
+			if err := os.WriteFile(input, []byte(data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			output, err := runImport(t, "--local", input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v\n%s", err, output)
+			}
+			if !strings.Contains(output, `Imported agent "claude" (agents.cue)`) {
+				t.Errorf("output missing import line:\n%s", output)
+			}
+			for _, f := range []string{"agents.cue", "roles.cue", "settings.cue"} {
+				if _, err := os.Stat(filepath.Join(dir, ".start", f)); err != nil {
+					t.Errorf("%s not written: %v", f, err)
+				}
+			}
+
+			buf := new(bytes.Buffer)
+			cmd := NewRootCmd()
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetArgs([]string{"config", "export", "--local", "--format", format})
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("export: %v\n%s", err, buf.String())
+			}
+			exported := filepath.Join(dir, "exported."+format)
+			if err := os.WriteFile(exported, buf.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(buf.String(), "Be careful.") {
+				t.Errorf("export missing role prompt:\n%s", buf.String())
+			}
+
+			// Re-importing the export replaces entries in place
+			before, _ := os.ReadFile(filepath.Join(dir, ".start", "roles.cue"))
+			if output, err := runImport(t, "--local", exported); err != nil {
+				t.Fatalf("re-import: %v\n%s", err, output)
+			}
+			after, _ := os.ReadFile(filepath.Join(dir, ".start", "roles.cue"))
+			if string(before) != string(after) {
+				t.Errorf("round trip changed roles.cue:\n%s\n---\n%s", before, after)
+			}
+		})
+	}
+}
+
+func TestConfigImport_DataExistingFile(t *testing.T) {
+	dir := setupDataImport(t)
+	if err := os.MkdirAll(filepath.Join(dir, ".start"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".start", "config.cue"), []byte("roles: dev: prompt: \"old\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	input := filepath.Join(dir, "roles.json")
+	if err := os.WriteFile(input, []byte(`{"roles": {"dev": {"prompt": "new"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if output, err := runImport(t, "--local", input); err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, output)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ".start", "config.cue"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"new"`) {
+		t.Errorf("entry not updated in the file that defines it:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".start", "roles.cue")); !os.IsNotExist(err) {
+		t.Error("roles.cue should not be created for an existing entry")
+	}
+}
+
+func TestConfigImport_DataErrors(t *testing.T) {
+	dir := setupDataImport(t)
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		args    []string
+		wantErr string
+	}{
+		{"unknown key", "c.json", `{"agentz": {}}`, nil, `did you mean "agents"?`},
+		{"unknown extension", "c.txt", `{}`, nil, "--format json"},
+		{"invalid json", "c.json", `{"roles": `, nil, "parsing"},
+		{"not an object", "c.yaml", "- a\n", nil, "expected an object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := runImport(t, append(tt.args, "--local", path)...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := runImport(t, "--format", "json"); err == nil || !strings.Contains(err.Error(), "requires a file") {
+		t.Errorf("--format without file: error = %v", err)
+	}
+}
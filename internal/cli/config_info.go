@@ -6,6 +6,9 @@ import (
 	"sort"
 	"strings"
 
+	"cuelang.org/go/cue"
+	"github.com/grantcarthew/start/internal/config"
+	internalcue "github.com/grantcarthew/start/internal/cue"
 	"github.com/grantcarthew/start/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -21,7 +24,8 @@ Search by name across all categories. If multiple items match, a numbered
 menu is presented. With no argument, prompts interactively for category and item.
 
 This shows raw stored fields, not resolved content. Use 'start show' to view
-resolved content after global/local merging.`,
+resolved content after global/local merging. Items that use extends or
+merge also show their resolved definition.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: runConfigInfo,
 	}
@@ -37,7 +41,7 @@ func runConfigInfo(cmd *cobra.Command, args []string) error {
 
 	stdin := cmd.InOrStdin()
 	stdout := cmd.OutOrStdout()
-	local := getFlags(cmd).Local
+	scope := getFlags(cmd).Scope()
 	jsonFlag, _ := cmd.Flags().GetBool("json")
 
 	if len(args) == 0 {
@@ -47,11 +51,11 @@ func runConfigInfo(cmd *cobra.Command, args []string) error {
 		if !isTerminal(stdin) {
 			return fmt.Errorf("interactive info requires a terminal")
 		}
-		return runConfigInfoInteractive(stdin, stdout, local)
+		return runConfigInfoInteractive(stdin, stdout, scope)
 	}
 
 	query := args[0]
-	matches, err := searchAllConfigCategories(query, local)
+	matches, err := searchAllConfigCategories(query, scope)
 	if err != nil {
 		return err
 	}
@@ -69,12 +73,13 @@ func runConfigInfo(cmd *cobra.Command, args []string) error {
 	if jsonFlag {
 		var results []ConfigListItem
 		for _, m := range matches {
-			item, err := buildConfigListItem(m, local)
+			item, err := buildConfigListItem(m, scope)
 			if err != nil {
 				return err
 			}
 			results = append(results, item)
 		}
+		setDefinedAt(results, scope)
 		if err := writeJSON(stdout, results); err != nil {
 			return fmt.Errorf("marshalling config info: %w", err)
 		}
@@ -94,18 +99,18 @@ func runConfigInfo(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	return printConfigInfo(stdout, local, selected)
+	return printConfigInfo(stdout, scope, selected)
 }
 
 // runConfigInfoInteractive prompts for category then item, then shows info.
-func runConfigInfoInteractive(stdin io.Reader, stdout io.Writer, local bool) error {
+func runConfigInfoInteractive(stdin io.Reader, stdout io.Writer, scope config.Scope) error {
 	_, _ = fmt.Fprintln(stdout, "Info:")
 	category, err := promptSelectCategory(stdout, stdin, allConfigCategories)
 	if err != nil || category == "" {
 		return err
 	}
 
-	names, err := loadNamesForCategory(category, local)
+	names, err := loadNamesForCategory(category, scope)
 	if err != nil {
 		return err
 	}
@@ -121,27 +126,27 @@ func runConfigInfoInteractive(stdin io.Reader, stdout io.Writer, local bool) err
 		return err
 	}
 
-	return printConfigInfo(stdout, local, configMatch{Name: selected, Category: singular})
+	return printConfigInfo(stdout, scope, configMatch{Name: selected, Category: singular})
 }
 
 // printConfigInfo displays the raw config fields for a single matched item.
-func printConfigInfo(w io.Writer, local bool, m configMatch) error {
+func printConfigInfo(w io.Writer, scope config.Scope, m configMatch) error {
 	switch m.Category {
 	case "agent":
-		return printAgentInfo(w, local, m.Name)
+		return printAgentInfo(w, scope, m.Name)
 	case "role":
-		return printRoleInfo(w, local, m.Name)
+		return printRoleInfo(w, scope, m.Name)
 	case "context":
-		return printContextInfo(w, local, m.Name)
+		return printContextInfo(w, scope, m.Name)
 	case "task":
-		return printTaskInfo(w, local, m.Name)
+		return printTaskInfo(w, scope, m.Name)
 	}
 	return fmt.Errorf("unknown category %q", m.Category)
 }
 
 // printAgentInfo displays raw fields for an agent.
-func printAgentInfo(w io.Writer, local bool, name string) error {
-	agents, _, err := loadAgentsForScope(local)
+func printAgentInfo(w io.Writer, scope config.Scope, name string) error {
+	agents, _, err := loadAgentsForScope(scope)
 	if err != nil {
 		return err
 	}
@@ -162,6 +167,7 @@ func printAgentInfo(w io.Writer, local bool, name string) error {
 		_, _ = tui.ColorDim.Fprint(w, "Origin:")
 		_, _ = fmt.Fprintf(w, " %s\n", agent.Origin)
 	}
+	printInheritance(w, agent.Extends, agent.Merge)
 	if agent.Bin != "" {
 		_, _ = tui.ColorDim.Fprint(w, "Bin:")
 		_, _ = fmt.Fprintf(w, " %s\n", agent.Bin)
@@ -196,13 +202,16 @@ func printAgentInfo(w io.Writer, local bool, name string) error {
 			_, _ = tui.ColorDim.Fprintf(w, "%s\n", agent.Models[alias])
 		}
 	}
+	if agent.Extends != "" || agent.Merge {
+		printResolvedItem(w, scope, internalcue.KeyAgents, resolvedName)
+	}
 	printSeparator(w)
 	return nil
 }
 
 // printRoleInfo displays raw fields for a role.
-func printRoleInfo(w io.Writer, local bool, name string) error {
-	roles, _, err := loadRolesForScope(local)
+func printRoleInfo(w io.Writer, scope config.Scope, name string) error {
+	roles, _, err := loadRolesForScope(scope)
 	if err != nil {
 		return err
 	}
@@ -223,6 +232,7 @@ func printRoleInfo(w io.Writer, local bool, name string) error {
 		_, _ = tui.ColorDim.Fprint(w, "Origin:")
 		_, _ = fmt.Fprintf(w, " %s\n", role.Origin)
 	}
+	printInheritance(w, role.Extends, role.Merge)
 	if role.Description != "" {
 		_, _ = fmt.Fprintln(w)
 		_, _ = tui.ColorDim.Fprint(w, "Description:")
@@ -248,13 +258,16 @@ func printRoleInfo(w io.Writer, local bool, name string) error {
 		_, _ = tui.ColorDim.Fprint(w, "Tags:")
 		_, _ = fmt.Fprintf(w, " %s\n", strings.Join(role.Tags, ", "))
 	}
+	if role.Extends != "" || role.Merge {
+		printResolvedItem(w, scope, internalcue.KeyRoles, resolvedName)
+	}
 	printSeparator(w)
 	return nil
 }
 
 // printContextInfo displays raw fields for a context.
-func printContextInfo(w io.Writer, local bool, name string) error {
-	contexts, _, err := loadContextsForScope(local)
+func printContextInfo(w io.Writer, scope config.Scope, name string) error {
+	contexts, _, err := loadContextsForScope(scope)
 	if err != nil {
 		return err
 	}
@@ -275,6 +288,7 @@ func printContextInfo(w io.Writer, local bool, name string) error {
 		_, _ = tui.ColorDim.Fprint(w, "Origin:")
 		_, _ = fmt.Fprintf(w, " %s\n", ctx.Origin)
 	}
+	printInheritance(w, ctx.Extends, ctx.Merge)
 	if ctx.Description != "" {
 		_, _ = fmt.Fprintln(w)
 		_, _ = tui.ColorDim.Fprint(w, "Description:")
@@ -300,13 +314,16 @@ func printContextInfo(w io.Writer, local bool, name string) error {
 		_, _ = tui.ColorDim.Fprint(w, "Tags:")
 		_, _ = fmt.Fprintf(w, " %s\n", strings.Join(ctx.Tags, ", "))
 	}
+	if ctx.Extends != "" || ctx.Merge {
+		printResolvedItem(w, scope, internalcue.KeyContexts, resolvedName)
+	}
 	printSeparator(w)
 	return nil
 }
 
 // printTaskInfo displays raw fields for a task.
-func printTaskInfo(w io.Writer, local bool, name string) error {
-	tasks, _, err := loadTasksForScope(local)
+func printTaskInfo(w io.Writer, scope config.Scope, name string) error {
+	tasks, _, err := loadTasksForScope(scope)
 	if err != nil {
 		return err
 	}
@@ -327,6 +344,7 @@ func printTaskInfo(w io.Writer, local bool, name string) error {
 		_, _ = tui.ColorDim.Fprint(w, "Origin:")
 		_, _ = fmt.Fprintf(w, " %s\n", task.Origin)
 	}
+	printInheritance(w, task.Extends, task.Merge)
 	if task.Description != "" {
 		_, _ = fmt.Fprintln(w)
 		_, _ = tui.ColorDim.Fprint(w, "Description:")
@@ -352,6 +370,40 @@ func printTaskInfo(w io.Writer, local bool, name string) error {
 		_, _ = tui.ColorDim.Fprint(w, "Tags:")
 		_, _ = fmt.Fprintf(w, " %s\n", strings.Join(task.Tags, ", "))
 	}
+	if task.Extends != "" || task.Merge {
+		printResolvedItem(w, scope, internalcue.KeyTasks, resolvedName)
+	}
 	printSeparator(w)
 	return nil
 }
+
+// printInheritance displays the extends and merge directives of an item.
+func printInheritance(w io.Writer, extends string, merge bool) {
+	if extends != "" {
+		_, _ = tui.ColorDim.Fprint(w, "Extends:")
+		_, _ = fmt.Fprintf(w, " %s\n", extends)
+	}
+	if merge {
+		_, _ = tui.ColorDim.Fprint(w, "Merge:")
+		_, _ = fmt.Fprintln(w, " true")
+	}
+}
+
+// printResolvedItem displays the merged definition of an item whose raw
+// fields above are partial because it extends or merges into another.
+func printResolvedItem(w io.Writer, scope config.Scope, cueKey, name string) {
+	result, err := loadConfig(scope)
+	if err != nil {
+		_, _ = fmt.Fprintln(w)
+		_, _ = tui.ColorWarning.Fprint(w, "Resolved:")
+		_, _ = fmt.Fprintf(w, " %v\n", err)
+		return
+	}
+	v := result.Value.LookupPath(cue.MakePath(cue.Str(cueKey), cue.Str(name)))
+	if !v.Exists() {
+		return
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = tui.ColorDim.Fprintln(w, "Resolved:")
+	_, _ = fmt.Fprintln(w, formatCUEDefinition(v))
+}
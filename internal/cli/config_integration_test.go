@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/grantcarthew/start/internal/config"
 )
 
 // Integration tests for config commands that test the full workflow.
@@ -28,7 +30,7 @@ func TestConfigAgent_FullWorkflow(t *testing.T) {
 	t.Run("add agent interactively", func(t *testing.T) {
 		// Prompts: name, bin, command, models(skip), defaultModel="sonnet", description, tags(skip)
 		stdout := &bytes.Buffer{}
-		if err := configAgentAdd(slowStdin("claude\nclaude\n"+`claude --model {{.model}} "{{.prompt}}"`+"\n\nsonnet\nAnthropic Claude\n\n"), stdout, false); err != nil {
+		if err := configAgentAdd(slowStdin("claude\nclaude\n"+`claude --model {{.model}} "{{.prompt}}"`+"\n\nsonnet\nAnthropic Claude\n\n"), stdout, config.ScopeMerged); err != nil {
 			t.Fatalf("add failed: %v", err)
 		}
 
@@ -86,7 +88,7 @@ func TestConfigAgent_FullWorkflow(t *testing.T) {
 
 	t.Run("add second agent", func(t *testing.T) {
 		// Prompts: name, bin, command template, default model (empty), description (empty), tags (skip)
-		if err := configAgentAdd(slowStdin("gemini\ngemini\n"+`gemini "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configAgentAdd(slowStdin("gemini\ngemini\n"+`gemini "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("add gemini failed: %v", err)
 		}
 	})
@@ -173,7 +175,7 @@ func TestConfigAgent_FullWorkflow(t *testing.T) {
 
 	t.Run("remove with -y short flag", func(t *testing.T) {
 		// Re-add gemini for this test
-		if err := configAgentAdd(slowStdin("gemini\ngemini\n"+`gemini "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configAgentAdd(slowStdin("gemini\ngemini\n"+`gemini "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("re-add gemini failed: %v", err)
 		}
 
@@ -195,7 +197,7 @@ func TestConfigAgent_FullWorkflow(t *testing.T) {
 
 	t.Run("add duplicate fails", func(t *testing.T) {
 		// Provide full add responses; duplicate check fires after all prompts
-		err := configAgentAdd(slowStdin("claude\nclaude\n"+`claude "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, false)
+		err := configAgentAdd(slowStdin("claude\nclaude\n"+`claude "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged)
 		if err == nil {
 			t.Fatal("expected error for duplicate agent")
 		}
@@ -219,7 +221,7 @@ func TestConfigRole_FullWorkflow(t *testing.T) {
 	t.Run("add role with file", func(t *testing.T) {
 		// Prompts: name, description, content choice (Enter→"1"→file), file path, optional (N), tags (skip)
 		stdout := &bytes.Buffer{}
-		if err := configRoleAdd(slowStdin("go-expert\nGo programming expert\n\n~/.config/start/roles/go-expert.md\n\n\n"), stdout, false); err != nil {
+		if err := configRoleAdd(slowStdin("go-expert\nGo programming expert\n\n~/.config/start/roles/go-expert.md\n\n\n"), stdout, config.ScopeMerged); err != nil {
 			t.Fatalf("add failed: %v", err)
 		}
 
@@ -231,7 +233,7 @@ func TestConfigRole_FullWorkflow(t *testing.T) {
 
 	t.Run("add role with prompt", func(t *testing.T) {
 		// Prompts: name, description, content choice "3"→inline, prompt text, blank line to finish, tags (skip)
-		if err := configRoleAdd(slowStdin("reviewer\nCode review expert\n3\nYou are a code reviewer. Review code for bugs and style issues.\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configRoleAdd(slowStdin("reviewer\nCode review expert\n3\nYou are a code reviewer. Review code for bugs and style issues.\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("add failed: %v", err)
 		}
 	})
@@ -311,7 +313,7 @@ func TestConfigContext_FullWorkflow(t *testing.T) {
 	t.Run("add required context", func(t *testing.T) {
 		// Prompts: name, description, content choice (Enter→file), file path, required "y", tags (skip)
 		stdout := &bytes.Buffer{}
-		if err := configContextAdd(slowStdin("project\nProject documentation\n\nPROJECT.md\ny\n\n"), stdout, false); err != nil {
+		if err := configContextAdd(slowStdin("project\nProject documentation\n\nPROJECT.md\ny\n\n"), stdout, config.ScopeMerged); err != nil {
 			t.Fatalf("add failed: %v", err)
 		}
 
@@ -327,7 +329,7 @@ func TestConfigContext_FullWorkflow(t *testing.T) {
 	t.Run("add default context", func(t *testing.T) {
 		// Prompts: name, description (empty), content choice (Enter→file), file path,
 		//          required "n", default "y", tags (skip)
-		if err := configContextAdd(slowStdin("readme\n\n\nREADME.md\nn\ny\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configContextAdd(slowStdin("readme\n\n\nREADME.md\nn\ny\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("add failed: %v", err)
 		}
 	})
@@ -408,7 +410,7 @@ func TestConfigTask_FullWorkflow(t *testing.T) {
 		// Prompts: name, description, content choice (Enter→"3"→inline), prompt text,
 		//          blank line to finish, role, tags (skip)
 		stdout := &bytes.Buffer{}
-		if err := configTaskAdd(slowStdin("review\nCode review task\n\nReview this code for bugs and improvements\n\ncode-reviewer\n\n"), stdout, false); err != nil {
+		if err := configTaskAdd(slowStdin("review\nCode review task\n\nReview this code for bugs and improvements\n\ncode-reviewer\n\n"), stdout, config.ScopeMerged); err != nil {
 			t.Fatalf("add failed: %v", err)
 		}
 
@@ -503,13 +505,13 @@ func TestConfigLocal_Isolation(t *testing.T) {
 	}
 
 	t.Run("add global agent", func(t *testing.T) {
-		if err := configAgentAdd(slowStdin("global-agent\nglobal\n"+`global "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configAgentAdd(slowStdin("global-agent\nglobal\n"+`global "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("add global failed: %v", err)
 		}
 	})
 
 	t.Run("add local agent", func(t *testing.T) {
-		if err := configAgentAdd(slowStdin("local-agent\nlocal\n"+`local "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, true); err != nil {
+		if err := configAgentAdd(slowStdin("local-agent\nlocal\n"+`local "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeLocal); err != nil {
 			t.Fatalf("add local failed: %v", err)
 		}
 
@@ -582,7 +584,7 @@ func TestConfigTask_SubstringResolution(t *testing.T) {
 		{"golang/review/architecture", "Review arch"},
 		{"golang/review/code", "Review code"},
 	} {
-		if err := configTaskAdd(slowStdin(tc.name+"\n\n\n"+tc.prompt+"\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configTaskAdd(slowStdin(tc.name+"\n\n\n"+tc.prompt+"\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("add task %s failed: %v", tc.name, err)
 		}
 	}
@@ -658,7 +660,7 @@ func TestConfigTask_SubstringResolution(t *testing.T) {
 		// Interactive edit of "confluence/read-doc":
 		// Prompts: description, keep current content? (Enter=Y), role (Enter=keep ""), tags (Enter=keep nil)
 		stdout := &bytes.Buffer{}
-		if err := configTaskEdit(slowStdin("Updated description\n\n\n\n"), stdout, false, "read-doc"); err != nil {
+		if err := configTaskEdit(slowStdin("Updated description\n\n\n\n"), stdout, config.ScopeMerged, "read-doc"); err != nil {
 			t.Fatalf("edit with substring failed: %v", err)
 		}
 
@@ -734,10 +736,10 @@ func TestConfigTask_SubstringResolution(t *testing.T) {
 
 	t.Run("remove with ambiguous query in non-interactive mode without --yes errors", func(t *testing.T) {
 		// Re-add some tasks first
-		if err := configTaskAdd(slowStdin("golang/review/security\n\n\nReview security.\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configTaskAdd(slowStdin("golang/review/security\n\n\nReview security.\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("re-add failed: %v", err)
 		}
-		if err := configTaskAdd(slowStdin("golang/review/perf\n\n\nReview perf.\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configTaskAdd(slowStdin("golang/review/perf\n\n\nReview perf.\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("re-add failed: %v", err)
 		}
 
@@ -774,7 +776,7 @@ func TestConfigRemove_MultipleArgs(t *testing.T) {
 			{"golang/review/security", "Security"},
 			{"confluence/read-doc", "Read"},
 		} {
-			if err := configTaskAdd(slowStdin(tc.name+"\n\n\n"+tc.prompt+"\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+			if err := configTaskAdd(slowStdin(tc.name+"\n\n\n"+tc.prompt+"\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 				t.Fatalf("add %s failed: %v", tc.name, err)
 			}
 		}
@@ -818,11 +820,11 @@ func TestConfigRemove_MultipleArgs(t *testing.T) {
 		}
 
 		// Add an agent named "shared"
-		if err := configAgentAdd(slowStdin("shared\nshared\n"+`shared "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configAgentAdd(slowStdin("shared\nshared\n"+`shared "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("add agent failed: %v", err)
 		}
 		// Add a role named "shared"
-		if err := configRoleAdd(slowStdin("shared\n\n3\nShared role prompt.\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configRoleAdd(slowStdin("shared\n\n3\nShared role prompt.\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("add role failed: %v", err)
 		}
 
@@ -859,7 +861,7 @@ func TestConfigRemove_MultipleArgs(t *testing.T) {
 		}
 
 		for _, name := range []string{"alpha", "beta", "gamma"} {
-			if err := configAgentAdd(slowStdin(name+"\n"+name+"\n"+name+` "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+			if err := configAgentAdd(slowStdin(name+"\n"+name+"\n"+name+` "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 				t.Fatalf("add %s failed: %v", name, err)
 			}
 		}
@@ -908,7 +910,7 @@ func TestConfigRemove_MultipleArgs(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if err := configAgentAdd(slowStdin("testagent\ntest\n"+`test "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+		if err := configAgentAdd(slowStdin("testagent\ntest\n"+`test "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 			t.Fatalf("add failed: %v", err)
 		}
 
@@ -950,7 +952,7 @@ func TestConfigAgentAdd_WithModelsAndTags(t *testing.T) {
 	//          defaultModel: "1" (select first from list),
 	//          description, tags: "ai,coding\n"
 	stdout := &bytes.Buffer{}
-	err := configAgentAdd(slowStdin("myagent\nmybin\n"+`mybin "{{.prompt}}"`+"\nfast=claude-3-haiku\n\n1\nMy agent\nai,coding\n"), stdout, false)
+	err := configAgentAdd(slowStdin("myagent\nmybin\n"+`mybin "{{.prompt}}"`+"\nfast=claude-3-haiku\n\n1\nMy agent\nai,coding\n"), stdout, config.ScopeMerged)
 	if err != nil {
 		t.Fatalf("add failed: %v", err)
 	}
@@ -977,7 +979,7 @@ func TestConfigAgentAdd_NoModelsWhenNoDefaultModel(t *testing.T) {
 
 	// Prompts: name, bin, command, models(empty=skip), defaultModel(empty=skip), description, tags(skip)
 	stdout := &bytes.Buffer{}
-	err := configAgentAdd(slowStdin("nomodel\nbin\n"+`bin "{{.prompt}}"`+"\n\n\nA desc\n\n"), stdout, false)
+	err := configAgentAdd(slowStdin("nomodel\nbin\n"+`bin "{{.prompt}}"`+"\n\n\nA desc\n\n"), stdout, config.ScopeMerged)
 	if err != nil {
 		t.Fatalf("add failed: %v", err)
 	}
@@ -1000,7 +1002,7 @@ func TestConfigRoleAdd_OptionalWithFileSource(t *testing.T) {
 	}
 
 	// Prompts: name, description, content choice (Enter→file), file path, optional "y", tags (skip)
-	err := configRoleAdd(slowStdin("opt-role\nOptional role\n\n~/.config/start/roles/opt.md\ny\n\n"), &bytes.Buffer{}, false)
+	err := configRoleAdd(slowStdin("opt-role\nOptional role\n\n~/.config/start/roles/opt.md\ny\n\n"), &bytes.Buffer{}, config.ScopeMerged)
 	if err != nil {
 		t.Fatalf("add failed: %v", err)
 	}
@@ -1023,7 +1025,7 @@ func TestConfigRoleAdd_NoOptionalWithPromptSource(t *testing.T) {
 
 	// Prompts: name, description, content choice "3"→inline, prompt text, blank line, tags (skip)
 	// No optional prompt since content source is not file
-	err := configRoleAdd(slowStdin("prompt-role\nA role\n3\nDo stuff.\n\n\n"), &bytes.Buffer{}, false)
+	err := configRoleAdd(slowStdin("prompt-role\nA role\n3\nDo stuff.\n\n\n"), &bytes.Buffer{}, config.ScopeMerged)
 	if err != nil {
 		t.Fatalf("add failed: %v", err)
 	}
@@ -1045,13 +1047,13 @@ func TestConfigRoleEdit_OptionalPrompt(t *testing.T) {
 	}
 
 	// Add a role with file source, optional=false
-	err := configRoleAdd(slowStdin("edit-role\nEditable\n\n~/.config/start/roles/edit.md\n\n\n"), &bytes.Buffer{}, false)
+	err := configRoleAdd(slowStdin("edit-role\nEditable\n\n~/.config/start/roles/edit.md\n\n\n"), &bytes.Buffer{}, config.ScopeMerged)
 	if err != nil {
 		t.Fatalf("add failed: %v", err)
 	}
 
 	// Edit: description (keep), keep content (Y), optional "y", tags (keep)
-	err = configRoleEdit(slowStdin("\n\ny\n\n"), &bytes.Buffer{}, false, "edit-role")
+	err = configRoleEdit(slowStdin("\n\ny\n\n"), &bytes.Buffer{}, config.ScopeMerged, "edit-role")
 	if err != nil {
 		t.Fatalf("edit failed: %v", err)
 	}
@@ -1073,7 +1075,7 @@ func TestConfigContextAdd_WithTags(t *testing.T) {
 	}
 
 	// Prompts: name, description, content choice (Enter→file), file path, required "n", default "n", tags "docs,ref"
-	err := configContextAdd(slowStdin("tagged-ctx\nA context\n\nctx.md\nn\nn\ndocs,ref\n"), &bytes.Buffer{}, false)
+	err := configContextAdd(slowStdin("tagged-ctx\nA context\n\nctx.md\nn\nn\ndocs,ref\n"), &bytes.Buffer{}, config.ScopeMerged)
 	if err != nil {
 		t.Fatalf("add failed: %v", err)
 	}
@@ -1096,7 +1098,7 @@ func TestConfigTaskAdd_WithTags(t *testing.T) {
 	}
 
 	// Prompts: name, description, content choice (Enter→"3"→inline), prompt, blank line, role (empty), tags "review,go"
-	err := configTaskAdd(slowStdin("tagged-task\nA task\n\nDo this.\n\n\nreview,go\n"), &bytes.Buffer{}, false)
+	err := configTaskAdd(slowStdin("tagged-task\nA task\n\nDo this.\n\n\nreview,go\n"), &bytes.Buffer{}, config.ScopeMerged)
 	if err != nil {
 		t.Fatalf("add failed: %v", err)
 	}
@@ -1251,3 +1253,59 @@ func TestConfigInfo_ZeroMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigInfo_ShowsResolvedExtends(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	chdir(t, tmpDir)
+
+	globalDir := filepath.Join(tmpDir, "start")
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	agents := `agents: {
+	claude: {
+		bin: "claude"
+		command: "claude --model {{.model}}"
+		default_model: "sonnet"
+	}
+	"claude-opus": {
+		extends: "claude"
+		default_model: "opus"
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(globalDir, "agents.cue"), []byte(agents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewRootCmd()
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"config", "info", "claude-opus"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("info failed: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{"Extends: claude", "Resolved:", `bin:`, `default_model: "opus"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("info output missing %q:\n%s", want, output)
+		}
+	}
+
+	// Rewriting the file keeps the directive rather than the inherited fields
+	loaded, _, err := loadAgentsFromDir(globalDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(globalDir, "agents.cue")
+	if err := writeAgentsFile(path, loaded); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), `extends: "claude"`) {
+		t.Errorf("agents.cue lost extends:\n%s", content)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/grantcarthew/start/internal/config"
 )
 
 func TestConfigInteractive_RequiresTerminal(t *testing.T) {
@@ -52,16 +54,16 @@ func TestLoadNamesForCategory(t *testing.T) {
 	}
 
 	// Seed one item in each category using stdin-driven interactive input.
-	if err := configAgentAdd(slowStdin("my-agent\nagent\n"+`agent "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+	if err := configAgentAdd(slowStdin("my-agent\nagent\n"+`agent "{{.prompt}}"`+"\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 		t.Fatalf("setup agent add failed: %v", err)
 	}
-	if err := configRoleAdd(slowStdin("my-role\n\n3\nYou are a role.\n\n\n"), &bytes.Buffer{}, false); err != nil {
+	if err := configRoleAdd(slowStdin("my-role\n\n3\nYou are a role.\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 		t.Fatalf("setup role add failed: %v", err)
 	}
-	if err := configContextAdd(slowStdin("my-context\n\n3\nContext info.\n\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+	if err := configContextAdd(slowStdin("my-context\n\n3\nContext info.\n\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 		t.Fatalf("setup context add failed: %v", err)
 	}
-	if err := configTaskAdd(slowStdin("my-task\n\n\nDo a task.\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+	if err := configTaskAdd(slowStdin("my-task\n\n\nDo a task.\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 		t.Fatalf("setup task add failed: %v", err)
 	}
 
@@ -75,7 +77,7 @@ func TestLoadNamesForCategory(t *testing.T) {
 		{"tasks", "my-task"},
 	} {
 		t.Run(tc.category, func(t *testing.T) {
-			names, err := loadNamesForCategory(tc.category, false)
+			names, err := loadNamesForCategory(tc.category, config.ScopeMerged)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -93,7 +95,7 @@ func TestLoadNamesForCategory(t *testing.T) {
 	}
 
 	t.Run("unknown category returns error", func(t *testing.T) {
-		_, err := loadNamesForCategory("unknown", false)
+		_, err := loadNamesForCategory("unknown", config.ScopeMerged)
 		if err == nil {
 			t.Fatal("expected error for unknown category")
 		}
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/grantcarthew/start/internal/config"
 	"github.com/grantcarthew/start/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -29,15 +30,36 @@ type ConfigListItem struct {
 	Tags         []string          `json:"tags,omitempty"`
 	Source       string            `json:"source"`
 	Origin       string            `json:"origin,omitempty"`
+	Disabled     bool              `json:"disabled,omitempty"`
+	DefinedAt    string            `json:"definedAt,omitempty"` // file:line of the effective definition
+}
+
+// setDefinedAt fills DefinedAt for each item from the loader's provenance.
+// Items are left unchanged if the config cannot be loaded.
+func setDefinedAt(items []ConfigListItem, scope config.Scope) {
+	result, err := loadConfig(scope)
+	if err != nil {
+		return
+	}
+	for i := range items {
+		for _, cat := range blameCategories {
+			if cat.category != items[i].Category {
+				continue
+			}
+			if defs := result.Definitions(cat.key, items[i].Name); len(defs) > 0 {
+				items[i].DefinedAt = defs[len(defs)-1].Position()
+			}
+		}
+	}
 }
 
 // buildConfigListItem loads the full config data for a match and maps it to ConfigListItem.
 // Used by config info and config list JSON paths.
-func buildConfigListItem(m configMatch, local bool) (ConfigListItem, error) {
+func buildConfigListItem(m configMatch, scope config.Scope) (ConfigListItem, error) {
 	item := ConfigListItem{Category: m.Category, Name: m.Name}
 	switch m.Category {
 	case "agent":
-		agents, _, err := loadAgentsForScope(local)
+		agents, _, err := loadAgentsForScope(scope)
 		if err != nil {
 			return item, err
 		}
@@ -53,8 +75,9 @@ func buildConfigListItem(m configMatch, local bool) (ConfigListItem, error) {
 		item.Tags = agent.Tags
 		item.Source = agent.Source
 		item.Origin = agent.Origin
+		item.Disabled = agent.Disabled
 	case "role":
-		roles, _, err := loadRolesForScope(local)
+		roles, _, err := loadRolesForScope(scope)
 		if err != nil {
 			return item, err
 		}
@@ -70,8 +93,9 @@ func buildConfigListItem(m configMatch, local bool) (ConfigListItem, error) {
 		item.Tags = role.Tags
 		item.Source = role.Source
 		item.Origin = role.Origin
+		item.Disabled = role.Disabled
 	case "context":
-		contexts, _, err := loadContextsForScope(local)
+		contexts, _, err := loadContextsForScope(scope)
 		if err != nil {
 			return item, err
 		}
@@ -88,8 +112,9 @@ func buildConfigListItem(m configMatch, local bool) (ConfigListItem, error) {
 		item.Tags = ctx.Tags
 		item.Source = ctx.Source
 		item.Origin = ctx.Origin
+		item.Disabled = ctx.Disabled
 	case "task":
-		tasks, _, err := loadTasksForScope(local)
+		tasks, _, err := loadTasksForScope(scope)
 		if err != nil {
 			return item, err
 		}
@@ -105,6 +130,7 @@ func buildConfigListItem(m configMatch, local bool) (ConfigListItem, error) {
 		item.Tags = task.Tags
 		item.Source = task.Source
 		item.Origin = task.Origin
+		item.Disabled = task.Disabled
 	default:
 		return item, fmt.Errorf("unknown category %q", m.Category)
 	}
@@ -114,11 +140,11 @@ func buildConfigListItem(m configMatch, local bool) (ConfigListItem, error) {
 // collectConfigListItems loads all configured items for the given category (or all if "").
 // All categories are sorted alphabetically for consistent, analysis-friendly JSON output.
 // The human-readable display preserves injection order for roles and contexts.
-func collectConfigListItems(local bool, category string) ([]ConfigListItem, error) {
+func collectConfigListItems(scope config.Scope, category string) ([]ConfigListItem, error) {
 	var items []ConfigListItem
 
 	if category == "" || category == "agent" {
-		agents, order, err := loadAgentsForScope(local)
+		agents, order, err := loadAgentsForScope(scope)
 		if err != nil {
 			return nil, err
 		}
@@ -128,13 +154,13 @@ func collectConfigListItems(local bool, category string) ([]ConfigListItem, erro
 			items = append(items, ConfigListItem{
 				Category: "agent", Name: name, Bin: a.Bin, Command: a.Command,
 				DefaultModel: a.DefaultModel, Description: a.Description,
-				Models: a.Models, Tags: a.Tags, Source: a.Source, Origin: a.Origin,
+				Models: a.Models, Tags: a.Tags, Source: a.Source, Origin: a.Origin, Disabled: a.Disabled,
 			})
 		}
 	}
 
 	if category == "" || category == "role" {
-		roles, order, err := loadRolesForScope(local)
+		roles, order, err := loadRolesForScope(scope)
 		if err != nil {
 			return nil, err
 		}
@@ -144,13 +170,13 @@ func collectConfigListItems(local bool, category string) ([]ConfigListItem, erro
 			items = append(items, ConfigListItem{
 				Category: "role", Name: name, Command: r.Command, Description: r.Description,
 				File: r.File, Optional: r.Optional, Prompt: r.Prompt,
-				Tags: r.Tags, Source: r.Source, Origin: r.Origin,
+				Tags: r.Tags, Source: r.Source, Origin: r.Origin, Disabled: r.Disabled,
 			})
 		}
 	}
 
 	if category == "" || category == "context" {
-		contexts, order, err := loadContextsForScope(local)
+		contexts, order, err := loadContextsForScope(scope)
 		if err != nil {
 			return nil, err
 		}
@@ -160,13 +186,13 @@ func collectConfigListItems(local bool, category string) ([]ConfigListItem, erro
 			items = append(items, ConfigListItem{
 				Category: "context", Name: name, Command: c.Command, Default: c.Default,
 				Description: c.Description, File: c.File, Prompt: c.Prompt,
-				Required: c.Required, Tags: c.Tags, Source: c.Source, Origin: c.Origin,
+				Required: c.Required, Tags: c.Tags, Source: c.Source, Origin: c.Origin, Disabled: c.Disabled,
 			})
 		}
 	}
 
 	if category == "" || category == "task" {
-		tasks, order, err := loadTasksForScope(local)
+		tasks, order, err := loadTasksForScope(scope)
 		if err != nil {
 			return nil, err
 		}
@@ -176,7 +202,7 @@ func collectConfigListItems(local bool, category string) ([]ConfigListItem, erro
 			items = append(items, ConfigListItem{
 				Category: "task", Name: name, Command: t.Command, Description: t.Description,
 				File: t.File, Prompt: t.Prompt, Role: t.Role,
-				Tags: t.Tags, Source: t.Source, Origin: t.Origin,
+				Tags: t.Tags, Source: t.Source, Origin: t.Origin, Disabled: t.Disabled,
 			})
 		}
 	}
@@ -210,7 +236,7 @@ func runConfigListCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	_, _ = fmt.Fprintln(cmd.OutOrStdout())
-	local := getFlags(cmd).Local
+	scope := getFlags(cmd).Scope()
 	jsonFlag, _ := cmd.Flags().GetBool("json")
 
 	if jsonFlag {
@@ -221,13 +247,14 @@ func runConfigListCmd(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("unknown category %q: expected agent, role, context, or task", args[0])
 			}
 		}
-		items, err := collectConfigListItems(local, category)
+		items, err := collectConfigListItems(scope, category)
 		if err != nil {
 			return err
 		}
 		if items == nil {
 			items = []ConfigListItem{}
 		}
+		setDefinedAt(items, scope)
 		if err := writeJSON(cmd.OutOrStdout(), items); err != nil {
 			return fmt.Errorf("marshalling config list: %w", err)
 		}
@@ -239,19 +266,19 @@ func runConfigListCmd(cmd *cobra.Command, args []string) error {
 
 	if len(args) == 0 {
 		// List all categories
-		if err := listAgents(w, stderr, local); err != nil {
+		if err := listAgents(w, stderr, scope); err != nil {
 			return err
 		}
 		_, _ = fmt.Fprintln(w)
-		if err := listRoles(w, stderr, local); err != nil {
+		if err := listRoles(w, stderr, scope); err != nil {
 			return err
 		}
 		_, _ = fmt.Fprintln(w)
-		if err := listContexts(w, stderr, local); err != nil {
+		if err := listContexts(w, stderr, scope); err != nil {
 			return err
 		}
 		_, _ = fmt.Fprintln(w)
-		return listTasks(w, stderr, local)
+		return listTasks(w, stderr, scope)
 	}
 
 	category := normalizeCategoryArg(args[0])
@@ -261,20 +288,20 @@ func runConfigListCmd(cmd *cobra.Command, args []string) error {
 
 	switch category {
 	case "agent":
-		return listAgents(w, stderr, local)
+		return listAgents(w, stderr, scope)
 	case "role":
-		return listRoles(w, stderr, local)
+		return listRoles(w, stderr, scope)
 	case "context":
-		return listContexts(w, stderr, local)
+		return listContexts(w, stderr, scope)
 	case "task":
-		return listTasks(w, stderr, local)
+		return listTasks(w, stderr, scope)
 	}
 	return nil
 }
 
 // listAgents prints the agents section to w.
-func listAgents(w io.Writer, stderr io.Writer, local bool) error {
-	agents, order, err := loadAgentsForScope(local)
+func listAgents(w io.Writer, stderr io.Writer, scope config.Scope) error {
+	agents, order, err := loadAgentsForScope(scope)
 	if err != nil {
 		printWarning(stderr, "failed to load agents: %s", err)
 	}
@@ -289,7 +316,7 @@ func listAgents(w io.Writer, stderr io.Writer, local bool) error {
 	}
 
 	defaultAgent := ""
-	if cfg, err := loadConfigForScope(local); err == nil {
+	if cfg, err := loadConfigForScope(scope); err == nil {
 		defaultAgent = getDefaultAgentFromConfig(cfg)
 	}
 
@@ -299,10 +326,7 @@ func listAgents(w io.Writer, stderr io.Writer, local bool) error {
 		if name == defaultAgent {
 			marker = tui.ColorInstalled.Sprint("→") + " "
 		}
-		source := agent.Source
-		if agent.Origin != "" {
-			source += ", registry"
-		}
+		source := itemSourceLabel(agent.Source, agent.Origin, agent.Disabled)
 		if agent.Description != "" {
 			_, _ = fmt.Fprintf(w, "%s%s ", marker, name)
 			_, _ = tui.ColorDim.Fprint(w, "- "+agent.Description+" ")
@@ -316,8 +340,8 @@ func listAgents(w io.Writer, stderr io.Writer, local bool) error {
 }
 
 // listRoles prints the roles section to w.
-func listRoles(w io.Writer, stderr io.Writer, local bool) error {
-	roles, order, err := loadRolesForScope(local)
+func listRoles(w io.Writer, stderr io.Writer, scope config.Scope) error {
+	roles, order, err := loadRolesForScope(scope)
 	if err != nil {
 		printWarning(stderr, "failed to load roles: %s", err)
 	}
@@ -333,10 +357,7 @@ func listRoles(w io.Writer, stderr io.Writer, local bool) error {
 
 	for _, name := range order {
 		role := roles[name]
-		source := role.Source
-		if role.Origin != "" {
-			source += ", registry"
-		}
+		source := itemSourceLabel(role.Source, role.Origin, role.Disabled)
 		if role.Description != "" {
 			_, _ = fmt.Fprintf(w, "  %s ", name)
 			_, _ = tui.ColorDim.Fprint(w, "- "+role.Description+" ")
@@ -350,8 +371,8 @@ func listRoles(w io.Writer, stderr io.Writer, local bool) error {
 }
 
 // listContexts prints the contexts section to w.
-func listContexts(w io.Writer, stderr io.Writer, local bool) error {
-	contexts, order, err := loadContextsForScope(local)
+func listContexts(w io.Writer, stderr io.Writer, scope config.Scope) error {
+	contexts, order, err := loadContextsForScope(scope)
 	if err != nil {
 		printWarning(stderr, "failed to load contexts: %s", err)
 	}
@@ -367,10 +388,7 @@ func listContexts(w io.Writer, stderr io.Writer, local bool) error {
 
 	for _, name := range order {
 		ctx := contexts[name]
-		source := ctx.Source
-		if ctx.Origin != "" {
-			source += ", registry"
-		}
+		source := itemSourceLabel(ctx.Source, ctx.Origin, ctx.Disabled)
 		if ctx.Description != "" {
 			_, _ = fmt.Fprintf(w, "  %s ", name)
 			_, _ = tui.ColorDim.Fprint(w, "- "+ctx.Description+" ")
@@ -398,12 +416,12 @@ func listContexts(w io.Writer, stderr io.Writer, local bool) error {
 // runConfigTaskList is a Cobra-compatible wrapper used by task.go.
 func runConfigTaskList(cmd *cobra.Command, _ []string) error {
 	_, _ = fmt.Fprintln(cmd.OutOrStdout())
-	return listTasks(cmd.OutOrStdout(), cmd.ErrOrStderr(), getFlags(cmd).Local)
+	return listTasks(cmd.OutOrStdout(), cmd.ErrOrStderr(), getFlags(cmd).Scope())
 }
 
 // listTasks prints the tasks section to w.
-func listTasks(w io.Writer, stderr io.Writer, local bool) error {
-	tasks, order, err := loadTasksForScope(local)
+func listTasks(w io.Writer, stderr io.Writer, scope config.Scope) error {
+	tasks, order, err := loadTasksForScope(scope)
 	if err != nil {
 		printWarning(stderr, "failed to load tasks: %s", err)
 	}
@@ -419,10 +437,7 @@ func listTasks(w io.Writer, stderr io.Writer, local bool) error {
 
 	for _, name := range order {
 		task := tasks[name]
-		source := task.Source
-		if task.Origin != "" {
-			source += ", registry"
-		}
+		source := itemSourceLabel(task.Source, task.Origin, task.Disabled)
 		if task.Description != "" {
 			_, _ = fmt.Fprintf(w, "  %s ", name)
 			_, _ = tui.ColorDim.Fprint(w, "- "+task.Description+" ")
@@ -434,3 +449,14 @@ func listTasks(w io.Writer, stderr io.Writer, local bool) error {
 	}
 	return nil
 }
+
+// itemSourceLabel returns the source annotation for a listed item.
+func itemSourceLabel(source, origin string, disabled bool) string {
+	if disabled {
+		return "disabled by " + source
+	}
+	if origin != "" {
+		source += ", registry"
+	}
+	return source
+}
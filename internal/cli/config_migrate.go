@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/grantcarthew/start/internal/config"
+	"github.com/grantcarthew/start/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+// addConfigMigrateCommand adds the "config migrate" command.
+func addConfigMigrateCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Update config files to the current format version",
+		Long: `Update global and local config files to the current config format
+version (` + strconv.Itoa(config.CurrentVersion) + `).
+
+Each config directory records its format version in ` + config.VersionFile + `; a
+directory without one is at version 1. Migrations rewrite the CUE files in
+place, keeping comments and field order, and then record the new version.
+Shared layers from include_dirs are not changed.
+
+With --dry-run, the changes are shown as a diff and nothing is written.
+Changes can be reverted with 'start config undo'.`,
+		Example: `  start config migrate --dry-run    Preview the changes
+  start config migrate              Apply them`,
+		Args: cobra.NoArgs,
+		RunE: withBackup(runConfigMigrate),
+	}
+	parent.AddCommand(cmd)
+}
+
+// runConfigMigrate migrates each writable config directory.
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	paths, err := config.ResolvePaths("")
+	if err != nil {
+		return fmt.Errorf("resolving config paths: %w", err)
+	}
+
+	var plans []config.MigrationPlan
+	for _, dir := range append([]string{paths.Global}, paths.Locals()...) {
+		plan, err := config.PlanMigration(dir)
+		if err != nil {
+			return err
+		}
+		if len(plan.Files) > 0 {
+			plans = append(plans, plan)
+		}
+	}
+
+	flags := getFlags(cmd)
+	w := cmd.OutOrStdout()
+	if len(plans) == 0 {
+		if !flags.Quiet {
+			_, _ = fmt.Fprintf(w, "Config is up to date (version %d)\n", config.CurrentVersion)
+		}
+		return nil
+	}
+
+	for _, plan := range plans {
+		if flags.DryRun {
+			_, _ = fmt.Fprintf(w, "%s: version %d → %d\n\n", shortenHome(plan.Dir), plan.From, config.CurrentVersion)
+			for _, f := range plan.Files {
+				name := filepath.Base(f.Path)
+				printUnifiedDiff(w, diff.Unified("a/"+name, "b/"+name, string(f.Before), string(f.After), 3))
+			}
+			continue
+		}
+
+		if err := config.ApplyMigration(plan); err != nil {
+			return err
+		}
+		if flags.Quiet {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "Migrated %s to version %d\n", shortenHome(plan.Dir), config.CurrentVersion)
+		for _, f := range plan.Files {
+			for _, desc := range f.Applied {
+				_, _ = fmt.Fprintf(w, "  %s: %s\n", filepath.Base(f.Path), desc)
+			}
+		}
+	}
+
+	if flags.DryRun && !flags.Quiet {
+		_, _ = fmt.Fprintln(w, "Dry run: no files written")
+	}
+	return nil
+}
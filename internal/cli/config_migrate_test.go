@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grantcarthew/start/internal/config"
+)
+
+func TestConfigMigrate(t *testing.T) {
+	globalDir := setupConfigFlagsTest(t)
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	agentsPath := filepath.Join(globalDir, "agents.cue")
+	original := `agents: claude: {
+	bin: "claude"
+	// Fast model
+	models: fast: {id: "claude-haiku-4"}
+}
+`
+	if err := os.WriteFile(agentsPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := runConfigCmd(t, "migrate", "--dry-run")
+	if err != nil {
+		t.Fatalf("migrate --dry-run: %v\n%s", err, output)
+	}
+	for _, want := range []string{"version 1 → 2", `+	models: fast: "claude-haiku-4"`, "b/" + config.VersionFile, "Dry run: no files written"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("dry run output missing %q:\n%s", want, output)
+		}
+	}
+	if got, _ := os.ReadFile(agentsPath); string(got) != original {
+		t.Errorf("dry run changed agents.cue:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(globalDir, config.VersionFile)); !os.IsNotExist(err) {
+		t.Error("dry run should not create the version file")
+	}
+
+	output, err = runConfigCmd(t, "migrate")
+	if err != nil {
+		t.Fatalf("migrate: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "agents.cue: convert agent models") {
+		t.Errorf("output = %q", output)
+	}
+	got, err := os.ReadFile(agentsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), `models: fast: "claude-haiku-4"`) || !strings.Contains(string(got), "// Fast model") {
+		t.Errorf("agents.cue after migrate:\n%s", got)
+	}
+	if v, err := config.DirVersion(globalDir); err != nil || v != config.CurrentVersion {
+		t.Errorf("DirVersion = %d, %v; want %d", v, err, config.CurrentVersion)
+	}
+
+	output, err = runConfigCmd(t, "migrate")
+	if err != nil || !strings.Contains(output, "up to date") {
+		t.Errorf("second migrate: %v\n%s", err, output)
+	}
+}
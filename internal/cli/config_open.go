@@ -25,7 +25,7 @@ prompted interactively. Plural aliases (agents, roles, etc.) are accepted.
 
 Use --local to target project-specific configuration (.start/).`,
 		Args: cobra.MaximumNArgs(1),
-		RunE: runConfigOpen,
+		RunE: withBackup(runConfigOpen),
 	}
 	parent.AddCommand(cmd)
 }
@@ -36,7 +36,7 @@ func runConfigOpen(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	flags := getFlags(cmd)
-	local := flags.Local
+	scope := flags.Scope()
 
 	category := ""
 	if len(args) > 0 {
@@ -58,7 +58,7 @@ func runConfigOpen(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	path, err := resolveConfigOpenPath(local, category)
+	path, err := resolveConfigOpenPath(scope, category)
 	if err != nil {
 		return err
 	}
@@ -68,7 +68,7 @@ func runConfigOpen(cmd *cobra.Command, args []string) error {
 
 // resolveConfigOpenPath returns the absolute path to the CUE config file for
 // the given category. Both singular and plural forms are accepted.
-func resolveConfigOpenPath(local bool, category string) (string, error) {
+func resolveConfigOpenPath(scope config.Scope, category string) (string, error) {
 	// Normalise plural to singular by stripping a trailing "s".
 	singular := strings.TrimSuffix(strings.ToLower(category), "s")
 
@@ -93,5 +93,5 @@ func resolveConfigOpenPath(local bool, category string) (string, error) {
 		return "", fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	return filepath.Join(paths.Dir(local), filename), nil
+	return filepath.Join(paths.Dir(scope), filename), nil
 }
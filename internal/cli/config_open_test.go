@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/grantcarthew/start/internal/config"
 )
 
 func TestResolveConfigOpenPath(t *testing.T) {
@@ -15,7 +17,7 @@ func TestResolveConfigOpenPath(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		local    bool
+		scope    config.Scope
 		category string
 		wantFile string
 		wantErr  bool
@@ -33,15 +35,15 @@ func TestResolveConfigOpenPath(t *testing.T) {
 		{name: "tasks", category: "tasks", wantFile: "tasks.cue"},
 		{name: "settings", category: "settings", wantFile: "settings.cue"},
 		// local flag
-		{name: "agent local", category: "agent", local: true, wantFile: "agents.cue"},
-		{name: "setting local", category: "setting", local: true, wantFile: "settings.cue"},
+		{name: "agent local", category: "agent", scope: config.ScopeLocal, wantFile: "agents.cue"},
+		{name: "setting local", category: "setting", scope: config.ScopeLocal, wantFile: "settings.cue"},
 		// error case
 		{name: "unknown", category: "unknown", wantErr: true},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got, err := resolveConfigOpenPath(tc.local, tc.category)
+			got, err := resolveConfigOpenPath(tc.scope, tc.category)
 			if tc.wantErr {
 				if err == nil {
 					t.Fatal("expected error, got nil")
@@ -57,7 +59,7 @@ func TestResolveConfigOpenPath(t *testing.T) {
 			if filepath.Base(got) != tc.wantFile {
 				t.Errorf("got filename %q, want %q", filepath.Base(got), tc.wantFile)
 			}
-			if tc.local {
+			if tc.scope == config.ScopeLocal {
 				if !strings.Contains(got, ".start") {
 					t.Errorf("expected local path to contain .start, got %q", got)
 				}
@@ -167,7 +169,7 @@ func TestConfigOpen_InteractivePrompt(t *testing.T) {
 			if category == "" {
 				t.Fatal("expected category, got empty string")
 			}
-			got, err := resolveConfigOpenPath(false, category)
+			got, err := resolveConfigOpenPath(config.ScopeMerged, category)
 			if err != nil {
 				t.Fatalf("unexpected error resolving path: %v", err)
 			}
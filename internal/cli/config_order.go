@@ -25,7 +25,7 @@ Provide a category (role, context) or omit it to be prompted
 interactively. Non-orderable categories (agent, task) fall back
 to the interactive menu.`,
 		Args: cobra.MaximumNArgs(1),
-		RunE: runConfigOrder,
+		RunE: withBackup(runConfigOrder),
 	}
 
 	parent.AddCommand(orderCmd)
@@ -58,7 +58,7 @@ func runConfigOrder(cmd *cobra.Command, args []string) error {
 	}
 
 	stdout := cmd.OutOrStdout()
-	local := getFlags(cmd).Local
+	scope := getFlags(cmd).Scope()
 
 	category := ""
 	if len(args) > 0 {
@@ -84,21 +84,21 @@ func runConfigOrder(cmd *cobra.Command, args []string) error {
 
 	switch category {
 	case "roles":
-		return reorderRoles(stdout, stdin, local)
+		return reorderRoles(stdout, stdin, scope)
 	case "contexts":
-		return reorderContexts(stdout, stdin, local)
+		return reorderContexts(stdout, stdin, scope)
 	}
 	return nil
 }
 
 // reorderContexts performs the interactive context reorder.
-func reorderContexts(stdout io.Writer, stdin io.Reader, local bool) error {
+func reorderContexts(stdout io.Writer, stdin io.Reader, scope config.Scope) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(local)
+	configDir := paths.Dir(scope)
 
 	contexts, order, err := loadContextsFromDir(configDir)
 	if err != nil {
@@ -111,7 +111,7 @@ func reorderContexts(stdout io.Writer, stdin io.Reader, local bool) error {
 	}
 
 	contextPath := filepath.Join(configDir, "contexts.cue")
-	heading := fmt.Sprintf("Reorder Contexts %s:", tui.Annotate("%s - %s", scopeString(local), contextPath))
+	heading := fmt.Sprintf("Reorder Contexts %s:", tui.Annotate("%s - %s", scopeString(scope), contextPath))
 
 	formatItem := func(i int, name string) string {
 		ctx := contexts[name]
@@ -144,13 +144,13 @@ func reorderContexts(stdout io.Writer, stdin io.Reader, local bool) error {
 }
 
 // reorderRoles performs the interactive role reorder.
-func reorderRoles(stdout io.Writer, stdin io.Reader, local bool) error {
+func reorderRoles(stdout io.Writer, stdin io.Reader, scope config.Scope) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(local)
+	configDir := paths.Dir(scope)
 
 	roles, order, err := loadRolesFromDir(configDir)
 	if err != nil {
@@ -163,7 +163,7 @@ func reorderRoles(stdout io.Writer, stdin io.Reader, local bool) error {
 	}
 
 	rolePath := filepath.Join(configDir, "roles.cue")
-	heading := fmt.Sprintf("Reorder Roles %s:", tui.Annotate("%s - %s", scopeString(local), rolePath))
+	heading := fmt.Sprintf("Reorder Roles %s:", tui.Annotate("%s - %s", scopeString(scope), rolePath))
 
 	formatItem := func(i int, name string) string {
 		role := roles[name]
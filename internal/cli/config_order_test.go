@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/grantcarthew/start/internal/config"
 )
 
 func TestResolveOrderCategory(t *testing.T) {
@@ -484,7 +486,7 @@ func TestConfigContextOrder_Command(t *testing.T) {
 
 	// Move item 2 (alpha) up, then save
 	stdout := &bytes.Buffer{}
-	if err := reorderContexts(stdout, strings.NewReader("2\n\n"), false); err != nil {
+	if err := reorderContexts(stdout, strings.NewReader("2\n\n"), config.ScopeMerged); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -536,7 +538,7 @@ func TestConfigRoleOrder_Command(t *testing.T) {
 
 	// Move item 2 (alpha) up, then save
 	stdout := &bytes.Buffer{}
-	if err := reorderRoles(stdout, strings.NewReader("2\n\n"), false); err != nil {
+	if err := reorderRoles(stdout, strings.NewReader("2\n\n"), config.ScopeMerged); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -587,7 +589,7 @@ func TestConfigContextOrder_Cancel(t *testing.T) {
 
 	// Move item 2 up, then cancel
 	stdout := &bytes.Buffer{}
-	if err := reorderContexts(stdout, strings.NewReader("2\nq\n"), false); err != nil {
+	if err := reorderContexts(stdout, strings.NewReader("2\nq\n"), config.ScopeMerged); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -625,7 +627,7 @@ func TestConfigContextOrder_NoContexts(t *testing.T) {
 	chdir(t, tmpDir)
 
 	stdout := &bytes.Buffer{}
-	if err := reorderContexts(stdout, strings.NewReader(""), false); err != nil {
+	if err := reorderContexts(stdout, strings.NewReader(""), config.ScopeMerged); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -657,7 +659,7 @@ func TestConfigContextOrder_SingleItem(t *testing.T) {
 
 	// Test reorder with single item (save immediately)
 	stdout := &bytes.Buffer{}
-	if err := reorderContexts(stdout, strings.NewReader("\n"), false); err != nil {
+	if err := reorderContexts(stdout, strings.NewReader("\n"), config.ScopeMerged); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -693,7 +695,7 @@ func TestConfigContextAdd_PreservesOrder(t *testing.T) {
 
 	// Add a new context "beta" - should appear at end
 	// Prompts: name, description (empty), content choice (Enter=default file), file path, required (N), default (N), tags (skip)
-	if err := configContextAdd(slowStdin("beta\n\n\nbeta.md\n\n\n\n"), &bytes.Buffer{}, false); err != nil {
+	if err := configContextAdd(slowStdin("beta\n\n\nbeta.md\n\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 		t.Fatalf("add failed: %v", err)
 	}
 
@@ -740,7 +742,7 @@ func TestConfigRoleAdd_PreservesOrder(t *testing.T) {
 
 	// Add a new role "beta"
 	// Prompts: name, description (empty), content choice "3" (inline prompt), prompt text, blank line to finish, tags (skip)
-	if err := configRoleAdd(slowStdin("beta\n\n3\nBeta role\n\n\n"), &bytes.Buffer{}, false); err != nil {
+	if err := configRoleAdd(slowStdin("beta\n\n3\nBeta role\n\n\n"), &bytes.Buffer{}, config.ScopeMerged); err != nil {
 		t.Fatalf("add failed: %v", err)
 	}
 
@@ -761,6 +763,7 @@ func TestConfigRoleAdd_PreservesOrder(t *testing.T) {
 	}
 }
 
+// The human-readable role list shows injection order; JSON output is sorted.
 func TestConfigRoleList_SortsAlphabetically(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", tmpDir)
@@ -795,7 +798,7 @@ func TestConfigRoleList_SortsAlphabetically(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	cmd.SetOut(stdout)
 	cmd.SetErr(&bytes.Buffer{})
-	cmd.SetArgs([]string{"config", "list", "role"})
+	cmd.SetArgs([]string{"config", "list", "role", "--json"})
 
 	if err := cmd.Execute(); err != nil {
 		t.Fatalf("unexpected error: %v", err)
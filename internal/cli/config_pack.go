@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/grantcarthew/start/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// addConfigPackCommand adds the "config pack" command.
+func addConfigPackCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "pack [file]",
+		Short: "Write a scope's config files to a bundle for sharing",
+		Long: `Write the config files of global config, or local with --local, to a
+bundle that 'start config apply' can merge into another setup.
+
+The bundle is a gzipped tar archive holding the .cue files and a
+manifest.json that records the bundle format, the config format version, and
+a SHA-256 checksum for each file. The checksum of the whole archive is
+printed so it can be shared alongside the bundle.
+
+The default file name is start-<scope>-config.tar.gz in the working
+directory. Use - to write the bundle to stdout.`,
+		Example: `  start config pack                     Pack global config
+  start config pack --local team.tar.gz Pack local config into team.tar.gz`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runConfigPack,
+	}
+	parent.AddCommand(cmd)
+}
+
+// runConfigPack handles the "config pack [file]" command.
+func runConfigPack(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+	flags := getFlags(cmd)
+
+	paths, err := config.ResolvePaths("")
+	if err != nil {
+		return fmt.Errorf("resolving config paths: %w", err)
+	}
+	scope := scopeString(flags.Scope())
+	dir := paths.Dir(flags.Scope())
+
+	file := fmt.Sprintf("start-%s-config.tar.gz", scope)
+	if len(args) > 0 {
+		file = args[0]
+	}
+
+	var out io.Writer = cmd.OutOrStdout()
+	var f *os.File
+	if file != "-" {
+		// Write beside the target and rename, so a failed pack leaves no
+		// partial bundle behind.
+		f, err = os.CreateTemp(filepath.Dir(file), ".start-pack-*")
+		if err != nil {
+			return fmt.Errorf("creating %s: %w", file, err)
+		}
+		defer func() { _ = os.Remove(f.Name()) }()
+		out = f
+	}
+
+	hash := sha256.New()
+	manifest, err := config.PackBundle(io.MultiWriter(out, hash), dir, scope)
+	if f != nil {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("writing %s: %w", file, closeErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if f == nil {
+		return nil
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", file, err)
+	}
+	if err := os.Rename(f.Name(), file); err != nil {
+		return fmt.Errorf("writing %s: %w", file, err)
+	}
+
+	if !flags.Quiet {
+		w := cmd.OutOrStdout()
+		_, _ = fmt.Fprintf(w, "Packed %d files from %s config %s\n", len(manifest.Files), scope, shortenHome(dir))
+		for _, bf := range manifest.Files {
+			_, _ = fmt.Fprintf(w, "  %s\n", bf.Name)
+		}
+		_, _ = fmt.Fprintf(w, "Bundle: %s (config version %d)\n", file, manifest.ConfigVersion)
+		_, _ = fmt.Fprintf(w, "SHA-256: %s\n", hex.EncodeToString(hash.Sum(nil)))
+	}
+	return nil
+}
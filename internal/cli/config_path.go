@@ -0,0 +1,476 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	cueformat "cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/literal"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+	"github.com/grantcarthew/start/internal/config"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/suggest"
+	"github.com/spf13/cobra"
+)
+
+// configPathHelp describes config paths for the get, set, and unset commands.
+const configPathHelp = `A path names a field by its config keys, separated by dots:
+  agents.claude.command
+  agents.claude.models.fast
+  contexts.readme.tags
+  settings.timeout
+Quote item names that contain dots or slashes: 'agents."gemini/interactive".bin'.`
+
+// addConfigGetCommand adds the "config get <path>" command.
+func addConfigGetCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "get <path>",
+		Short: "Print a config value by path",
+		Long: `Print the effective value of a config field after merging all layers.
+Use --local or --global to read a single scope.
+
+Strings are printed as-is; other values are printed as CUE, or as JSON with
+--json.
+
+` + configPathHelp + `
+
+Examples:
+  start config get agents.claude.command
+  start config get agents.claude.models --json
+  start config get settings.timeout --global`,
+		Args: cobra.ExactArgs(1),
+		RunE: runConfigGet,
+	}
+	cmd.Flags().Bool("global", false, "Read global configuration only")
+	cmd.Flags().Bool("json", false, "Output as JSON")
+	parent.AddCommand(cmd)
+}
+
+// addConfigSetCommand adds the "config set <path> <value>" command.
+func addConfigSetCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "set <path> <value>",
+		Short: "Set a config value by path",
+		Long: `Set a config field in the CUE file that defines it, keeping the rest of the
+file, including comments and field order, as it is.
+
+Writes to global config, or local with --local. The field is changed in the
+file that defines the item or setting in that scope; new items go to the file
+for their category (agents.cue, roles.cue, ...). The merged config is loaded
+after the change and the file is restored if it no longer loads, if the value
+changes the field's type, or if an item is left without its required fields.
+
+The value is read as a CUE literal when it is one (120, true, "text",
+["a", "b"], {key: "value"}); anything else is taken as a string, so most
+strings need no quoting. Use --append or --remove to add or remove one
+element of a list field such as tags.
+
+` + configPathHelp + `
+
+Examples:
+  start config set agents.claude.command 'claude --model {{.model}} {{.prompt}}'
+  start config set agents.claude.models.fast claude-haiku-4
+  start config set settings.timeout 300
+  start config set contexts.readme.tags go --append
+  start config set contexts.readme.tags go --remove
+  start config set --local roles.dev.file ./ROLE.md`,
+		Args: cobra.ExactArgs(2),
+		RunE: withBackup(runConfigSet),
+	}
+	cmd.Flags().Bool("append", false, "Append the value to a list field")
+	cmd.Flags().Bool("remove", false, "Remove the value from a list field")
+	parent.AddCommand(cmd)
+}
+
+// addConfigUnsetCommand adds the "config unset <path>" command.
+func addConfigUnsetCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "unset <path>",
+		Short: "Remove a config value by path",
+		Long: `Remove a config field from the CUE file that defines it, keeping the rest
+of the file as it is. Writes to global config, or local with --local. The
+result is validated as for 'start config set'.
+
+` + configPathHelp + `
+
+Examples:
+  start config unset agents.claude.models.fast
+  start config unset --local settings.default_agent`,
+		Args: cobra.ExactArgs(1),
+		RunE: withBackup(runConfigUnset),
+	}
+	parent.AddCommand(cmd)
+}
+
+// runConfigGet prints the value at a config path.
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+	path, err := parseConfigPath(args[0], false)
+	if err != nil {
+		return err
+	}
+	scope, err := showScopeFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(scope)
+	if err != nil {
+		return err
+	}
+
+	v, err := lookupConfigPath(cfg.Value, path)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if jsonOut, _ := cmd.Flags().GetBool("json"); jsonOut {
+		data, err := encodeConfigData(v, "json")
+		if err != nil {
+			return err
+		}
+		_, _ = w.Write(data)
+		return nil
+	}
+	if s, err := v.String(); err == nil {
+		_, _ = fmt.Fprintln(w, s)
+		return nil
+	}
+	b, err := cueformat.Node(v.Syntax(cue.Final(), cue.Concrete(true)))
+	if err != nil {
+		return fmt.Errorf("formatting %s: %w", args[0], err)
+	}
+	_, _ = fmt.Fprintln(w, string(b))
+	return nil
+}
+
+// runConfigSet sets the value at a config path in the owning file.
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+	path, err := parseConfigPath(args[0], true)
+	if err != nil {
+		return err
+	}
+	appendValue, _ := cmd.Flags().GetBool("append")
+	removeValue, _ := cmd.Flags().GetBool("remove")
+	if appendValue && removeValue {
+		return fmt.Errorf("--append and --remove are mutually exclusive")
+	}
+
+	value := internalcue.ParseLiteral(args[1])
+	if path[0] == internalcue.KeySettings {
+		if value, err = settingExpr(path, args[1], value); err != nil {
+			return err
+		}
+	}
+
+	flags := getFlags(cmd)
+	changed, err := editConfigPath(flags.Scope(), path, func(f *ast.File) (bool, error) {
+		if !appendValue && !removeValue {
+			return true, internalcue.SetField(f, path, value)
+		}
+		return editListField(f, path, value, appendValue)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !flags.Quiet {
+		w := cmd.OutOrStdout()
+		switch {
+		case !changed && removeValue:
+			_, _ = fmt.Fprintf(w, "%s does not contain %s\n", args[0], args[1])
+		case !changed:
+			_, _ = fmt.Fprintf(w, "%s already contains %s\n", args[0], args[1])
+		case appendValue:
+			_, _ = fmt.Fprintf(w, "Appended %s to %s\n", args[1], args[0])
+		case removeValue:
+			_, _ = fmt.Fprintf(w, "Removed %s from %s\n", args[1], args[0])
+		default:
+			_, _ = fmt.Fprintf(w, "Set %s\n", args[0])
+		}
+	}
+	return nil
+}
+
+// runConfigUnset removes the field at a config path from the owning file.
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+	path, err := parseConfigPath(args[0], true)
+	if err != nil {
+		return err
+	}
+
+	flags := getFlags(cmd)
+	_, err = editConfigPath(flags.Scope(), path, func(f *ast.File) (bool, error) {
+		if !internalcue.DeleteField(f, path) {
+			return false, fmt.Errorf("%s is not set in %s config", args[0], scopeString(flags.Scope()))
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !flags.Quiet {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Unset %s\n", args[0])
+	}
+	return nil
+}
+
+// parseConfigPath splits a dotted config path into labels and checks its
+// top-level key. With forWrite set, the path must name at least an item or
+// setting, since whole categories cannot be set.
+func parseConfigPath(s string, forWrite bool) ([]string, error) {
+	var labels []string
+	if p := cue.ParsePath(s); p.Err() == nil {
+		for _, sel := range p.Selectors() {
+			if sel.LabelType() != cue.StringLabel {
+				return nil, fmt.Errorf("invalid path %q: use field names only", s)
+			}
+			labels = append(labels, sel.Unquoted())
+		}
+	} else {
+		labels = strings.Split(s, ".")
+		if slices.Contains(labels, "") {
+			return nil, fmt.Errorf("invalid path %q", s)
+		}
+	}
+
+	keys := []string{internalcue.KeyAgents, internalcue.KeyRoles, internalcue.KeyContexts, internalcue.KeyTasks, internalcue.KeySettings}
+	if len(labels) == 0 || !slices.Contains(keys, labels[0]) {
+		msg := fmt.Sprintf("unknown config key in path %q", s)
+		if len(labels) > 0 {
+			if sug := suggest.Closest(labels[0], keys); sug != "" {
+				msg += fmt.Sprintf("; did you mean %q?", sug)
+			}
+		}
+		return nil, fmt.Errorf("%s", msg)
+	}
+	if forWrite && len(labels) < 2 {
+		return nil, fmt.Errorf("path %q must name an item or setting, e.g. %s.<name>", s, labels[0])
+	}
+	return labels, nil
+}
+
+// lookupConfigPath returns the value at path, suggesting the closest field
+// name when a label does not exist.
+func lookupConfigPath(v cue.Value, path []string) (cue.Value, error) {
+	for i, label := range path {
+		next := v.LookupPath(cue.MakePath(cue.Str(label)))
+		if !next.Exists() {
+			msg := fmt.Sprintf("%q not found", strings.Join(path[:i+1], "."))
+			if sug := suggest.Closest(label, fieldNames(v)); sug != "" {
+				msg += fmt.Sprintf("; did you mean %q?", sug)
+			}
+			return cue.Value{}, fmt.Errorf("%s", msg)
+		}
+		v = next
+	}
+	return v, nil
+}
+
+// fieldNames returns the regular field names of a struct value.
+func fieldNames(v cue.Value) []string {
+	iter, err := v.Fields()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for iter.Next() {
+		names = append(names, iter.Selector().Unquoted())
+	}
+	return names
+}
+
+// settingExpr checks a settings path names a known setting and returns the
+// value to write for it, validated and typed by the settings registry.
+func settingExpr(path []string, raw string, value ast.Expr) (ast.Expr, error) {
+	key := path[1]
+	if _, ok := config.SettingsRegistry[key]; !ok {
+		msg := fmt.Sprintf("unknown setting %q", key)
+		if sug := suggest.Closest(key, config.SettingKeys()); sug != "" {
+			msg += fmt.Sprintf("; did you mean %q?", sug)
+		}
+		return nil, fmt.Errorf("%s\n\nValid settings: %s", msg, config.ValidSettingsKeysString())
+	}
+	if len(path) > 2 {
+		return value, nil
+	}
+	if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if s, err := literal.Unquote(lit.Value); err == nil {
+			raw = s
+		}
+	}
+	if err := config.ValidateSetting(key, raw); err != nil {
+		return nil, err
+	}
+	switch v := config.SettingCUEValue(key, raw).(type) {
+	case int:
+		return ast.NewLit(token.INT, strconv.Itoa(v)), nil
+	case bool:
+		return ast.NewBool(v), nil
+	default:
+		return ast.NewString(raw), nil
+	}
+}
+
+// editListField appends value to, or removes it from, the list at path.
+// Returns whether the list changed. A missing list is created on append.
+func editListField(f *ast.File, path []string, value ast.Expr, appendValue bool) (bool, error) {
+	field := internalcue.FindField(f, path)
+	var list *ast.ListLit
+	if field != nil {
+		var ok bool
+		if list, ok = field.Value.(*ast.ListLit); !ok {
+			return false, fmt.Errorf("%s is not a list", strings.Join(path, "."))
+		}
+	}
+
+	want, err := cueformat.Node(value)
+	if err != nil {
+		return false, err
+	}
+	index := -1
+	if list != nil {
+		for i, elt := range list.Elts {
+			if b, err := cueformat.Node(elt); err == nil && string(b) == string(want) {
+				index = i
+				break
+			}
+		}
+	}
+
+	if appendValue {
+		if index >= 0 {
+			return false, nil
+		}
+		if list == nil {
+			return true, internalcue.SetField(f, path, ast.NewList(value))
+		}
+		list.Elts = append(list.Elts, value)
+		return true, nil
+	}
+	if index < 0 {
+		return false, nil
+	}
+	list.Elts = slices.Delete(list.Elts, index, index+1)
+	return true, nil
+}
+
+// editConfigPath applies edit to the CUE file that owns path in the global or
+// local config directory, then validates the merged config. The file is
+// restored, under the same lock, if validation fails. edit reports whether
+// it changed the file.
+func editConfigPath(scope config.Scope, path []string, edit func(*ast.File) (bool, error)) (bool, error) {
+	paths, err := config.ResolvePaths("")
+	if err != nil {
+		return false, fmt.Errorf("resolving config paths: %w", err)
+	}
+	configDir := paths.Dir(scope)
+
+	// The config before the edit, for type checks; it may not exist yet.
+	before, err := loadConfig(config.ScopeMerged)
+	var noConfig *noConfigError
+	if err != nil && !errors.As(err, &noConfig) {
+		return false, err
+	}
+
+	configPath, err := owningConfigFile(configDir, path)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	err = config.UpdateFileChecked(configPath, func(current []byte) ([]byte, error) {
+		file, err := parser.ParseFile(configPath, current, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", configPath, err)
+		}
+		if changed, err = edit(file); err != nil || !changed {
+			return nil, err
+		}
+		formatted, err := cueformat.Node(file)
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s: %w", configPath, err)
+		}
+		return formatted, nil
+	}, func() error {
+		if err := validateConfigEdit(before.Value, path); err != nil {
+			return fmt.Errorf("%s not changed: %w", filepath.Base(configPath), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return changed, nil
+}
+
+// owningConfigFile returns the file in dir that defines the item or setting
+// named by path, or the category file when dir does not define it.
+func owningConfigFile(dir string, path []string) (string, error) {
+	defs, err := loadDirDefinitions(dir)
+	if err != nil {
+		return "", err
+	}
+	if d := defs.Definitions(path[0], path[1]); len(d) > 0 && d[len(d)-1].File != "" {
+		return d[len(d)-1].File, nil
+	}
+	return filepath.Join(dir, internalcue.ConfigFiles[path[0]]), nil
+}
+
+// validateConfigEdit loads the merged config after an edit and checks the
+// edited field kept its type and the edited item kept its required fields.
+// before is the merged config from before the edit, which may not exist.
+func validateConfigEdit(before cue.Value, path []string) error {
+	after, err := loadConfig(config.ScopeMerged)
+	if err != nil {
+		return err
+	}
+
+	labels := make([]cue.Selector, len(path))
+	for i, label := range path {
+		labels[i] = cue.Str(label)
+	}
+	p := cue.MakePath(labels...)
+	if old, updated := before.LookupPath(p), after.Value.LookupPath(p); before.Exists() && old.Exists() && updated.Exists() {
+		oldKind, newKind := old.IncompleteKind(), updated.IncompleteKind()
+		if oldKind&newKind == cue.BottomKind && !(oldKind&cue.NumberKind != 0 && newKind&cue.NumberKind != 0) {
+			return fmt.Errorf("type mismatch: %s is %s, new value is %s", strings.Join(path, "."), oldKind, newKind)
+		}
+	}
+
+	if path[0] == internalcue.KeySettings {
+		return nil
+	}
+	item := after.Value.LookupPath(cue.MakePath(cue.Str(path[0]), cue.Str(path[1])))
+	if !item.Exists() {
+		return nil
+	}
+	if path[0] == internalcue.KeyAgents {
+		if s, _ := item.LookupPath(cue.ParsePath("command")).String(); s == "" {
+			return fmt.Errorf("agent %q needs a command", path[1])
+		}
+		return nil
+	}
+	for _, field := range []string{"file", "command", "prompt"} {
+		if item.LookupPath(cue.ParsePath(field)).Exists() {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %q needs one of: file, command, or prompt", strings.TrimSuffix(path[0], "s"), path[1])
+}
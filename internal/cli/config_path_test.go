@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const configPathAgents = `// Agents
+agents: {
+	// The main agent
+	claude: {
+		bin:     "claude"
+		command: "claude {{.prompt}}"
+		models: opus: "claude-opus-4"
+	}
+}
+`
+
+// setupConfigPathTest writes global agents and contexts files. Returns the
+// global config dir.
+func setupConfigPathTest(t *testing.T) string {
+	t.Helper()
+	globalDir := setupConfigFlagsTest(t)
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"agents.cue":   configPathAgents,
+		"contexts.cue": `contexts: readme: {file: "README.md", tags: ["docs"]}` + "\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(globalDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return globalDir
+}
+
+func TestConfigGet(t *testing.T) {
+	setupConfigPathTest(t)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"get", "agents.claude.bin"}, "claude\n"},
+		{[]string{"get", "agents.claude.models"}, "{\n\topus: \"claude-opus-4\"\n}\n"},
+		{[]string{"get", "contexts.readme.tags", "--json"}, "[\n  \"docs\"\n]\n"},
+	}
+	for _, tt := range tests {
+		output, err := runConfigCmd(t, tt.args...)
+		if err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if output != tt.want {
+			t.Errorf("%v = %q, want %q", tt.args, output, tt.want)
+		}
+	}
+
+	if _, err := runConfigCmd(t, "get", "agents.claude.modles"); err == nil || !strings.Contains(err.Error(), `did you mean "models"?`) {
+		t.Errorf("missing field: error = %v", err)
+	}
+	if _, err := runConfigCmd(t, "get", "agent.claude"); err == nil || !strings.Contains(err.Error(), `did you mean "agents"?`) {
+		t.Errorf("unknown key: error = %v", err)
+	}
+}
+
+func TestConfigSet(t *testing.T) {
+	globalDir := setupConfigPathTest(t)
+
+	steps := [][]string{
+		{"set", "agents.claude.models.fast", "claude-haiku-4"},
+		{"set", "agents.claude.command", "claude --model {{.model}} {{.prompt}}"},
+		{"set", "contexts.readme.tags", "go", "--append"},
+		{"set", "contexts.readme.tags", "docs", "--remove"},
+		{"set", "settings.timeout", "300"},
+	}
+	for _, args := range steps {
+		if output, err := runConfigCmd(t, args...); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, output)
+		}
+	}
+
+	agents, err := os.ReadFile(filepath.Join(globalDir, "agents.cue"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"// Agents\n", "// The main agent\n", `fast: "claude-haiku-4"`, `command: "claude --model {{.model}} {{.prompt}}"`} {
+		if !strings.Contains(string(agents), want) {
+			t.Errorf("agents.cue missing %q:\n%s", want, agents)
+		}
+	}
+	if output, _ := runConfigCmd(t, "get", "contexts.readme.tags", "--json"); output != "[\n  \"go\"\n]\n" {
+		t.Errorf("tags = %q", output)
+	}
+	if output, _ := runConfigCmd(t, "get", "settings.timeout"); output != "300\n" {
+		t.Errorf("timeout = %q", output)
+	}
+
+	// Appending an existing element changes nothing
+	output, err := runConfigCmd(t, "set", "contexts.readme.tags", "go", "--append")
+	if err != nil || !strings.Contains(output, "already contains") {
+		t.Errorf("duplicate append: %v %q", err, output)
+	}
+}
+
+func TestConfigSet_Validation(t *testing.T) {
+	globalDir := setupConfigPathTest(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"type change", []string{"set", "agents.claude.models", "5"}, "type mismatch"},
+		{"unknown setting", []string{"set", "settings.timeuot", "3"}, `did you mean "timeout"?`},
+		{"non-integer setting", []string{"set", "settings.timeout", "abc"}, "requires an integer"},
+		{"incomplete item", []string{"set", "roles.dev.description", "x"}, "needs one of: file, command, or prompt"},
+		{"whole category", []string{"set", "agents", "x"}, "must name an item"},
+		{"append to non-list", []string{"set", "agents.claude.bin", "x", "--append"}, "is not a list"},
+		{"remove required field", []string{"unset", "agents.claude.command"}, "needs a command"},
+		{"unset missing", []string{"unset", "agents.claude.description"}, "is not set in global config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runConfigCmd(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	agents, err := os.ReadFile(filepath.Join(globalDir, "agents.cue"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(agents) != configPathAgents {
+		t.Errorf("failed edits should restore agents.cue:\n%s", agents)
+	}
+	if _, err := os.Stat(filepath.Join(globalDir, "roles.cue")); !os.IsNotExist(err) {
+		t.Error("failed edit should remove the file it created")
+	}
+}
+
+func TestConfigSet_MissingScopeDir(t *testing.T) {
+	globalDir := setupConfigFlagsTest(t)
+
+	for _, args := range [][]string{
+		{"set", "settings.timeout", "300"},
+		{"set", "settings.shell", "bash", "--local"},
+	} {
+		if output, err := runConfigCmd(t, args...); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, output)
+		}
+	}
+
+	for _, path := range []string{
+		filepath.Join(globalDir, "settings.cue"),
+		filepath.Join(".start", "settings.cue"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s not created: %v", path, err)
+		}
+	}
+}
+
+func TestConfigUnset(t *testing.T) {
+	globalDir := setupConfigPathTest(t)
+
+	if output, err := runConfigCmd(t, "unset", "agents.claude.models.opus"); err != nil {
+		t.Fatalf("unset: %v\n%s", err, output)
+	}
+	agents, err := os.ReadFile(filepath.Join(globalDir, "agents.cue"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(agents), "opus") || !strings.Contains(string(agents), "// The main agent") {
+		t.Errorf("unexpected agents.cue:\n%s", agents)
+	}
+}
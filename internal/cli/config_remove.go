@@ -26,7 +26,7 @@ With no argument, prompts interactively for category and item.
 
 Use --yes / -y to skip the confirmation prompt.`,
 		Args: cobra.MaximumNArgs(1),
-		RunE: runConfigRemove,
+		RunE: withBackup(runConfigRemove),
 	}
 	cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
 	parent.AddCommand(cmd)
@@ -40,19 +40,19 @@ func runConfigRemove(cmd *cobra.Command, args []string) error {
 
 	stdin := cmd.InOrStdin()
 	stdout := cmd.OutOrStdout()
-	local := getFlags(cmd).Local
+	scope := getFlags(cmd).Scope()
 	skipConfirm, _ := cmd.Flags().GetBool("yes")
 
 	if len(args) == 0 {
 		if !isTerminal(stdin) {
 			return fmt.Errorf("interactive remove requires a terminal")
 		}
-		return runConfigRemoveInteractive(stdin, stdout, local, skipConfirm, getFlags(cmd).Quiet)
+		return runConfigRemoveInteractive(stdin, stdout, scope, skipConfirm, getFlags(cmd).Quiet)
 	}
 
 	_, _ = fmt.Fprintln(stdout)
 	query := args[0]
-	matches, err := searchAllConfigCategories(query, local)
+	matches, err := searchAllConfigCategories(query, scope)
 	if err != nil {
 		return err
 	}
@@ -85,7 +85,7 @@ func runConfigRemove(cmd *cobra.Command, args []string) error {
 		if !isTerminal(stdin) {
 			return fmt.Errorf("--yes flag required in non-interactive mode")
 		}
-		confirmed, err := confirmConfigRemoval(stdout, stdin, toRemove, local)
+		confirmed, err := confirmConfigRemoval(stdout, stdin, toRemove, scope)
 		if err != nil {
 			return err
 		}
@@ -96,7 +96,7 @@ func runConfigRemove(cmd *cobra.Command, args []string) error {
 
 	flags := getFlags(cmd)
 	for _, m := range toRemove {
-		if err := removeConfigItem(m, local); err != nil {
+		if err := removeConfigItem(m, scope); err != nil {
 			return fmt.Errorf("removing %s %q: %w", m.Category, m.Name, err)
 		}
 		if !flags.Quiet {
@@ -108,7 +108,7 @@ func runConfigRemove(cmd *cobra.Command, args []string) error {
 }
 
 // runConfigRemoveInteractive prompts for category, item(s), confirmation, then removes.
-func runConfigRemoveInteractive(stdin io.Reader, stdout io.Writer, local bool, skipConfirm bool, quiet bool) error {
+func runConfigRemoveInteractive(stdin io.Reader, stdout io.Writer, scope config.Scope, skipConfirm bool, quiet bool) error {
 	_, _ = fmt.Fprintln(stdout)
 	_, _ = fmt.Fprintln(stdout, "Remove:")
 	category, err := promptSelectCategory(stdout, stdin, allConfigCategories)
@@ -116,7 +116,7 @@ func runConfigRemoveInteractive(stdin io.Reader, stdout io.Writer, local bool, s
 		return err
 	}
 
-	names, err := loadNamesForCategory(category, local)
+	names, err := loadNamesForCategory(category, scope)
 	if err != nil {
 		return err
 	}
@@ -138,7 +138,7 @@ func runConfigRemoveInteractive(stdin io.Reader, stdout io.Writer, local bool, s
 	}
 
 	if !skipConfirm {
-		confirmed, err := confirmConfigRemoval(stdout, stdin, toRemove, local)
+		confirmed, err := confirmConfigRemoval(stdout, stdin, toRemove, scope)
 		if err != nil {
 			return err
 		}
@@ -148,7 +148,7 @@ func runConfigRemoveInteractive(stdin io.Reader, stdout io.Writer, local bool, s
 	}
 
 	for _, m := range toRemove {
-		if err := removeConfigItem(m, local); err != nil {
+		if err := removeConfigItem(m, scope); err != nil {
 			return fmt.Errorf("removing %s %q: %w", m.Category, m.Name, err)
 		}
 		if !quiet {
@@ -161,13 +161,13 @@ func runConfigRemoveInteractive(stdin io.Reader, stdout io.Writer, local bool, s
 
 // confirmConfigRemoval prompts the user to confirm removal of one or more items.
 // Returns false (without error) when the user declines.
-func confirmConfigRemoval(w io.Writer, r io.Reader, items []configMatch, local bool) (bool, error) {
-	scope := scopeString(local)
+func confirmConfigRemoval(w io.Writer, r io.Reader, items []configMatch, scope config.Scope) (bool, error) {
+	scopeName := scopeString(scope)
 	if len(items) == 1 {
 		m := items[0]
-		_, _ = fmt.Fprintf(w, "Remove %s %q from %s config? %s ", m.Category, m.Name, scope, tui.Bracket("y/N"))
+		_, _ = fmt.Fprintf(w, "Remove %s %q from %s config? %s ", m.Category, m.Name, scopeName, tui.Bracket("y/N"))
 	} else {
-		_, _ = fmt.Fprintf(w, "Remove the following items from %s config?\n", scope)
+		_, _ = fmt.Fprintf(w, "Remove the following items from %s config?\n", scopeName)
 		for _, m := range items {
 			_, _ = fmt.Fprintf(w, "  - %s %s\n", m.Category, m.Name)
 		}
@@ -188,27 +188,27 @@ func confirmConfigRemoval(w io.Writer, r io.Reader, items []configMatch, local b
 }
 
 // removeConfigItem removes a single named item from the appropriate config category file.
-func removeConfigItem(m configMatch, local bool) error {
+func removeConfigItem(m configMatch, scope config.Scope) error {
 	switch m.Category {
 	case "agent":
-		return removeAgent(m.Name, local)
+		return removeAgent(m.Name, scope)
 	case "role":
-		return removeRole(m.Name, local)
+		return removeRole(m.Name, scope)
 	case "context":
-		return removeContext(m.Name, local)
+		return removeContext(m.Name, scope)
 	case "task":
-		return removeTask(m.Name, local)
+		return removeTask(m.Name, scope)
 	}
 	return fmt.Errorf("unknown category %q", m.Category)
 }
 
 // removeAgent removes an agent from the config directory.
-func removeAgent(name string, local bool) error {
+func removeAgent(name string, scope config.Scope) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
-	configDir := paths.Dir(local)
+	configDir := paths.Dir(scope)
 
 	agents, _, err := loadAgentsFromDir(configDir)
 	if err != nil && !os.IsNotExist(err) {
@@ -222,12 +222,12 @@ func removeAgent(name string, local bool) error {
 }
 
 // removeRole removes a role from the config directory (preserving order of remaining roles).
-func removeRole(name string, local bool) error {
+func removeRole(name string, scope config.Scope) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
-	configDir := paths.Dir(local)
+	configDir := paths.Dir(scope)
 
 	roles, order, err := loadRolesFromDir(configDir)
 	if err != nil && !os.IsNotExist(err) {
@@ -247,12 +247,12 @@ func removeRole(name string, local bool) error {
 }
 
 // removeContext removes a context from the config directory (preserving order of remaining contexts).
-func removeContext(name string, local bool) error {
+func removeContext(name string, scope config.Scope) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
-	configDir := paths.Dir(local)
+	configDir := paths.Dir(scope)
 
 	contexts, order, err := loadContextsFromDir(configDir)
 	if err != nil && !os.IsNotExist(err) {
@@ -272,12 +272,12 @@ func removeContext(name string, local bool) error {
 }
 
 // removeTask removes a task from the config directory.
-func removeTask(name string, local bool) error {
+func removeTask(name string, scope config.Scope) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
-	configDir := paths.Dir(local)
+	configDir := paths.Dir(scope)
 
 	tasks, _, err := loadTasksFromDir(configDir)
 	if err != nil && !os.IsNotExist(err) {
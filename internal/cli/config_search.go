@@ -101,7 +101,8 @@ func runConfigSearch(cmd *cobra.Command, args []string) error {
 
 	// Search local config (.start/)
 	if paths.LocalExists {
-		cfg, err := loader.LoadSingle(paths.Local)
+		local, err := loader.Load(paths.Locals())
+		cfg := local.Value
 		if err != nil && !errors.Is(err, internalcue.ErrNoCUEFiles) {
 			printWarning(stderr, "failed to load local config: %s", err)
 		} else if err == nil {
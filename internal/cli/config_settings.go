@@ -6,7 +6,6 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 
 	"cuelang.org/go/cue"
@@ -23,13 +22,7 @@ func addConfigSettingsCommand(parent *cobra.Command) {
 		Use:     "settings [key] [value]",
 		Aliases: []string{"setting"},
 		Short:   "Manage settings configuration",
-		Long: `Manage settings for start.
-
-Available settings:
-  assets_index   CUE module path for the assets index (default: built-in)
-  default_agent  Agent to use when --agent not specified
-  shell          Shell for command execution (default: auto-detect)
-  timeout        Command timeout in seconds`,
+		Long:    settingsHelp(),
 		Example: `  start config settings                                               List all settings
   start config settings <key>                                         Show a setting value
   start config settings <key> <val>                                   Set a setting value
@@ -42,8 +35,9 @@ Available settings:
   start config settings default_agent claude
   start config settings shell /bin/bash
   start config settings timeout 120`,
-		Args: cobra.MaximumNArgs(2),
-		RunE: executeConfigSettings,
+		Args:              cobra.MaximumNArgs(2),
+		RunE:              withBackup(executeConfigSettings),
+		ValidArgsFunction: completeSettings,
 	}
 
 	settingsCmd.Flags().Bool("unset", false, "Remove a setting value")
@@ -52,6 +46,60 @@ Available settings:
 	parent.AddCommand(settingsCmd)
 }
 
+// settingsHelp describes each setting in the registry for the command help.
+func settingsHelp() string {
+	keys := config.SettingKeys()
+	width := 0
+	for _, key := range keys {
+		width = max(width, len(key))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Manage settings for start.\n\nAvailable settings:\n")
+	for _, key := range keys {
+		info := config.SettingsRegistry[key]
+		typ := string(info.Type)
+		if info.Type == config.TypeEnum {
+			typ = strings.Join(info.Values, "|")
+		}
+		fmt.Fprintf(&sb, "  %-*s  %s (%s)\n", width, key, info.Description, typ)
+	}
+	sb.WriteString("\nA START_<KEY> environment variable, such as START_TIMEOUT, overrides any setting.")
+	return sb.String()
+}
+
+// completeSettings completes setting keys, then suggested values for the key.
+func completeSettings(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return append(config.SettingKeys(), "edit", "list"), cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		info, ok := config.SettingsRegistry[args[0]]
+		if !ok {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		if info.Type == config.TypePath {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		if args[0] == "default_agent" {
+			return completeAgentNames(), cobra.ShellCompDirectiveNoFileComp
+		}
+		return config.SettingValues(args[0]), cobra.ShellCompDirectiveNoFileComp
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeAgentNames returns the configured agent names, or nil if config
+// cannot be loaded.
+func completeAgentNames() []string {
+	cfg, err := loadConfig(config.ScopeMerged)
+	if err != nil {
+		return nil
+	}
+	return fieldNames(cfg.Value.LookupPath(cue.ParsePath(internalcue.KeyAgents)))
+}
+
 // executeConfigSettings handles the settings command.
 func executeConfigSettings(cmd *cobra.Command, args []string) error {
 	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
@@ -60,7 +108,7 @@ func executeConfigSettings(cmd *cobra.Command, args []string) error {
 
 	stdout := cmd.OutOrStdout()
 	flags := getFlags(cmd)
-	local := flags.Local
+	scope := flags.Scope()
 	unset, _ := cmd.Flags().GetBool("unset")
 	jsonFlag, _ := cmd.Flags().GetBool("json")
 
@@ -71,41 +119,41 @@ func executeConfigSettings(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
 			return fmt.Errorf("--unset takes only one argument")
 		}
-		return unsetSetting(stdout, flags, args[0], local)
+		return unsetSetting(stdout, flags, args[0], scope)
 	}
 
 	switch len(args) {
 	case 0:
 		if jsonFlag {
-			return listSettingsJSON(stdout, local)
+			return listSettingsJSON(stdout, scope)
 		}
 		// List all settings
-		return listSettings(stdout, local)
+		return listSettings(stdout, scope)
 	case 1:
 		if args[0] == "list" || args[0] == "ls" {
 			if jsonFlag {
-				return listSettingsJSON(stdout, local)
+				return listSettingsJSON(stdout, scope)
 			}
-			return listSettings(stdout, local)
+			return listSettings(stdout, scope)
 		}
 		if args[0] == "edit" {
-			return editSettings(local)
+			return editSettings(scope)
 		}
 		if jsonFlag {
-			return showSettingJSON(stdout, args[0], local)
+			return showSettingJSON(stdout, args[0], scope)
 		}
 		// Show single setting
-		return showSetting(stdout, args[0], local)
+		return showSetting(stdout, args[0], scope)
 	case 2:
 		// Set setting
-		return setSetting(stdout, flags, args[0], args[1], local)
+		return setSetting(stdout, flags, args[0], args[1], scope)
 	default:
 		return fmt.Errorf("too many arguments")
 	}
 }
 
 // listSettings displays all settings with their values and sources.
-func listSettings(w io.Writer, localOnly bool) error {
+func listSettings(w io.Writer, scope config.Scope) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
@@ -116,7 +164,7 @@ func listSettings(w io.Writer, localOnly bool) error {
 	printConfigPaths(w, paths)
 	_, _ = fmt.Fprintln(w)
 
-	entries, err := config.ResolveAllSettings(paths, localOnly)
+	entries, err := config.ResolveAllSettings(paths, scope)
 	if err != nil {
 		return err
 	}
@@ -127,23 +175,65 @@ func listSettings(w io.Writer, localOnly bool) error {
 	return nil
 }
 
-// printConfigPaths displays the configuration directory paths.
+// printConfigPaths displays the configuration directory paths, lowest
+// priority first.
 func printConfigPaths(w io.Writer, paths config.Paths) {
 	_, _ = tui.ColorPaths.Fprintln(w, "Configuration Paths:")
+
+	for _, dir := range paths.Policy {
+		printConfigPath(w, "Policy:", dir, "enforced")
+	}
+
+	// Shared read-only layers beneath global config
+	for _, layer := range paths.Shared() {
+		label := strings.ToUpper(layer.Source[:1]) + layer.Source[1:] + ":"
+		printConfigPath(w, label, layer.Dir, "read-only")
+	}
+
 	globalStatus := "not found"
 	if paths.GlobalExists {
 		globalStatus = "exists"
 	}
-	localStatus := "not found"
-	if paths.LocalExists {
-		localStatus = "exists"
+	printConfigPath(w, "Global:", paths.Global, globalStatus)
+
+	// Local layers, outermost first; later layers take precedence
+	var layers []string
+	for _, dir := range paths.Locals() {
+		if dir != paths.Personal {
+			layers = append(layers, dir)
+		}
 	}
-	_, _ = tui.ColorDim.Fprintf(w, "  Global: ")
-	_, _ = fmt.Fprintf(w, "%s ", paths.Global)
-	_, _ = fmt.Fprintln(w, tui.Annotate("%s", globalStatus))
-	_, _ = tui.ColorDim.Fprintf(w, "  Local:  ")
-	_, _ = fmt.Fprintf(w, "%s ", paths.Local)
-	_, _ = fmt.Fprintln(w, tui.Annotate("%s", localStatus))
+	personalStatus := "not found"
+	if paths.PersonalExists {
+		personalStatus = "exists"
+	}
+	defer printConfigPath(w, "Personal:", paths.Personal, personalStatus)
+
+	if len(layers) == 0 {
+		printConfigPath(w, "Local:", paths.Local, "not found")
+		return
+	}
+	for i, dir := range layers {
+		label := "Local:"
+		if i > 0 {
+			label = ""
+		}
+		status := "exists"
+		if len(layers) > 1 {
+			status = fmt.Sprintf("layer %d", i+1)
+			if i == len(layers)-1 {
+				status += ", nearest"
+			}
+		}
+		printConfigPath(w, label, dir, status)
+	}
+}
+
+// printConfigPath prints a labelled config directory with its status.
+func printConfigPath(w io.Writer, label, dir, status string) {
+	_, _ = tui.ColorDim.Fprintf(w, "  %-10s", label)
+	_, _ = fmt.Fprintf(w, "%s ", dir)
+	_, _ = fmt.Fprintln(w, tui.Annotate("%s", status))
 }
 
 // printSettingsEntries displays resolved setting entries in a formatted table.
@@ -177,7 +267,7 @@ func printSettingsEntries(w io.Writer, entries map[string]config.SettingEntry) {
 }
 
 // showSetting displays a single setting value with its source.
-func showSetting(w io.Writer, key string, localOnly bool) error {
+func showSetting(w io.Writer, key string, scope config.Scope) error {
 	if _, valid := config.SettingsRegistry[key]; !valid {
 		return fmt.Errorf("unknown setting %q\n\nValid settings: %s", key, config.ValidSettingsKeysString())
 	}
@@ -187,7 +277,7 @@ func showSetting(w io.Writer, key string, localOnly bool) error {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	entries, err := config.ResolveAllSettings(paths, localOnly)
+	entries, err := config.ResolveAllSettings(paths, scope)
 	if err != nil {
 		return err
 	}
@@ -204,13 +294,13 @@ func showSetting(w io.Writer, key string, localOnly bool) error {
 }
 
 // listSettingsJSON outputs all settings as a JSON object keyed by setting name.
-func listSettingsJSON(w io.Writer, localOnly bool) error {
+func listSettingsJSON(w io.Writer, scope config.Scope) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	entries, err := config.ResolveAllSettings(paths, localOnly)
+	entries, err := config.ResolveAllSettings(paths, scope)
 	if err != nil {
 		return err
 	}
@@ -222,7 +312,7 @@ func listSettingsJSON(w io.Writer, localOnly bool) error {
 }
 
 // showSettingJSON outputs a single setting as a JSON object.
-func showSettingJSON(w io.Writer, key string, localOnly bool) error {
+func showSettingJSON(w io.Writer, key string, scope config.Scope) error {
 	if _, valid := config.SettingsRegistry[key]; !valid {
 		return fmt.Errorf("unknown setting %q\n\nValid settings: %s", key, config.ValidSettingsKeysString())
 	}
@@ -232,7 +322,7 @@ func showSettingJSON(w io.Writer, key string, localOnly bool) error {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	entries, err := config.ResolveAllSettings(paths, localOnly)
+	entries, err := config.ResolveAllSettings(paths, scope)
 	if err != nil {
 		return err
 	}
@@ -245,16 +335,9 @@ func showSettingJSON(w io.Writer, key string, localOnly bool) error {
 }
 
 // setSetting sets a setting value.
-func setSetting(w io.Writer, flags *Flags, key, value string, localOnly bool) error {
-	info, valid := config.SettingsRegistry[key]
-	if !valid {
-		return fmt.Errorf("unknown setting %q\n\nValid settings: %s", key, config.ValidSettingsKeysString())
-	}
-
-	if info.Type == "int" {
-		if _, err := strconv.Atoi(value); err != nil {
-			return fmt.Errorf("setting %q requires an integer value", key)
-		}
+func setSetting(w io.Writer, flags *Flags, key, value string, scope config.Scope) error {
+	if err := config.ValidateSetting(key, value); err != nil {
+		return err
 	}
 
 	// Get config directory
@@ -263,7 +346,15 @@ func setSetting(w io.Writer, flags *Flags, key, value string, localOnly bool) er
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(localOnly)
+	policy, err := config.LoadPolicy(paths)
+	if err != nil {
+		return err
+	}
+	if lock, ok := policy.Locked[key]; ok {
+		return fmt.Errorf("setting %q is locked to %q by policy %s", key, lock.Value, lock.Dir)
+	}
+
+	configDir := paths.Dir(scope)
 
 	// Ensure directory exists
 	if err := os.MkdirAll(configDir, 0755); err != nil {
@@ -287,19 +378,22 @@ func setSetting(w io.Writer, flags *Flags, key, value string, localOnly bool) er
 
 	if !flags.Quiet {
 		_, _ = fmt.Fprintf(w, "Set %s to %q\n", key, value)
+		if envVar := config.SettingEnvVar(key); os.Getenv(envVar) != "" {
+			printWarning(w, "%s is set and overrides this value", envVar)
+		}
 	}
 
 	return nil
 }
 
 // editSettings opens the settings file in the user's editor.
-func editSettings(localOnly bool) error {
+func editSettings(scope config.Scope) error {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(localOnly)
+	configDir := paths.Dir(scope)
 
 	// Ensure directory exists
 	if err := os.MkdirAll(configDir, 0755); err != nil {
@@ -320,7 +414,7 @@ func editSettings(localOnly bool) error {
 
 // loadSettingsForScope loads settings from the appropriate scope.
 // Returns an empty map if no settings are configured.
-func loadSettingsForScope(localOnly bool) (map[string]string, error) {
+func loadSettingsForScope(scope config.Scope) (map[string]string, error) {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return nil, fmt.Errorf("resolving config paths: %w", err)
@@ -328,34 +422,29 @@ func loadSettingsForScope(localOnly bool) (map[string]string, error) {
 
 	settings := make(map[string]string)
 
-	if localOnly {
-		if paths.LocalExists {
-			localSettings, err := config.LoadSettingsFromDir(paths.Local)
-			if err != nil {
-				return nil, err
-			}
-			for k, v := range localSettings {
-				settings[k] = v
-			}
+	for _, dir := range paths.ForScope(scope) {
+		dirSettings, err := config.LoadSettingsFromDir(dir)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		if paths.GlobalExists {
-			globalSettings, err := config.LoadSettingsFromDir(paths.Global)
-			if err != nil {
-				return nil, err
-			}
-			for k, v := range globalSettings {
-				settings[k] = v
-			}
+		for k, v := range dirSettings {
+			settings[k] = v
 		}
-		if paths.LocalExists {
-			localSettings, err := config.LoadSettingsFromDir(paths.Local)
-			if err != nil {
-				return nil, err
-			}
-			for k, v := range localSettings {
-				settings[k] = v
-			}
+	}
+	if scope == config.ScopeMerged {
+		env, err := config.EnvSettings()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range env {
+			settings[k] = v
+		}
+		policy, err := config.LoadPolicy(paths)
+		if err != nil {
+			return nil, err
+		}
+		for k, lock := range policy.Locked {
+			settings[k] = lock.Value
 		}
 	}
 
@@ -386,13 +475,17 @@ func hasNonSettingsContent(content string) bool {
 // writeSettingsFile writes the settings to a CUE file.
 // It checks for existing non-settings content to prevent data loss.
 func writeSettingsFile(path string, settings map[string]string) error {
-	// Check if file exists with non-settings content
-	if existingContent, err := os.ReadFile(path); err == nil {
-		if hasNonSettingsContent(string(existingContent)) {
-			return fmt.Errorf("settings.cue contains non-settings content (agents, roles, etc.)\n\nPlease edit the file manually: %s", path)
+	return config.UpdateFile(path, func(existing []byte) ([]byte, error) {
+		// Check if file exists with non-settings content
+		if hasNonSettingsContent(string(existing)) {
+			return nil, fmt.Errorf("settings.cue contains non-settings content (agents, roles, etc.)\n\nPlease edit the file manually: %s", path)
 		}
-	}
+		return []byte(settingsFileContent(settings)), nil
+	})
+}
 
+// settingsFileContent renders settings as the content of settings.cue.
+func settingsFileContent(settings map[string]string) string {
 	var sb strings.Builder
 
 	sb.WriteString("// Auto-generated by start config\n")
@@ -408,25 +501,29 @@ func writeSettingsFile(path string, settings map[string]string) error {
 		sort.Strings(keys)
 
 		for _, k := range keys {
-			v := settings[k]
-			// Check if this is an int setting
-			if info, exists := config.SettingsRegistry[k]; exists && info.Type == "int" {
-				// Write as integer (no quotes)
-				sb.WriteString(fmt.Sprintf("\t%s: %s\n", k, v))
-			} else {
-				// Write as string (with quotes)
+			// Int and bool settings are written unquoted, lists as CUE lists
+			switch v := config.SettingCUEValue(k, settings[k]).(type) {
+			case string:
 				sb.WriteString(fmt.Sprintf("\t%s: %q\n", k, v))
+			case []string:
+				quoted := make([]string, len(v))
+				for i, entry := range v {
+					quoted[i] = fmt.Sprintf("%q", entry)
+				}
+				sb.WriteString(fmt.Sprintf("\t%s: [%s]\n", k, strings.Join(quoted, ", ")))
+			default:
+				sb.WriteString(fmt.Sprintf("\t%s: %v\n", k, v))
 			}
 		}
 	}
 
 	sb.WriteString("}\n")
 
-	return os.WriteFile(path, []byte(sb.String()), 0644)
+	return sb.String()
 }
 
 // unsetSetting removes a setting key from the settings file.
-func unsetSetting(w io.Writer, flags *Flags, key string, localOnly bool) error {
+func unsetSetting(w io.Writer, flags *Flags, key string, scope config.Scope) error {
 	if _, valid := config.SettingsRegistry[key]; !valid {
 		return fmt.Errorf("unknown setting %q\n\nValid settings: %s", key, config.ValidSettingsKeysString())
 	}
@@ -436,7 +533,7 @@ func unsetSetting(w io.Writer, flags *Flags, key string, localOnly bool) error {
 		return fmt.Errorf("resolving config paths: %w", err)
 	}
 
-	configDir := paths.Dir(localOnly)
+	configDir := paths.Dir(scope)
 
 	// Only load (and propagate errors from) the settings file if it exists.
 	// Absence of the file is not an error — it means nothing is configured.
@@ -665,18 +665,19 @@ func TestScopeString(t *testing.T) {
 
 	tests := []struct {
 		name  string
-		local bool
+		scope config.Scope
 		want  string
 	}{
-		{"local scope", true, "local"},
-		{"global scope", false, "global"},
+		{"local scope", config.ScopeLocal, "local"},
+		{"personal scope", config.ScopePersonal, "personal"},
+		{"global scope", config.ScopeMerged, "global"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := scopeString(tt.local)
+			got := scopeString(tt.scope)
 			if got != tt.want {
-				t.Errorf("scopeString(%v) = %q, want %q", tt.local, got, tt.want)
+				t.Errorf("scopeString(%v) = %q, want %q", tt.scope, got, tt.want)
 			}
 		})
 	}
@@ -880,6 +881,90 @@ func TestConfigSettingsShow_SingleKey(t *testing.T) {
 	}
 }
 
+func TestConfigSettingsShow_EnvOverride(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	t.Setenv("START_DEFAULT_AGENT", "claude")
+
+	globalDir := filepath.Join(tmpDir, "start")
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(globalDir, "settings.cue"), []byte(`settings: default_agent: "gemini"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	chdir(t, tmpDir)
+
+	cmd := NewRootCmd()
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"config", "settings", "default_agent"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := stdout.String(); !strings.Contains(output, "default_agent: claude (env)") {
+		t.Errorf("expected 'default_agent: claude (env)', got: %s", output)
+	}
+
+	cmd = NewRootCmd()
+	stdout.Reset()
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"config", "settings", "default_agent", "gemini"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := stdout.String(); !strings.Contains(output, "START_DEFAULT_AGENT is set and overrides this value") {
+		t.Errorf("expected override warning, got: %s", output)
+	}
+}
+
+func TestConfigSettings_PolicyLocked(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_DIRS", filepath.Join(tmpDir, "system"))
+
+	policyDir := filepath.Join(tmpDir, "system", "start", "policy")
+	if err := os.MkdirAll(policyDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(policyDir, "policy.cue"), []byte(`locked: secret_policy: "block"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	chdir(t, tmpDir)
+
+	cmd := NewRootCmd()
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"config", "settings", "secret_policy"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := stdout.String(); !strings.Contains(output, "secret_policy: block (policy)") {
+		t.Errorf("expected 'secret_policy: block (policy)', got: %s", output)
+	}
+
+	cmd = NewRootCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"config", "settings", "secret_policy", "warn"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "locked") {
+		t.Errorf("expected locked error, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "start", "settings.cue")); !os.IsNotExist(err) {
+		t.Error("locked setting should not be written")
+	}
+}
+
 func TestConfigSettingsShow_NotSet(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", tmpDir)
@@ -1018,7 +1103,10 @@ func TestResolveAssetsIndexPath(t *testing.T) {
 			t.Fatalf("set failed: %v", err)
 		}
 
-		got := resolveAssetsIndexPath()
+		got, err := resolveAssetsIndexPath()
+		if err != nil {
+			t.Fatalf("resolveAssetsIndexPath() error: %v", err)
+		}
 		if got != "github.com/example/custom/index@v0" {
 			t.Errorf("resolveAssetsIndexPath() = %q, want %q", got, "github.com/example/custom/index@v0")
 		}
@@ -1030,11 +1118,35 @@ func TestResolveAssetsIndexPath(t *testing.T) {
 
 		chdir(t, tmpDir)
 
-		got := resolveAssetsIndexPath()
+		got, err := resolveAssetsIndexPath()
+		if err != nil {
+			t.Fatalf("resolveAssetsIndexPath() error: %v", err)
+		}
 		if got != "" {
 			t.Errorf("resolveAssetsIndexPath() = %q, want empty string", got)
 		}
 	})
+
+	t.Run("fails when the policy cannot be read", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		t.Setenv("XDG_CONFIG_HOME", tmpDir)
+		t.Setenv("XDG_CONFIG_DIRS", filepath.Join(tmpDir, "system"))
+
+		policyDir := filepath.Join(tmpDir, "system", "start", "policy")
+		if err := os.MkdirAll(policyDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(policyDir, "policy.cue"), []byte(`locked: assets_index: `), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		chdir(t, tmpDir)
+
+		got, err := resolveAssetsIndexPath()
+		if err == nil {
+			t.Fatalf("resolveAssetsIndexPath() = %q, want error for the broken policy", got)
+		}
+	})
 }
 
 func TestConfigSettingsSet(t *testing.T) {
@@ -1104,6 +1216,43 @@ func TestConfigSettingsSet_Integer(t *testing.T) {
 	}
 }
 
+func TestConfigSettingsSet_List(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	chdir(t, tmpDir)
+
+	cmd := NewRootCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"config", "settings", "secret_patterns", "ghp_[a-z]+\nsk-[a-z]+"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	settingsPath := filepath.Join(tmpDir, "start", "settings.cue")
+	content, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatalf("failed to read settings file: %v", err)
+	}
+	if !strings.Contains(string(content), `secret_patterns: ["ghp_[a-z]+", "sk-[a-z]+"]`) {
+		t.Errorf("settings file missing secret_patterns as a CUE list, content: %s", content)
+	}
+
+	cfg, err := loadMergedConfigFromDir(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, scanner, err := loadSecretSettings(cfg.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if findings := scanner.Scan("prompt", "token sk-abcdef"); len(findings) == 0 {
+		t.Error("expected the listed pattern to detect a secret")
+	}
+}
+
 func TestConfigSettingsSet_InvalidValue(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", tmpDir)
@@ -1127,6 +1276,33 @@ func TestConfigSettingsSet_InvalidValue(t *testing.T) {
 	}
 }
 
+func TestConfigSettingsSet_ValidatesValue(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	chdir(t, tmpDir)
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"timeout", "0"}, "must be greater than zero"},
+		{[]string{"secret_policy", "ignore"}, "must be one of: warn, redact, block"},
+		{[]string{"secret_patterns", "(["}, "secret_patterns"},
+	}
+	for _, tt := range tests {
+		cmd := NewRootCmd()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"config", "settings"}, tt.args...))
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("settings %v: error = %v, want containing %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
 func TestHasNonSettingsContent(t *testing.T) {
 	tests := []struct {
 		name    string
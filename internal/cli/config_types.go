@@ -3,7 +3,6 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -21,14 +20,18 @@ type AgentConfig struct {
 	Description  string            `json:"description,omitempty"`
 	Models       map[string]string `json:"models,omitempty"`
 	Tags         []string          `json:"tags,omitempty"`
-	Source       string            `json:"source"`           // "global" or "local"
-	Origin       string            `json:"origin,omitempty"` // Registry module path when installed from registry
+	Instructions string            `json:"instructionsFile,omitempty"`
+	Source       string            `json:"source"`             // Config layer: "global", "local", or a shared layer
+	Origin       string            `json:"origin,omitempty"`   // Registry module path when installed from registry
+	Extends      string            `json:"extends,omitempty"`  // Item this one inherits fields from
+	Merge        bool              `json:"merge,omitempty"`    // Merge into the same-named item from an earlier layer
+	Disabled     bool              `json:"disabled,omitempty"` // Set by enabled: false; hides an item from lower layers
 }
 
 // loadAgentsForScope loads agents from the appropriate scope.
 // Returns the agents map, names in definition order, and any error.
-func loadAgentsForScope(localOnly bool) (map[string]AgentConfig, []string, error) {
-	return loadForScope(localOnly, loadAgentsFromDir, func(a *AgentConfig, s string) { a.Source = s })
+func loadAgentsForScope(scope config.Scope) (map[string]AgentConfig, []string, error) {
+	return loadForScope(scope, loadAgentsFromDir, func(a *AgentConfig, s string) { a.Source = s })
 }
 
 // loadAgentsFromDir loads agents from a specific directory.
@@ -75,6 +78,9 @@ func loadAgentsFromDir(dir string) (map[string]AgentConfig, []string, error) {
 		if v := val.LookupPath(cue.ParsePath("description")); v.Exists() {
 			agent.Description, _ = v.String()
 		}
+		if v := val.LookupPath(cue.ParsePath("instructions_file")); v.Exists() {
+			agent.Instructions, _ = v.String()
+		}
 
 		agent.Tags = extractTags(val)
 
@@ -97,6 +103,15 @@ func loadAgentsFromDir(dir string) (map[string]AgentConfig, []string, error) {
 			agent.Origin, _ = v.String()
 		}
 
+		// Load inheritance directives
+		if v := val.LookupPath(cue.ParsePath(internalcue.FieldExtends)); v.Exists() {
+			agent.Extends, _ = v.String()
+		}
+		if v := val.LookupPath(cue.ParsePath(internalcue.FieldMerge)); v.Exists() {
+			agent.Merge, _ = v.Bool()
+		}
+		agent.Disabled = internalcue.IsDisabled(val)
+
 		agents[name] = agent
 		order = append(order, name)
 	}
@@ -128,6 +143,15 @@ func writeAgentsFile(path string, agents map[string]AgentConfig) error {
 		if agent.Origin != "" {
 			sb.WriteString(fmt.Sprintf("\t\torigin: %q\n", agent.Origin))
 		}
+		if agent.Extends != "" {
+			sb.WriteString(fmt.Sprintf("\t\textends: %q\n", agent.Extends))
+		}
+		if agent.Merge {
+			sb.WriteString("\t\tmerge: true\n")
+		}
+		if agent.Disabled {
+			sb.WriteString("\t\tenabled: false\n")
+		}
 		if agent.Bin != "" {
 			sb.WriteString(fmt.Sprintf("\t\tbin:     %q\n", agent.Bin))
 		}
@@ -141,6 +165,9 @@ func writeAgentsFile(path string, agents map[string]AgentConfig) error {
 		if agent.Description != "" {
 			sb.WriteString(fmt.Sprintf("\t\tdescription: %q\n", agent.Description))
 		}
+		if agent.Instructions != "" {
+			sb.WriteString(fmt.Sprintf("\t\tinstructions_file: %q\n", agent.Instructions))
+		}
 		writeCUETags(&sb, agent.Tags)
 		if len(agent.Models) > 0 {
 			sb.WriteString("\t\tmodels: {\n")
@@ -160,11 +187,11 @@ func writeAgentsFile(path string, agents map[string]AgentConfig) error {
 
 	sb.WriteString("}\n")
 
-	return os.WriteFile(path, []byte(sb.String()), 0644)
+	return config.WriteFile(path, []byte(sb.String()))
 }
 
 // loadConfigForScope loads the settings.cue settings for the scope.
-func loadConfigForScope(localOnly bool) (cue.Value, error) {
+func loadConfigForScope(scope config.Scope) (cue.Value, error) {
 	paths, err := config.ResolvePaths("")
 	if err != nil {
 		return cue.Value{}, err
@@ -172,14 +199,7 @@ func loadConfigForScope(localOnly bool) (cue.Value, error) {
 
 	loader := internalcue.NewLoader()
 
-	var dirs []string
-	if localOnly {
-		if paths.LocalExists {
-			dirs = []string{paths.Local}
-		}
-	} else {
-		dirs = paths.ForScope(config.ScopeMerged)
-	}
+	dirs := paths.ForScope(scope)
 
 	if len(dirs) == 0 {
 		return cue.Value{}, fmt.Errorf("no config found")
@@ -189,8 +209,15 @@ func loadConfigForScope(localOnly bool) (cue.Value, error) {
 	if err != nil {
 		return cue.Value{}, err
 	}
+	if scope != config.ScopeMerged {
+		return result.Value, nil
+	}
 
-	return result.Value, nil
+	v, err := config.ApplyEnvSettings(loader, result.Value)
+	if err != nil {
+		return cue.Value{}, err
+	}
+	return config.ApplyPolicy(loader, v, paths)
 }
 
 // getDefaultAgentFromConfig extracts default_agent from config value.
@@ -212,14 +239,17 @@ type RoleConfig struct {
 	Prompt      string   `json:"prompt,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
 	Optional    bool     `json:"optional,omitempty"` // If true, skip gracefully when file is missing
-	Source      string   `json:"source"`             // "global" or "local"
+	Source      string   `json:"source"`             // Config layer: "global", "local", or a shared layer
 	Origin      string   `json:"origin,omitempty"`   // Registry module path when installed from registry
+	Extends     string   `json:"extends,omitempty"`  // Item this one inherits fields from
+	Merge       bool     `json:"merge,omitempty"`    // Merge into the same-named item from an earlier layer
+	Disabled    bool     `json:"disabled,omitempty"` // Set by enabled: false; hides an item from lower layers
 }
 
 // loadRolesForScope loads roles from the appropriate scope.
 // Returns the roles map, names in definition order, and any error.
-func loadRolesForScope(localOnly bool) (map[string]RoleConfig, []string, error) {
-	return loadForScope(localOnly, loadRolesFromDir, func(r *RoleConfig, s string) { r.Source = s })
+func loadRolesForScope(scope config.Scope) (map[string]RoleConfig, []string, error) {
+	return loadForScope(scope, loadRolesFromDir, func(r *RoleConfig, s string) { r.Source = s })
 }
 
 // loadRolesFromDir loads roles from a specific directory.
@@ -274,6 +304,15 @@ func loadRolesFromDir(dir string) (map[string]RoleConfig, []string, error) {
 			role.Origin, _ = v.String()
 		}
 
+		// Load inheritance directives
+		if v := val.LookupPath(cue.ParsePath(internalcue.FieldExtends)); v.Exists() {
+			role.Extends, _ = v.String()
+		}
+		if v := val.LookupPath(cue.ParsePath(internalcue.FieldMerge)); v.Exists() {
+			role.Merge, _ = v.Bool()
+		}
+		role.Disabled = internalcue.IsDisabled(val)
+
 		// Load optional field
 		if v := val.LookupPath(cue.ParsePath("optional")); v.Exists() {
 			role.Optional, _ = v.Bool()
@@ -303,6 +342,15 @@ func writeRolesFile(path string, roles map[string]RoleConfig, order []string) er
 		if role.Origin != "" {
 			sb.WriteString(fmt.Sprintf("\t\torigin: %q\n", role.Origin))
 		}
+		if role.Extends != "" {
+			sb.WriteString(fmt.Sprintf("\t\textends: %q\n", role.Extends))
+		}
+		if role.Merge {
+			sb.WriteString("\t\tmerge: true\n")
+		}
+		if role.Disabled {
+			sb.WriteString("\t\tenabled: false\n")
+		}
 		if role.Description != "" {
 			sb.WriteString(fmt.Sprintf("\t\tdescription: %q\n", role.Description))
 		}
@@ -323,7 +371,7 @@ func writeRolesFile(path string, roles map[string]RoleConfig, order []string) er
 
 	sb.WriteString("}\n")
 
-	return os.WriteFile(path, []byte(sb.String()), 0644)
+	return config.WriteFile(path, []byte(sb.String()))
 }
 
 // ContextConfig represents a context configuration for editing.
@@ -336,14 +384,17 @@ type ContextConfig struct {
 	Required    bool     `json:"required,omitempty"`
 	Default     bool     `json:"default,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
-	Source      string   `json:"source"`           // "global" or "local"
-	Origin      string   `json:"origin,omitempty"` // Registry module path when installed from registry
+	Source      string   `json:"source"`             // Config layer: "global", "local", or a shared layer
+	Origin      string   `json:"origin,omitempty"`   // Registry module path when installed from registry
+	Extends     string   `json:"extends,omitempty"`  // Item this one inherits fields from
+	Merge       bool     `json:"merge,omitempty"`    // Merge into the same-named item from an earlier layer
+	Disabled    bool     `json:"disabled,omitempty"` // Set by enabled: false; hides an item from lower layers
 }
 
 // loadContextsForScope loads contexts from the appropriate scope.
 // Returns the contexts map, names in definition order, and any error.
-func loadContextsForScope(localOnly bool) (map[string]ContextConfig, []string, error) {
-	return loadForScope(localOnly, loadContextsFromDir, func(c *ContextConfig, s string) { c.Source = s })
+func loadContextsForScope(scope config.Scope) (map[string]ContextConfig, []string, error) {
+	return loadForScope(scope, loadContextsFromDir, func(c *ContextConfig, s string) { c.Source = s })
 }
 
 // loadContextsFromDir loads contexts from a specific directory.
@@ -404,6 +455,15 @@ func loadContextsFromDir(dir string) (map[string]ContextConfig, []string, error)
 			ctx.Origin, _ = v.String()
 		}
 
+		// Load inheritance directives
+		if v := val.LookupPath(cue.ParsePath(internalcue.FieldExtends)); v.Exists() {
+			ctx.Extends, _ = v.String()
+		}
+		if v := val.LookupPath(cue.ParsePath(internalcue.FieldMerge)); v.Exists() {
+			ctx.Merge, _ = v.Bool()
+		}
+		ctx.Disabled = internalcue.IsDisabled(val)
+
 		contexts[name] = ctx
 		order = append(order, name)
 	}
@@ -428,6 +488,15 @@ func writeContextsFile(path string, contexts map[string]ContextConfig, order []s
 		if ctx.Origin != "" {
 			sb.WriteString(fmt.Sprintf("\t\torigin: %q\n", ctx.Origin))
 		}
+		if ctx.Extends != "" {
+			sb.WriteString(fmt.Sprintf("\t\textends: %q\n", ctx.Extends))
+		}
+		if ctx.Merge {
+			sb.WriteString("\t\tmerge: true\n")
+		}
+		if ctx.Disabled {
+			sb.WriteString("\t\tenabled: false\n")
+		}
 		if ctx.Description != "" {
 			sb.WriteString(fmt.Sprintf("\t\tdescription: %q\n", ctx.Description))
 		}
@@ -451,7 +520,7 @@ func writeContextsFile(path string, contexts map[string]ContextConfig, order []s
 
 	sb.WriteString("}\n")
 
-	return os.WriteFile(path, []byte(sb.String()), 0644)
+	return config.WriteFile(path, []byte(sb.String()))
 }
 
 // TaskConfig represents a task configuration for editing.
@@ -463,14 +532,17 @@ type TaskConfig struct {
 	Prompt      string   `json:"prompt,omitempty"`
 	Role        string   `json:"role,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
-	Source      string   `json:"source"`           // "global" or "local"
-	Origin      string   `json:"origin,omitempty"` // Registry module path when installed from registry
+	Source      string   `json:"source"`             // Config layer: "global", "local", or a shared layer
+	Origin      string   `json:"origin,omitempty"`   // Registry module path when installed from registry
+	Extends     string   `json:"extends,omitempty"`  // Item this one inherits fields from
+	Merge       bool     `json:"merge,omitempty"`    // Merge into the same-named item from an earlier layer
+	Disabled    bool     `json:"disabled,omitempty"` // Set by enabled: false; hides an item from lower layers
 }
 
 // loadTasksForScope loads tasks from the appropriate scope.
 // Returns the tasks map, names in definition order, and any error.
-func loadTasksForScope(localOnly bool) (map[string]TaskConfig, []string, error) {
-	return loadForScope(localOnly, loadTasksFromDir, func(t *TaskConfig, s string) { t.Source = s })
+func loadTasksForScope(scope config.Scope) (map[string]TaskConfig, []string, error) {
+	return loadForScope(scope, loadTasksFromDir, func(t *TaskConfig, s string) { t.Source = s })
 }
 
 // loadTasksFromDir loads tasks from a specific directory.
@@ -528,6 +600,15 @@ func loadTasksFromDir(dir string) (map[string]TaskConfig, []string, error) {
 			task.Origin, _ = v.String()
 		}
 
+		// Load inheritance directives
+		if v := val.LookupPath(cue.ParsePath(internalcue.FieldExtends)); v.Exists() {
+			task.Extends, _ = v.String()
+		}
+		if v := val.LookupPath(cue.ParsePath(internalcue.FieldMerge)); v.Exists() {
+			task.Merge, _ = v.Bool()
+		}
+		task.Disabled = internalcue.IsDisabled(val)
+
 		tasks[name] = task
 		order = append(order, name)
 	}
@@ -559,6 +640,15 @@ func writeTasksFile(path string, tasks map[string]TaskConfig) error {
 		if task.Origin != "" {
 			sb.WriteString(fmt.Sprintf("\t\torigin: %q\n", task.Origin))
 		}
+		if task.Extends != "" {
+			sb.WriteString(fmt.Sprintf("\t\textends: %q\n", task.Extends))
+		}
+		if task.Merge {
+			sb.WriteString("\t\tmerge: true\n")
+		}
+		if task.Disabled {
+			sb.WriteString("\t\tenabled: false\n")
+		}
 		if task.Description != "" {
 			sb.WriteString(fmt.Sprintf("\t\tdescription: %q\n", task.Description))
 		}
@@ -579,5 +669,5 @@ func writeTasksFile(path string, tasks map[string]TaskConfig) error {
 
 	sb.WriteString("}\n")
 
-	return os.WriteFile(path, []byte(sb.String()), 0644)
+	return config.WriteFile(path, []byte(sb.String()))
 }
@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/grantcarthew/start/internal/diff"
+	"github.com/grantcarthew/start/internal/orchestration"
+	"github.com/grantcarthew/start/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// maxDiffArgLen is the display limit for a single command argument in diff output.
+const maxDiffArgLen = 72
+
+// addDiffCommand adds the diff command to the parent command.
+func addDiffCommand(parent *cobra.Command) {
+	diffCmd := &cobra.Command{
+		Use:     "diff <snapshot> [snapshot]",
+		GroupID: "commands",
+		Short:   "Compare two compositions",
+		Long: `Compare the composed role, prompt, contexts, and agent command of two
+compositions.
+
+A snapshot is a dry-run directory written by --dry-run (role.md, prompt.md,
+command.txt, contexts.json). With two arguments, the snapshots are compared
+directly. With one argument, the snapshot is compared against the current
+composition, built the same way as 'start' using the --agent, --role,
+--model, --context, and --no-role flags.
+
+Output shows agent, model and role changes, a summary of added (+), removed (-)
+and changed (~) contexts, command argument differences, and unified diffs of
+role.md and prompt.md. Snapshots written before contexts.json existed are
+compared by context name only.
+
+Config history entries ('start config history') are not snapshots: they hold
+the config files a command changed, not a composition, so they cannot be
+compared. Save a snapshot with --dry-run before changing config instead.
+
+Examples:
+  start --dry-run                                  Save a snapshot
+  start diff /tmp/start-20260101120000             Compare snapshot to now
+  start diff /tmp/start-20260101120000 /tmp/start-20260102120000
+  start diff /tmp/start-20260101120000 -c golang   Compare with extra contexts`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: runDiff,
+	}
+	parent.AddCommand(diffCmd)
+}
+
+// runDiff compares two snapshots, or a snapshot and the current composition.
+func runDiff(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		if err := checkSnapshotArg(arg); err != nil {
+			return err
+		}
+	}
+
+	oldSnap, err := orchestration.ReadSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+
+	var newSnap orchestration.Snapshot
+	if len(args) == 2 {
+		newSnap, err = orchestration.ReadSnapshot(args[1])
+		if err != nil {
+			return err
+		}
+	} else {
+		newSnap, err = currentSnapshot(cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	printSnapshotDiff(cmd.OutOrStdout(), orchestration.CompareSnapshots(oldSnap, newSnap))
+	return nil
+}
+
+// checkSnapshotArg rejects a config history id given in place of a snapshot
+// directory. History entries record config files, not compositions.
+func checkSnapshotArg(arg string) error {
+	if _, err := strconv.Atoi(arg); err != nil {
+		return nil
+	}
+	if _, err := os.Stat(arg); err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s is a config history id, not a snapshot; history entries hold config files, not compositions\nSave a snapshot with 'start --dry-run' before changing config", arg)
+}
+
+// currentSnapshot composes the prompt as 'start' would and captures it as a snapshot.
+// Composition output (agent selection notices, warnings) goes to stderr so
+// stdout carries only the diff.
+func currentSnapshot(cmd *cobra.Command) (orchestration.Snapshot, error) {
+	flags := getFlags(cmd)
+	selection := orchestration.ContextSelection{
+		IncludeRequired: true,
+		IncludeDefaults: true,
+		Tags:            flags.Context,
+	}
+	stderr := cmd.ErrOrStderr()
+	plan, err := composeStart(stderr, stderr, cmd.InOrStdin(), flags, selection, "")
+	if err != nil {
+		return orchestration.Snapshot{}, err
+	}
+	// Match the model recorded in dry-run command.txt (the --model value)
+	return orchestration.NewSnapshot(plan.env.Agent, plan.execConfig.Model, plan.result, plan.cmdStr), nil
+}
+
+// printSnapshotDiff prints the differences between two snapshots.
+func printSnapshotDiff(w io.Writer, d orchestration.SnapshotDiff) {
+	printHeader(w, "Composition Diff")
+	printSeparator(w)
+
+	_, _ = tui.ColorDim.Fprint(w, "Old:")
+	_, _ = fmt.Fprintf(w, " %s\n", d.Old.Source)
+	_, _ = tui.ColorDim.Fprint(w, "New:")
+	_, _ = fmt.Fprintf(w, " %s\n", d.New.Source)
+	_, _ = fmt.Fprintln(w)
+
+	if !d.HasChanges() {
+		_, _ = fmt.Fprintln(w, "No differences")
+		return
+	}
+
+	printValueChange(w, "Agent:", tui.ColorAgents, d.Old.Agent, d.New.Agent)
+	printValueChange(w, "Model:", tui.ColorAgents, d.Old.Model, d.New.Model)
+	printValueChange(w, "Role:", tui.ColorRoles, d.Old.RoleName, d.New.RoleName)
+
+	if len(d.AddedContexts)+len(d.RemovedContexts)+len(d.ChangedContexts) > 0 {
+		_, _ = tui.ColorContexts.Fprintln(w, "Contexts:")
+		for _, name := range d.AddedContexts {
+			_, _ = tui.ColorSuccess.Fprintf(w, "  + %s\n", name)
+		}
+		for _, name := range d.RemovedContexts {
+			_, _ = tui.ColorError.Fprintf(w, "  - %s\n", name)
+		}
+		for _, name := range d.ChangedContexts {
+			_, _ = tui.ColorWarning.Fprintf(w, "  ~ %s\n", name)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+
+	if diff.HasChanges(d.Args) {
+		_, _ = tui.ColorAgents.Fprintln(w, "Command:")
+		for _, e := range d.Args {
+			switch e.Op {
+			case diff.Delete:
+				_, _ = tui.ColorError.Fprintf(w, "  - %s\n", truncateArg(e.Text))
+			case diff.Insert:
+				_, _ = tui.ColorSuccess.Fprintf(w, "  + %s\n", truncateArg(e.Text))
+			}
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+
+	printUnifiedDiff(w, d.Role)
+	printUnifiedDiff(w, d.Prompt)
+}
+
+// printValueChange prints "Label: old → new" when the values differ.
+func printValueChange(w io.Writer, label string, labelColor *color.Color, oldVal, newVal string) {
+	if oldVal == newVal {
+		return
+	}
+	_, _ = labelColor.Fprint(w, label)
+	_, _ = fmt.Fprintf(w, " %s → %s\n\n", orDash(oldVal), orDash(newVal))
+}
+
+// printUnifiedDiff prints a unified diff with coloured lines.
+func printUnifiedDiff(w io.Writer, text string) {
+	if text == "" {
+		return
+	}
+	for i, line := range diff.SplitLines(text) {
+		switch {
+		case i < 2:
+			// --- and +++ file headers
+			_, _ = tui.ColorDim.Fprintln(w, line)
+		case strings.HasPrefix(line, "@@"):
+			_, _ = tui.ColorCyan.Fprintln(w, line)
+		case strings.HasPrefix(line, "-"):
+			_, _ = tui.ColorError.Fprintln(w, line)
+		case strings.HasPrefix(line, "+"):
+			_, _ = tui.ColorSuccess.Fprintln(w, line)
+		default:
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// truncateArg shortens long command arguments and flattens newlines for display.
+func truncateArg(arg string) string {
+	arg = strings.ReplaceAll(arg, "\n", "\\n")
+	if runes := []rune(arg); len(runes) > maxDiffArgLen {
+		return string(runes[:maxDiffArgLen-3]) + "..."
+	}
+	return arg
+}
+
+// orDash returns "-" for empty values.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
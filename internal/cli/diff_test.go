@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grantcarthew/start/internal/orchestration"
+)
+
+// dryRunSnapshot runs a dry-run start and returns the written directory.
+func dryRunSnapshot(t *testing.T, tmpDir string) string {
+	t.Helper()
+	stdout := new(bytes.Buffer)
+	err := executeStart(stdout, new(bytes.Buffer), strings.NewReader(""), &Flags{DryRun: true}, orchestration.ContextSelection{
+		IncludeRequired: true,
+		IncludeDefaults: true,
+	}, "")
+	if err != nil {
+		t.Fatalf("executeStart() error = %v", err)
+	}
+	matches, err := filepath.Glob(filepath.Join(tmpDir, "start-*"))
+	if err != nil || len(matches) == 0 {
+		t.Fatalf("no dry-run directory found in %s", tmpDir)
+	}
+	return matches[len(matches)-1]
+}
+
+func TestDiff_SnapshotAgainstCurrent(t *testing.T) {
+	tmpDir := setupStartTestConfig(t)
+	chdir(t, tmpDir)
+	t.Setenv("TMPDIR", tmpDir)
+
+	snapDir := dryRunSnapshot(t, tmpDir)
+	if _, err := os.Stat(filepath.Join(snapDir, orchestration.DryRunContextsFile)); err != nil {
+		t.Fatalf("dry-run did not write %s: %v", orchestration.DryRunContextsFile, err)
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		cmd := NewRootCmd()
+		stdout := new(bytes.Buffer)
+		cmd.SetOut(stdout)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"diff", snapDir})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("diff error = %v", err)
+		}
+		if !strings.Contains(stdout.String(), "No differences") {
+			t.Errorf("expected 'No differences', got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("changed context", func(t *testing.T) {
+		configFile := filepath.Join(tmpDir, ".start", "settings.cue")
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		updated := strings.Replace(string(data), `prompt: "Project context"`, `prompt: "Project context v2"`, 1)
+		if err := os.WriteFile(configFile, []byte(updated), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		cmd := NewRootCmd()
+		stdout := new(bytes.Buffer)
+		cmd.SetOut(stdout)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"diff", snapDir})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("diff error = %v", err)
+		}
+		output := stdout.String()
+		for _, want := range []string{"~ project", "-Project context", "+Project context v2"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("expected %q in output, got:\n%s", want, output)
+			}
+		}
+	})
+}
+
+func TestDiff_TwoSnapshots(t *testing.T) {
+	tmpDir := setupStartTestConfig(t)
+	chdir(t, tmpDir)
+	t.Setenv("TMPDIR", tmpDir)
+
+	first := dryRunSnapshot(t, tmpDir)
+	if err := os.Rename(first, filepath.Join(tmpDir, "snap-a")); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := new(bytes.Buffer)
+	err := executeStart(stdout, new(bytes.Buffer), strings.NewReader(""), &Flags{DryRun: true, NoRole: true}, orchestration.ContextSelection{
+		IncludeRequired: true,
+	}, "")
+	if err != nil {
+		t.Fatalf("executeStart() error = %v", err)
+	}
+	second, _ := filepath.Glob(filepath.Join(tmpDir, "start-*"))
+	if len(second) != 1 {
+		t.Fatalf("expected one new dry-run directory, got %v", second)
+	}
+
+	cmd := NewRootCmd()
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"diff", filepath.Join(tmpDir, "snap-a"), second[0]})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("diff error = %v", err)
+	}
+	output := out.String()
+	for _, want := range []string{"assistant → -", "- project", "--- a/role.md"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestDiff_InvalidSnapshot(t *testing.T) {
+	cmd := NewRootCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"diff", t.TempDir(), t.TempDir()})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "not a dry-run directory") {
+		t.Errorf("expected not a dry-run directory error, got %v", err)
+	}
+}
+
+func TestDiff_HistoryID(t *testing.T) {
+	cmd := NewRootCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"diff", "12", "14"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "config history id") {
+		t.Errorf("expected config history id error, got %v", err)
+	}
+}
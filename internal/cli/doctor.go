@@ -2,16 +2,24 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"time"
 
 	"cuelang.org/go/cue"
+	"cuelang.org/go/mod/modconfig"
 	"github.com/grantcarthew/start/internal/assets"
 	"github.com/grantcarthew/start/internal/cache"
 	"github.com/grantcarthew/start/internal/config"
 	internalcue "github.com/grantcarthew/start/internal/cue"
 	"github.com/grantcarthew/start/internal/doctor"
+	"github.com/grantcarthew/start/internal/orchestration"
 	"github.com/grantcarthew/start/internal/registry"
+	"github.com/grantcarthew/start/internal/secrets"
+	"github.com/grantcarthew/start/internal/temp"
 	"github.com/spf13/cobra"
 )
 
@@ -30,6 +38,8 @@ Checks performed:
   - Schema validation (fetched from registry)
   - Agent binary availability
   - Context and role file existence
+  - Secrets in the default composition (required and default contexts)
+  - Local config trust (commands from ./.start/ need 'start trust')
   - Environment (directory permissions)
 
 Exit codes:
@@ -96,14 +106,20 @@ func prepareDoctor() (doctor.Report, error) {
 	report.Sections = append(report.Sections, doctor.CheckIntro())
 
 	// Version section
-	indexPath := resolveAssetsIndexPath()
+	// A config or policy that cannot be read is reported below; the
+	// default index is not queried in its place.
+	indexPath, indexErr := resolveAssetsIndexPath()
+	var indexVersion string
+	if indexErr == nil {
+		indexVersion = resolveIndexVersion(indexPath)
+	}
 	buildInfo := doctor.BuildInfo{
 		Version:      cliVersion,
 		Commit:       commit,
 		BuildDate:    buildDate,
 		GoVersion:    doctor.DefaultBuildInfo().GoVersion,
 		Platform:     doctor.DefaultBuildInfo().Platform,
-		IndexVersion: resolveIndexVersion(indexPath),
+		IndexVersion: indexVersion,
 		IndexPath:    indexPath,
 	}
 	report.Sections = append(report.Sections, doctor.CheckVersion(buildInfo))
@@ -130,6 +146,12 @@ func prepareDoctor() (doctor.Report, error) {
 		dirs := paths.ForScope(config.ScopeMerged)
 		if len(dirs) > 0 {
 			cfgResult, err = loader.Load(dirs)
+			if err == nil {
+				cfgResult.Value, err = config.ApplyEnvSettings(loader, cfgResult.Value)
+			}
+			if err == nil {
+				cfgResult.Value, err = config.ApplyPolicy(loader, cfgResult.Value, paths)
+			}
 			if err == nil {
 				cfgLoaded = true
 			}
@@ -191,12 +213,70 @@ func prepareDoctor() (doctor.Report, error) {
 		})
 	}
 
+	// Secret scan of the default composition
+	if cfgLoaded {
+		report.Sections = append(report.Sections, checkDoctorSecrets(cfgResult))
+	} else {
+		report.Sections = append(report.Sections, doctor.SectionResult{
+			Name: "Secrets",
+			Results: []doctor.CheckResult{
+				{Status: doctor.StatusInfo, Label: "Skipped", Message: "no valid config"},
+			},
+		})
+	}
+
+	// Local config trust
+	report.Sections = append(report.Sections, doctor.CheckTrust(paths))
+
+	// Organisation policy
+	report.Sections = append(report.Sections, doctor.CheckPolicy(paths, settingsCfg))
+
 	// Environment checks
 	report.Sections = append(report.Sections, doctor.CheckEnvironment(paths))
 
 	return report, nil
 }
 
+// checkDoctorSecrets composes the default start prompt (required and default
+// contexts with the default role) and scans it for secrets. Context commands
+// run exactly as they would for 'start'. Files the composition writes go to
+// a throwaway directory, so no session is created or garbage collected.
+func checkDoctorSecrets(cfg internalcue.LoadResult) doctor.SectionResult {
+	policy, scanner, err := loadSecretSettings(cfg.Value)
+	if err != nil {
+		return doctor.CheckSecrets(secrets.DefaultPolicy, nil, err)
+	}
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return doctor.CheckSecrets(policy, nil, err)
+	}
+
+	// Never run commands from untrusted local config (no prompt in doctor)
+	blocked, err := checkLocalTrust(workingDir, &Flags{}, io.Discard, strings.NewReader(""))
+	if err != nil {
+		return doctor.CheckSecrets(policy, nil, err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "start-doctor-")
+	if err != nil {
+		return doctor.CheckSecrets(policy, nil, err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	processor := orchestration.NewTemplateProcessor(nil, newShellRunner(blocked), workingDir)
+	composer := orchestration.NewComposer(processor, temp.NewSessionManager(tempDir), workingDir)
+	result, err := composer.ComposeWithRole(cfg.Value, orchestration.ContextSelection{
+		IncludeRequired: true,
+		IncludeDefaults: true,
+	}, "", "")
+	if err != nil {
+		return doctor.CheckSecrets(policy, nil, err)
+	}
+
+	return doctor.CheckSecrets(policy, orchestration.ScanSecrets(scanner, result), nil)
+}
+
 // fetchAndValidateSchemas fetches schemas from the registry and validates config files.
 func fetchAndValidateSchemas(paths config.Paths) doctor.SectionResult {
 	if !paths.AnyExists() {
@@ -208,50 +288,58 @@ func fetchAndValidateSchemas(paths config.Paths) doctor.SectionResult {
 		}
 	}
 
-	client, err := registry.NewClient()
+	schemas, err := fetchSchemas()
 	if err != nil {
 		return doctor.SectionResult{
 			Name: "Schema Validation",
 			Results: []doctor.CheckResult{
-				{Status: doctor.StatusInfo, Label: "Skipped", Message: "registry unavailable"},
+				{Status: doctor.StatusInfo, Label: "Skipped", Message: err.Error()},
 			},
 		}
 	}
 
+	return doctor.CheckSchemaValidation(paths, schemas)
+}
+
+// fetchSchemas fetches the latest asset schemas from the registry.
+func fetchSchemas() (doctor.SchemaSet, error) {
+	client, err := registry.NewClient()
+	if err != nil {
+		return doctor.SchemaSet{}, errors.New("registry unavailable")
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	resolvedPath, err := client.ResolveLatestVersion(ctx, registry.SchemaModulePath)
 	if err != nil {
-		return doctor.SectionResult{
-			Name: "Schema Validation",
-			Results: []doctor.CheckResult{
-				{Status: doctor.StatusInfo, Label: "Skipped", Message: "cannot resolve schema version"},
-			},
-		}
+		return doctor.SchemaSet{}, errors.New("cannot resolve schema version")
 	}
 
 	result, err := client.Fetch(ctx, resolvedPath)
 	if err != nil {
-		return doctor.SectionResult{
-			Name: "Schema Validation",
-			Results: []doctor.CheckResult{
-				{Status: doctor.StatusInfo, Label: "Skipped", Message: "cannot fetch schemas"},
-			},
-		}
+		return doctor.SchemaSet{}, errors.New("cannot fetch schemas")
 	}
 
 	schemas, err := doctor.LoadSchemas(result.SourceDir, client.Registry())
 	if err != nil {
-		return doctor.SectionResult{
-			Name: "Schema Validation",
-			Results: []doctor.CheckResult{
-				{Status: doctor.StatusInfo, Label: "Skipped", Message: fmt.Sprintf("cannot load schemas: %v", err)},
-			},
-		}
+		return doctor.SchemaSet{}, fmt.Errorf("cannot load schemas: %w", err)
 	}
+	return schemas, nil
+}
 
-	return doctor.CheckSchemaValidation(paths, schemas)
+// cachedSchemas loads the newest asset schemas already in the CUE module
+// cache, without a network call.
+func cachedSchemas() (doctor.SchemaSet, error) {
+	dir, err := orchestration.ResolveModulePath("@module/", registry.SchemaModulePath)
+	if err != nil {
+		return doctor.SchemaSet{}, err
+	}
+	var reg modconfig.Registry
+	if client, err := registry.NewClient(); err == nil {
+		reg = client.Registry()
+	}
+	return doctor.LoadSchemas(dir, reg)
 }
 
 // resolveIndexVersion returns the latest index version string (e.g., "v0.3.2").
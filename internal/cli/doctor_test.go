@@ -8,6 +8,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/temp"
 )
 
 // cleanupCUECache makes CUE module cache files writable before t.TempDir() cleanup.
@@ -353,3 +357,49 @@ func TestDoctorCommand_JSONHasFlag(t *testing.T) {
 		t.Error("doctor command should have --json flag")
 	}
 }
+
+func TestCheckDoctorSecrets_NoSessionSideEffects(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	chdir(t, tmpDir)
+
+	sessionsDir := filepath.Join(tmpDir, "sessions")
+	stale := filepath.Join(sessionsDir, "session-20200101000000-99999999")
+	if err := os.MkdirAll(stale, 0700); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-2 * temp.DefaultSessionMaxAge)
+	if err := os.Chtimes(stale, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	configDir := filepath.Join(tmpDir, "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `
+contexts: env: {
+	required: true
+	prompt: "Environment context"
+}
+settings: temp_dir: "` + sessionsDir + `"
+`
+	if err := os.WriteFile(filepath.Join(configDir, "settings.cue"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := internalcue.NewLoader().Load([]string{configDir})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	checkDoctorSecrets(cfg)
+
+	entries, err := os.ReadDir(sessionsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != filepath.Base(stale) {
+		t.Errorf("session directory changed by doctor: %v", entries)
+	}
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"cuelang.org/go/cue"
+	"github.com/grantcarthew/start/internal/config"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/lint"
+	"github.com/grantcarthew/start/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// lintError is a silent exit-code-1 error for lint failures.
+type lintError struct{}
+
+func (e *lintError) Error() string { return "lint issues found" }
+func (e *lintError) Silent() bool  { return true }
+
+// addLintCommand adds the lint command to the parent command.
+func addLintCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:     "lint",
+		GroupID: "utilities",
+		Short:   "Check templates and config for mistakes",
+		Long: `Check agent commands and role, context, and task templates for mistakes
+that load without error but misbehave at runtime.
+
+Checks performed:
+  - Template syntax errors
+  - Unknown placeholders, which render empty (with "did you mean" suggestions)
+  - file and command fields the prompt never references
+  - Tasks that ignore instructions (no {{.instructions}} placeholder)
+  - Task roles that are not defined
+  - Roles that are never the default and no task uses (info only)
+
+Templates read from a file are checked in that file. Command output is only
+known at runtime and is not checked.
+
+Exit codes:
+  0 - No errors or warnings
+  1 - Errors or warnings found
+
+Examples:
+  start lint              Lint global and local config
+  start lint --local      Lint local config only
+  start lint --json       Output issues as JSON for CI`,
+		Args: noArgsOrHelp,
+		RunE: runLint,
+	}
+
+	cmd.Flags().Bool("json", false, "Output as JSON")
+	parent.AddCommand(cmd)
+}
+
+// runLint lints the configuration and prints the issues found.
+func runLint(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	flags := getFlags(cmd)
+	scope := flags.Scope()
+	cfg, err := loadLintConfig(scope)
+	if err != nil {
+		return err
+	}
+
+	issues := lint.Check(cfg)
+	if issues == nil {
+		issues = []lint.Issue{}
+	}
+
+	w := cmd.OutOrStdout()
+	if jsonFlag, _ := cmd.Flags().GetBool("json"); jsonFlag {
+		if err := writeJSON(w, issues); err != nil {
+			return fmt.Errorf("writing JSON: %w", err)
+		}
+	} else {
+		printLintIssues(w, issues, flags.Quiet)
+	}
+
+	if lint.HasFailures(issues) {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &lintError{}
+	}
+	return nil
+}
+
+// loadLintConfig loads the merged config for scope along with each config
+// directory on its own, local first, so issues can report file positions.
+func loadLintConfig(scope config.Scope) (lint.Config, error) {
+	result, err := loadConfig(scope)
+	if err != nil {
+		return lint.Config{}, err
+	}
+
+	paths, err := config.ResolvePaths("")
+	if err != nil {
+		return lint.Config{}, fmt.Errorf("resolving config paths: %w", err)
+	}
+	dirs := paths.ForScope(scope)
+
+	loader := internalcue.NewLoader()
+	var sources []cue.Value
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if v, err := loader.LoadSingle(dirs[i]); err == nil {
+			sources = append(sources, v)
+		}
+	}
+	return lint.Config{Value: result.Value, Sources: sources}, nil
+}
+
+// printLintIssues prints issues one per line, followed by a summary.
+func printLintIssues(w io.Writer, issues []lint.Issue, quiet bool) {
+	counts := make(map[lint.Severity]int)
+	for _, i := range issues {
+		counts[i.Severity]++
+		if quiet && i.Severity == lint.SeverityInfo {
+			continue
+		}
+		if pos := i.Position(); pos != "" {
+			_, _ = fmt.Fprintf(w, "%s: ", pos)
+		}
+		switch i.Severity {
+		case lint.SeverityError:
+			_, _ = tui.ColorError.Fprint(w, "error")
+		case lint.SeverityWarning:
+			_, _ = tui.ColorWarning.Fprint(w, "warning")
+		default:
+			_, _ = tui.ColorDim.Fprint(w, "info")
+		}
+		_, _ = fmt.Fprintf(w, ": %s %s: %s %s\n", i.Kind, i.Name, i.Message, tui.Annotate("%s", i.Rule))
+	}
+
+	if quiet {
+		return
+	}
+	if len(issues) == 0 {
+		_, _ = tui.ColorSuccess.Fprintln(w, "No issues found")
+		return
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "%s, %s, %s\n",
+		plural(counts[lint.SeverityError], "error"),
+		plural(counts[lint.SeverityWarning], "warning"),
+		plural(counts[lint.SeverityInfo], "info"))
+}
+
+// plural formats a count with a noun, adding "s" when count is not 1.
+// "info" is uncountable.
+func plural(count int, noun string) string {
+	if count == 1 || noun == "info" {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grantcarthew/start/internal/lint"
+)
+
+// setupLintTest creates global and local config and changes into the project.
+func setupLintTest(t *testing.T, global, local string) (globalFile, localFile string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	globalFile = filepath.Join(tmpDir, "start", "config.cue")
+	localFile = filepath.Join(tmpDir, "project", ".start", "config.cue")
+	for file, content := range map[string]string{globalFile: global, localFile: local} {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chdir(t, filepath.Join(tmpDir, "project"))
+	return globalFile, localFile
+}
+
+func runLintCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewRootCmd()
+	stdout := new(bytes.Buffer)
+	cmd.SetOut(stdout)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs(append([]string{"lint"}, args...))
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestLint_Clean(t *testing.T) {
+	setupLintTest(t, `roles: dev: prompt: "Developer"`, `tasks: fix: prompt: "Fix {{.instructions}}"`)
+
+	output, err := runLintCmd(t)
+	if err != nil {
+		t.Fatalf("lint error = %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "No issues found") {
+		t.Errorf("expected no issues, got:\n%s", output)
+	}
+}
+
+func TestLint_ReportsPositionsAcrossScopes(t *testing.T) {
+	globalFile, localFile := setupLintTest(t,
+		`contexts: env: prompt: "Host {{.hostnam}}"`,
+		"tasks: fix: {\n\tprompt: \"Fix {{.instruction}}\"\n}\n")
+
+	output, err := runLintCmd(t)
+	if err == nil || !IsSilentError(err) {
+		t.Fatalf("expected silent lint error, got %v", err)
+	}
+	for _, want := range []string{
+		globalFile + ":1: error: context env: unknown placeholder {{.hostnam}}",
+		"did you mean {{.hostname}}?",
+		localFile + ":2: error: task fix: unknown placeholder {{.instruction}}",
+		"2 errors, 0 warnings, 0 info",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	// --local skips global config
+	output, _ = runLintCmd(t, "--local")
+	if strings.Contains(output, "hostnam") {
+		t.Errorf("--local output includes global issue:\n%s", output)
+	}
+}
+
+func TestLint_JSON(t *testing.T) {
+	setupLintTest(t, `roles: dev: prompt: "Developer"`, `tasks: fix: prompt: "Fix the bug"`)
+
+	output, err := runLintCmd(t, "--json")
+	if err == nil {
+		t.Fatal("expected lint error for missing instructions")
+	}
+	var issues []lint.Issue
+	if err := json.Unmarshal([]byte(output), &issues); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if len(issues) != 1 || issues[0].Rule != lint.RuleMissingInstructions || issues[0].Name != "fix" {
+		t.Errorf("issues = %+v", issues)
+	}
+}
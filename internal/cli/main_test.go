@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain keeps config backups written by commands under test out of the
+// user's state directory.
+func TestMain(m *testing.M) {
+	stateDir, err := os.MkdirTemp("", "start-state-")
+	if err != nil {
+		panic(err)
+	}
+	_ = os.Setenv("XDG_STATE_HOME", stateDir)
+	code := m.Run()
+	_ = os.RemoveAll(stateDir)
+	os.Exit(code)
+}
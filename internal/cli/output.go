@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/grantcarthew/start/internal/orchestration"
+	"github.com/grantcarthew/start/internal/secrets"
 	"github.com/grantcarthew/start/internal/tui"
 )
 
@@ -220,3 +221,36 @@ func printRoleTable(w io.Writer, resolutions []orchestration.RoleResolution) {
 	}
 	_, _ = fmt.Fprintln(w)
 }
+
+// printSecretFindings prints secrets detected in the composition as a table
+// of source, rule, line, and masked value.
+func printSecretFindings(w io.Writer, report orchestration.SecretReport) {
+	if len(report.Findings) == 0 {
+		return
+	}
+
+	var action string
+	switch report.Policy {
+	case secrets.PolicyRedact:
+		action = "redacted"
+	case secrets.PolicyBlock:
+		action = "blocked"
+	default:
+		action = "sent unchanged"
+	}
+	_, _ = tui.ColorWarning.Fprint(w, "Secrets:")
+	_, _ = fmt.Fprintf(w, " %s\n", tui.Annotate("%d found, %s", len(report.Findings), action))
+
+	sourceWidth := 6 // "Source" header
+	ruleWidth := 4   // "Rule" header
+	for _, f := range report.Findings {
+		sourceWidth = max(sourceWidth, len(f.Source))
+		ruleWidth = max(ruleWidth, len(f.Rule))
+	}
+
+	_, _ = tui.ColorDim.Fprintf(w, "  %-*s  %-*s  %-4s  %s\n", sourceWidth, "Source", ruleWidth, "Rule", "Line", "Value")
+	for _, f := range report.Findings {
+		_, _ = fmt.Fprintf(w, "  %-*s  %-*s  %-4d  %s\n", sourceWidth, f.Source, ruleWidth, f.Rule, f.Line, f.Preview)
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -8,7 +8,6 @@ import (
 
 	"cuelang.org/go/cue"
 	"github.com/grantcarthew/start/internal/orchestration"
-	"github.com/grantcarthew/start/internal/shell"
 	"github.com/spf13/cobra"
 )
 
@@ -51,7 +50,7 @@ To inspect strictly within --local, ensure the asset is already installed.`,
 		RunE: runRead,
 	}
 
-	readCmd.PersistentFlags().Bool("global", false, "Read from global scope only")
+	readCmd.PersistentFlags().Bool("global", false, "Read from global config and shared layers only")
 
 	parent.AddCommand(readCmd)
 }
@@ -131,7 +130,7 @@ func runRead(cmd *cobra.Command, args []string) error {
 	if cat.itemType == "Agent" {
 		return readAgent(stdout, stderr, flags, r, match.Name, item)
 	}
-	return readUTD(stdout, stderr, flags, match.Name, cat.itemType, item)
+	return readUTD(stdout, stderr, stdin, flags, match.Name, cat.itemType, item)
 }
 
 // readResolveQuery returns the asset query, prompting interactively when no
@@ -196,7 +195,7 @@ func readAgent(stdout, stderr io.Writer, flags *Flags, r *resolver, name string,
 // trim block below flips it by clearing higher-priority sources before Process
 // runs. Shell and Timeout are execution config and pass through untouched so a
 // command-source asset still honours its declared shell and timeout.
-func readUTD(stdout, stderr io.Writer, flags *Flags, name, itemType string, item cue.Value) error {
+func readUTD(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, name, itemType string, item cue.Value) error {
 	fields := orchestration.ExtractUTDFields(item)
 	if !orchestration.IsUTDValid(fields) {
 		return fmt.Errorf("asset %q has no content fields (expected one of: file, prompt, command)", name)
@@ -259,9 +258,16 @@ func readUTD(stdout, stderr io.Writer, flags *Flags, name, itemType string, item
 		printReadVerbose(stderr, itemType, name, item, resolvedFile, fields.Command, fromModuleCache)
 	}
 
+	var blocked *untrustedConfig
+	if fields.Command != "" {
+		blocked, err = checkLocalTrust(workingDir, flags, stderr, stdin)
+		if err != nil {
+			return err
+		}
+	}
+
 	fr := &orchestration.DefaultFileReader{}
-	sr := shell.NewRunner()
-	processor := orchestration.NewTemplateProcessor(fr, sr, workingDir)
+	processor := orchestration.NewTemplateProcessor(fr, newShellRunner(blocked), workingDir)
 
 	result, err := processor.Process(fields, "")
 	if err != nil {
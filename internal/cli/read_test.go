@@ -176,6 +176,9 @@ tasks: {
 		t.Fatalf("writing config: %v", err)
 	}
 
+	// The command-source assets are local config; approve them to run.
+	trustLocalConfig(t, startDir)
+
 	chdir(t, dir)
 	return dir
 }
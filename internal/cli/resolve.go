@@ -655,7 +655,11 @@ func (r *resolver) ensureIndex() (*registry.Index, *registry.Client, error) {
 
 	// Check cache for a fresh canonical version to avoid network calls.
 	// Only use the cache when it belongs to the same module as the configured index.
-	indexPath := resolveAssetsIndexPath()
+	indexPath, err := resolveAssetsIndexPath()
+	if err != nil {
+		r.indexErr = err
+		return nil, nil, err
+	}
 	effectivePath := registry.EffectiveIndexPath(indexPath)
 	usedCache := false
 	cached, cacheErr := cache.ReadIndex()
@@ -714,14 +718,16 @@ func (r *resolver) ensureIndex() (*registry.Index, *registry.Client, error) {
 }
 
 // resolveAssetsIndexPath returns the configured assets_index setting value,
-// or empty string if not set or on any error. Callers should pass the result
-// to registry.EffectiveIndexPath to get the final module path.
-func resolveAssetsIndexPath() string {
-	settings, err := loadSettingsForScope(false)
+// or empty string if not set. Callers should pass the result to
+// registry.EffectiveIndexPath to get the final module path. Settings that
+// cannot be loaded, such as an unreadable policy, are an error rather than a
+// fallback to the default index.
+func resolveAssetsIndexPath() (string, error) {
+	settings, err := loadSettingsForScope(config.ScopeMerged)
 	if err != nil {
-		return ""
+		return "", fmt.Errorf("resolving assets_index: %w", err)
 	}
-	return settings["assets_index"]
+	return settings["assets_index"], nil
 }
 
 // reloadConfig reloads the merged config after installs.
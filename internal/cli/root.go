@@ -75,6 +75,11 @@ Examples:
 			if flags.Debug {
 				flags.Verbose = true
 			}
+
+			// Personal config is local config kept in the personal overlay
+			if flags.Personal {
+				flags.Local = true
+			}
 			return nil
 		},
 	}
@@ -93,7 +98,9 @@ Examples:
 	cmd.PersistentFlags().BoolVar(&flags.Debug, "debug", false, "Debug output (implies --verbose)")
 	cmd.PersistentFlags().BoolVar(&flags.NoColor, "no-color", false, "Disable colored output")
 	cmd.PersistentFlags().BoolVarP(&flags.Local, "local", "l", false, "Target local config (./.start/) instead of global")
+	cmd.PersistentFlags().BoolVar(&flags.Personal, "personal", false, "Target personal project config (./.start/local/, gitignored)")
 	cmd.PersistentFlags().BoolVar(&flags.NoRole, "no-role", false, "Skip role assignment")
+	cmd.PersistentFlags().BoolVar(&flags.Strict, "strict", false, "Fail when config does not match the asset schemas")
 	cmd.MarkFlagsMutuallyExclusive("role", "no-role")
 
 	// Set RunE on root command for `start` execution
@@ -110,10 +117,15 @@ Examples:
 	addReadCommand(cmd)
 	addPromptCommand(cmd)
 	addTaskCommand(cmd)
+	addDiffCommand(cmd)
 	addAssetsCommand(cmd)
 	addConfigCommand(cmd)
 	addSearchCommand(cmd)
 	addDoctorCommand(cmd)
+	addLintCommand(cmd)
+	addSyncFilesCommand(cmd)
+	addCleanCommand(cmd)
+	addTrustCommand(cmd)
 	addCompletionCommand(cmd)
 
 	// Replace default help command with one that includes agent-focused topic subcommands
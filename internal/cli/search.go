@@ -114,7 +114,8 @@ func runSearch(cmd *cobra.Command, args []string) error {
 
 	// Search local config
 	if paths.LocalExists {
-		cfg, err := loader.LoadSingle(paths.Local)
+		local, err := loader.Load(paths.Locals())
+		cfg := local.Value
 		if err != nil && !errors.Is(err, internalcue.ErrNoCUEFiles) {
 			printWarning(stderr, "failed to load local config: %s", err)
 		} else if err == nil {
@@ -164,10 +165,13 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	var registryErr error
 	ctx := context.Background()
 	client, err := registry.NewClient()
+	indexPath, pathErr := resolveAssetsIndexPath()
 	if err != nil {
 		registryErr = err
+	} else if pathErr != nil {
+		registryErr = pathErr
 	} else {
-		index, indexVersion, err := client.FetchIndex(ctx, resolveAssetsIndexPath())
+		index, indexVersion, err := client.FetchIndex(ctx, indexPath)
 		if err != nil {
 			registryErr = err
 		} else {
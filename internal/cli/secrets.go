@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/grantcarthew/start/internal/config"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/orchestration"
+	"github.com/grantcarthew/start/internal/secrets"
+)
+
+// loadSecretSettings reads settings.secret_policy and settings.secret_patterns
+// and builds the scanner.
+func loadSecretSettings(cfg cue.Value) (secrets.Policy, *secrets.Scanner, error) {
+	var policyStr, patternsStr string
+	settings := cfg.LookupPath(cue.ParsePath(internalcue.KeySettings))
+	if v := settings.LookupPath(cue.ParsePath("secret_policy")); v.Exists() {
+		policyStr, _ = v.String()
+	}
+	if v := settings.LookupPath(cue.ParsePath("secret_patterns")); v.Exists() {
+		patternsStr, _ = config.SettingString(v)
+	}
+
+	policy, err := secrets.ParsePolicy(policyStr)
+	if err != nil {
+		return "", nil, err
+	}
+	scanner, err := secrets.NewScanner(secrets.ParsePatterns(patternsStr))
+	if err != nil {
+		return "", nil, fmt.Errorf("settings.secret_patterns: %w", err)
+	}
+	return policy, scanner, nil
+}
+
+// applySecretPolicy scans the composition for secrets and applies
+// settings.secret_policy: warn records the findings, redact also replaces the
+// secrets in the role and prompt, and block refuses to launch.
+func applySecretPolicy(env *ExecutionEnv, result *orchestration.ComposeResult, flags *Flags, stderr io.Writer) error {
+	policy, scanner, err := loadSecretSettings(env.Cfg.Value)
+	if err != nil {
+		return err
+	}
+
+	findings := orchestration.ScanSecrets(scanner, *result)
+	debugf(stderr, flags, dbgCompose, "Secret scan: %d finding(s), policy %s", len(findings), policy)
+	if len(findings) == 0 {
+		return nil
+	}
+	result.Secrets = orchestration.SecretReport{Policy: policy, Findings: findings}
+
+	switch policy {
+	case secrets.PolicyBlock:
+		printSecretFindings(stderr, result.Secrets)
+		return fmt.Errorf("blocked: %d secret(s) detected in %s (settings.secret_policy is block)",
+			len(findings), secretSources(findings))
+
+	case secrets.PolicyRedact:
+		roleBefore := result.Role
+		orchestration.RedactSecrets(scanner, result)
+		// Agents given {{.role_file}} read the role from disk, so point them
+		// at a redacted copy in the session directory.
+		if result.RoleFile != "" && result.Role != roleBefore {
+			name := result.RoleName
+			if name == "" {
+				name = "redacted"
+			}
+			path, err := env.TempManager.WriteUTDFile("role", name, result.Role)
+			if err != nil {
+				return fmt.Errorf("writing redacted role file: %w", err)
+			}
+			result.RoleFile = path
+		}
+	}
+
+	return nil
+}
+
+// secretSources lists the distinct sources of findings, in order.
+func secretSources(findings []secrets.Finding) string {
+	sources := make([]string, 0, len(findings))
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		if !seen[f.Source] {
+			seen[f.Source] = true
+			sources = append(sources, f.Source)
+		}
+	}
+	return strings.Join(sources, ", ")
+}
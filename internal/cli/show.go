@@ -28,6 +28,10 @@ type ShowResult struct {
 	Value      cue.Value // The CUE value for this item
 	AllNames   []string  // All available items of this type
 	ShowReason string    // Why this item is shown (e.g., "first in config", "default")
+
+	// Definitions lists where the item is defined, lowest layer first.
+	// The last entry is the effective definition.
+	Definitions []internalcue.Definition
 }
 
 // showCategory maps category metadata used for cross-category operations.
@@ -82,7 +86,7 @@ To inspect strictly within --local, ensure the asset is already installed.`,
 	}
 
 	// Add --global flag to show command (show-specific scope restriction)
-	showCmd.PersistentFlags().Bool("global", false, "Show from global scope only")
+	showCmd.PersistentFlags().Bool("global", false, "Show from global config and shared layers only")
 
 	// Add show to parent
 	parent.AddCommand(showCmd)
@@ -148,7 +152,7 @@ func runShowListing(cmd *cobra.Command) error {
 	_, _ = fmt.Fprintln(w)
 
 	flags := getFlags(cmd)
-	entries, err := config.ResolveAllSettings(paths, flags.Local)
+	entries, err := config.ResolveAllSettings(paths, flags.Scope())
 	if err != nil {
 		return err
 	}
@@ -163,33 +167,37 @@ func runShowListing(cmd *cobra.Command) error {
 		return err
 	}
 
-	for _, cat := range showCategories {
-		items := cfg.Value.LookupPath(cue.ParsePath(cat.key))
-		if !items.Exists() {
-			continue
-		}
+	disabled := config.DisabledItems(paths, scope)
 
+	for _, cat := range showCategories {
 		type entry struct {
-			name string
-			desc string
+			name     string
+			desc     string
+			disabled string // Layer that disabled the item
 		}
 
 		var catEntries []entry
 		maxNameLen := 0
 
-		iter, err := items.Fields()
-		if err != nil {
-			continue
+		items := cfg.Value.LookupPath(cue.ParsePath(cat.key))
+		if iter, err := items.Fields(); err == nil {
+			for iter.Next() {
+				name := iter.Selector().Unquoted()
+				desc := ""
+				if d := iter.Value().LookupPath(cue.ParsePath("description")); d.Exists() {
+					desc, _ = d.String()
+				}
+				catEntries = append(catEntries, entry{name: name, desc: desc})
+			}
 		}
-		for iter.Next() {
-			name := iter.Selector().Unquoted()
-			desc := ""
-			if d := iter.Value().LookupPath(cue.ParsePath("description")); d.Exists() {
-				desc, _ = d.String()
+		for _, d := range disabled {
+			if d.Key == cat.key {
+				catEntries = append(catEntries, entry{name: d.Name, disabled: d.Source})
 			}
-			catEntries = append(catEntries, entry{name, desc})
-			if len(name) > maxNameLen {
-				maxNameLen = len(name)
+		}
+		for _, e := range catEntries {
+			if len(e.name) > maxNameLen {
+				maxNameLen = len(e.name)
 			}
 		}
 
@@ -201,6 +209,11 @@ func runShowListing(cmd *cobra.Command) error {
 		_, _ = fmt.Fprintln(w, "/")
 
 		for _, e := range catEntries {
+			if e.disabled != "" {
+				_, _ = fmt.Fprintf(w, "  %s ", e.name)
+				_, _ = fmt.Fprintln(w, tui.Annotate("disabled by %s", e.disabled))
+				continue
+			}
 			if e.desc != "" {
 				padding := strings.Repeat(" ", maxNameLen-len(e.name)+2)
 				_, _ = fmt.Fprintf(w, "  %s%s", e.name, padding)
@@ -249,6 +262,17 @@ func runShowSearch(cmd *cobra.Command, name string) error {
 		return err
 	}
 
+	// A disabled item would otherwise look missing, or be fetched from the
+	// registry again
+	if paths, err := config.ResolvePaths(""); err == nil && !hasExactItem(cfg.Value, name) {
+		for _, d := range config.DisabledItems(paths, scope) {
+			if d.Name == name {
+				_, _ = fmt.Fprintf(w, "%s/%s is disabled by %s config %s\n", d.Key, d.Name, d.Source, tui.Annotate("%s", shortenHome(d.Dir)))
+				return nil
+			}
+		}
+	}
+
 	r := newResolver(cfg, flags, w, stderr, stdin)
 	match, err := resolveCrossCategory(name, r)
 	if err != nil {
@@ -275,6 +299,16 @@ func runShowSearch(cmd *cobra.Command, name string) error {
 	return showVerboseItem(w, match.Name, effectiveScope, cat.key, cat.itemType)
 }
 
+// hasExactItem reports whether any category defines an item named name.
+func hasExactItem(cfg cue.Value, name string) bool {
+	for _, cat := range showCategories {
+		if cfg.LookupPath(cue.MakePath(cue.Str(cat.key), cue.Str(name))).Exists() {
+			return true
+		}
+	}
+	return false
+}
+
 // showVerboseItem prepares and displays a verbose dump for a single item.
 func showVerboseItem(w io.Writer, name string, scope config.Scope, cueKey, itemType string) error {
 	result, err := prepareShow(name, scope, cueKey, itemType)
@@ -351,6 +385,8 @@ func prepareShow(name string, scope config.Scope, cueKey, itemType string) (Show
 		Value:      item,
 		AllNames:   allNames,
 		ShowReason: showReason,
+
+		Definitions: cfg.Definitions(cueKey, resolvedName),
 	}, nil
 }
 
@@ -385,19 +421,22 @@ func showScopeFromCmd(cmd *cobra.Command) (config.Scope, error) {
 	if f := cmd.Flags().Lookup("global"); f != nil {
 		global, _ = cmd.Flags().GetBool("global")
 	}
-	local := getFlags(cmd).Local
-	if local && global {
+	scope := getFlags(cmd).Scope()
+	if scope != config.ScopeMerged && global {
 		return config.ScopeMerged, fmt.Errorf("--local and --global are mutually exclusive")
 	}
 	if global {
 		return config.ScopeGlobal, nil
 	}
-	if local {
-		return config.ScopeLocal, nil
-	}
-	return config.ScopeMerged, nil
+	return scope, nil
 }
 
+// noConfigError is returned by loadConfig when the scope has no config
+// files yet, as opposed to config that fails to load.
+type noConfigError struct{ msg string }
+
+func (e *noConfigError) Error() string { return e.msg }
+
 // loadConfig loads CUE configuration for the given scope.
 func loadConfig(scope config.Scope) (internalcue.LoadResult, error) {
 	paths, err := config.ResolvePaths("")
@@ -410,11 +449,11 @@ func loadConfig(scope config.Scope) (internalcue.LoadResult, error) {
 	if len(dirs) == 0 {
 		switch scope {
 		case config.ScopeGlobal:
-			return internalcue.LoadResult{}, fmt.Errorf("no global configuration found at %s", paths.Global)
-		case config.ScopeLocal:
-			return internalcue.LoadResult{}, fmt.Errorf("no local configuration found at %s", paths.Local)
+			return internalcue.LoadResult{}, &noConfigError{fmt.Sprintf("no global configuration found at %s", paths.Global)}
+		case config.ScopeLocal, config.ScopePersonal:
+			return internalcue.LoadResult{}, &noConfigError{fmt.Sprintf("no %s configuration found at %s", scopeString(scope), paths.Dir(scope))}
 		default:
-			return internalcue.LoadResult{}, fmt.Errorf("no configuration found (checked %s and %s)", paths.Global, paths.Local)
+			return internalcue.LoadResult{}, &noConfigError{fmt.Sprintf("no configuration found (checked %s and %s)", paths.Global, paths.Local)}
 		}
 	}
 
@@ -423,13 +462,21 @@ func loadConfig(scope config.Scope) (internalcue.LoadResult, error) {
 	if err != nil && errors.Is(err, internalcue.ErrNoCUEFiles) {
 		switch scope {
 		case config.ScopeGlobal:
-			return result, fmt.Errorf("no global configuration found at %s (directory exists but contains no .cue files)", paths.Global)
-		case config.ScopeLocal:
-			return result, fmt.Errorf("no local configuration found at %s (directory exists but contains no .cue files)", paths.Local)
+			return result, &noConfigError{fmt.Sprintf("no global configuration found at %s (directory exists but contains no .cue files)", paths.Global)}
+		case config.ScopeLocal, config.ScopePersonal:
+			return result, &noConfigError{fmt.Sprintf("no %s configuration found at %s (directory exists but contains no .cue files)", scopeString(scope), paths.Dir(scope))}
 		default:
-			return result, fmt.Errorf("no configuration found (checked %s and %s; directories exist but contain no .cue files)", paths.Global, paths.Local)
+			return result, &noConfigError{fmt.Sprintf("no configuration found (checked %s and %s; directories exist but contain no .cue files)", paths.Global, paths.Local)}
 		}
 	}
+	if err != nil || scope != config.ScopeMerged {
+		return result, err
+	}
+	result.Value, err = config.ApplyEnvSettings(loader, result.Value)
+	if err != nil {
+		return result, err
+	}
+	result.Value, err = config.ApplyPolicy(loader, result.Value, paths)
 	return result, err
 }
 
@@ -448,9 +495,20 @@ func printVerboseDump(w io.Writer, r ShowResult) {
 	_, _ = fmt.Fprintln(w)
 	printSeparator(w)
 
-	// Config source
-	configSource := findConfigSource(r.CueKey, r.Name)
-	if configSource != "" {
+	// Config source, with any lower-layer definitions it overrides
+	if n := len(r.Definitions); n > 0 {
+		sources := layerSources()
+		def := r.Definitions[n-1]
+		_, _ = fmt.Fprintf(w, "%s %s %s\n",
+			label("Config:"), def.Position(),
+			tui.Annotate("%s", sources[def.Dir]))
+		for i := n - 2; i >= 0; i-- {
+			def = r.Definitions[i]
+			_, _ = fmt.Fprintf(w, "%s %s %s\n",
+				label("Overrides:"), def.Position(),
+				tui.Annotate("%s", sources[def.Dir]))
+		}
+	} else if configSource := findConfigSource(r.CueKey, r.Name); configSource != "" {
 		_, _ = fmt.Fprintf(w, "%s %s %s\n",
 			label("Config:"), configSource,
 			tui.Annotate("%s", r.Name))
@@ -519,21 +577,10 @@ func findConfigSource(cueKey, name string) string {
 
 	loader := internalcue.NewLoader()
 
-	// Check local first (higher priority - overrides global)
-	if paths.LocalExists {
-		if v, err := loader.LoadSingle(paths.Local); err == nil {
-			item := v.LookupPath(cue.ParsePath(cueKey)).LookupPath(cue.MakePath(cue.Str(name)))
-			if item.Exists() {
-				if pos := item.Pos(); pos.IsValid() {
-					return pos.Filename()
-				}
-			}
-		}
-	}
-
-	// Check global
-	if paths.GlobalExists {
-		if v, err := loader.LoadSingle(paths.Global); err == nil {
+	// Check innermost first (highest priority), ending with global
+	dirs := paths.ForScope(config.ScopeMerged)
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if v, err := loader.LoadSingle(dirs[i]); err == nil {
 			item := v.LookupPath(cue.ParsePath(cueKey)).LookupPath(cue.MakePath(cue.Str(name)))
 			if item.Exists() {
 				if pos := item.Pos(); pos.IsValid() {
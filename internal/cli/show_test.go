@@ -1214,3 +1214,43 @@ func TestNotifyScopeWidenedIfLocal(t *testing.T) {
 		})
 	}
 }
+
+func TestShowDisabledItems(t *testing.T) {
+	dir := setupTestConfig(t)
+	globalDir := filepath.Join(dir, "config", "start")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Dir(globalDir))
+	if err := os.MkdirAll(globalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(globalDir, "agents.cue"),
+		[]byte(`agents: gemini: {bin: "gemini", command: "gemini", description: "Gemini"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".start", "disabled.cue"),
+		[]byte(`agents: gemini: enabled: false`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		cmd := NewRootCmd()
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		return buf.String()
+	}
+
+	if output := run("show"); !strings.Contains(output, "gemini (disabled by local)") {
+		t.Errorf("show listing missing disabled gemini:\n%s", output)
+	}
+	if output := run("show", "gemini"); !strings.Contains(output, "agents/gemini is disabled by local config") {
+		t.Errorf("show gemini output:\n%s", output)
+	}
+	if output := run("config", "list", "agent"); !strings.Contains(output, "disabled by local") {
+		t.Errorf("config list missing disabled gemini:\n%s", output)
+	}
+}
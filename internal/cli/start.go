@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,7 +16,6 @@ import (
 	"github.com/grantcarthew/start/internal/config"
 	internalcue "github.com/grantcarthew/start/internal/cue"
 	"github.com/grantcarthew/start/internal/orchestration"
-	"github.com/grantcarthew/start/internal/shell"
 	"github.com/grantcarthew/start/internal/temp"
 	"github.com/grantcarthew/start/internal/tui"
 	"github.com/spf13/cobra"
@@ -27,17 +27,19 @@ type flagsKey struct{}
 // Flags holds all CLI flag values. Each command instance gets its own Flags,
 // enabling parallel test execution without shared state.
 type Flags struct {
-	Agent   string
-	Role    string
-	Model   string
-	Context []string
-	DryRun  bool
-	Quiet   bool
-	Verbose bool
-	Debug   bool
-	NoColor bool
-	Local   bool
-	NoRole  bool
+	Agent    string
+	Role     string
+	Model    string
+	Context  []string
+	DryRun   bool
+	Quiet    bool
+	Verbose  bool
+	Debug    bool
+	NoColor  bool
+	Local    bool
+	Personal bool
+	NoRole   bool
+	Strict   bool
 }
 
 // getFlags retrieves Flags from the command context.
@@ -49,6 +51,18 @@ func getFlags(cmd *cobra.Command) *Flags {
 	return &Flags{}
 }
 
+// Scope returns the config scope selected by --local and --personal. Without
+// either, commands read merged config and write global config.
+func (f *Flags) Scope() config.Scope {
+	switch {
+	case f.Personal:
+		return config.ScopePersonal
+	case f.Local:
+		return config.ScopeLocal
+	}
+	return config.ScopeMerged
+}
+
 // Debug log categories.
 const (
 	dbgConfig  = "config"
@@ -60,6 +74,7 @@ const (
 	dbgExec    = "exec"
 	dbgResolve = "resolve"
 	dbgCache   = "cache"
+	dbgTemp    = "temp"
 )
 
 // debugf prints debug output if debug mode is enabled.
@@ -78,6 +93,19 @@ type ExecutionEnv struct {
 	Agent      orchestration.Agent
 	Composer   *orchestration.Composer
 	Executor   *orchestration.Executor
+	// TempManager holds this session's UTD temp files.
+	TempManager *temp.Manager
+	// AgentUntrusted is set when the agent command comes from untrusted
+	// local config. Dry runs may render it; launching is refused.
+	AgentUntrusted bool
+}
+
+// checkAgentTrusted returns an error if the agent must not be launched.
+func (env *ExecutionEnv) checkAgentTrusted() error {
+	if env.AgentUntrusted {
+		return fmt.Errorf("agent %q is defined by untrusted local config; review ./.start and run 'start trust'", env.Agent.Name)
+	}
+	return nil
 }
 
 // loadExecutionConfig loads configuration and resolves the working directory.
@@ -144,7 +172,7 @@ func resolveAgentName(cfg internalcue.LoadResult, flags *Flags, stdout, stderr i
 	}
 	debugf(stderr, flags, dbgAgent, "Selected %q (interactive)", selected)
 	if promptSetDefault(stdout, reader, selected) {
-		if err := setSetting(stdout, flags, "default_agent", selected, false); err != nil {
+		if err := setSetting(stdout, flags, "default_agent", selected, config.ScopeMerged); err != nil {
 			printWarning(stdout, "could not save default: %v", err)
 		}
 	}
@@ -171,20 +199,70 @@ func buildExecutionEnv(cfg internalcue.LoadResult, workingDir string, agentName
 	debugf(stderr, flags, dbgAgent, "Binary: %s", agent.Bin)
 	debugf(stderr, flags, dbgAgent, "Command template: %s", agent.Command)
 
-	shellRunner := shell.NewRunner()
-	processor := orchestration.NewTemplateProcessor(nil, shellRunner, workingDir)
-	composer := orchestration.NewComposer(processor, workingDir)
+	// Commands from untrusted local config must not run
+	blocked, err := checkLocalTrust(workingDir, flags, stderr, stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	processor := orchestration.NewTemplateProcessor(nil, newShellRunner(blocked), workingDir)
+	tempManager := sessionTempManager(cfg, flags, stderr)
+	composer := orchestration.NewComposer(processor, tempManager, workingDir)
 	executor := orchestration.NewExecutor(workingDir)
+	paths, err := config.ResolvePaths(workingDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving config paths: %w", err)
+	}
+	policy, err := config.LoadPolicy(paths)
+	if err != nil {
+		return nil, err
+	}
+	executor.SetPolicy(policy)
 
 	return &ExecutionEnv{
-		Cfg:        cfg,
-		WorkingDir: workingDir,
-		Agent:      agent,
-		Composer:   composer,
-		Executor:   executor,
+		Cfg:            cfg,
+		WorkingDir:     workingDir,
+		Agent:          agent,
+		Composer:       composer,
+		Executor:       executor,
+		TempManager:    tempManager,
+		AgentUntrusted: blocked.agentBlocked(agent.Name),
 	}, nil
 }
 
+// sessionTempManager creates the UTD temp manager for this session and
+// garbage collects session directories older than settings.session_max_age,
+// or temp.DefaultSessionMaxAge when unset. The base directory comes from
+// settings.temp_dir when set.
+func sessionTempManager(cfg internalcue.LoadResult, flags *Flags, stderr io.Writer) *temp.Manager {
+	var override string
+	if v := cfg.Value.LookupPath(cue.ParsePath(internalcue.KeySettings + ".temp_dir")); v.Exists() {
+		override, _ = v.String()
+	}
+	baseDir := temp.SessionBaseDir(override)
+
+	maxAge := temp.DefaultSessionMaxAge
+	if v := cfg.Value.LookupPath(cue.ParsePath(internalcue.KeySettings + ".session_max_age")); v.Exists() {
+		s, _ := v.String()
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			maxAge = d
+		} else if !flags.Quiet {
+			printWarning(stderr, "invalid settings.session_max_age %q, using %s", s, maxAge)
+		}
+	}
+
+	removed, err := temp.CollectGarbage(baseDir, maxAge)
+	if err != nil {
+		debugf(stderr, flags, dbgTemp, "garbage collection failed: %v", err)
+	} else if removed > 0 {
+		debugf(stderr, flags, dbgTemp, "Removed %d stale session(s) from %s", removed, baseDir)
+	}
+
+	m := temp.NewSessionManager(baseDir)
+	debugf(stderr, flags, dbgTemp, "Session directory: %s", m.BaseDir)
+	return m
+}
+
 // agentChoice represents an agent available for interactive selection.
 type agentChoice struct {
 	Name        string
@@ -301,12 +379,50 @@ func runStart(cmd *cobra.Command, args []string) error {
 	}, "")
 }
 
+// launchPlan holds a composed and validated agent launch, ready to execute
+// or preview.
+type launchPlan struct {
+	env         *ExecutionEnv
+	result      orchestration.ComposeResult
+	execConfig  orchestration.ExecuteConfig
+	cmdStr      string
+	model       string
+	modelSource string
+}
+
 // executeStart is the shared execution logic for start commands.
 func executeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selection orchestration.ContextSelection, customText string) error {
+	plan, err := composeStart(stdout, stderr, stdin, flags, selection, customText)
+	if err != nil {
+		return err
+	}
+
+	if flags.DryRun {
+		debugf(stderr, flags, dbgExec, "Dry-run mode, skipping execution")
+		return executeDryRun(stdout, plan.cmdStr, plan.execConfig, plan.result, plan.env.Agent, plan.model, plan.modelSource)
+	}
+
+	if err := plan.env.checkAgentTrusted(); err != nil {
+		return err
+	}
+
+	// Print execution info
+	if !flags.Quiet {
+		printExecutionInfo(stdout, plan.env.Agent, plan.model, plan.modelSource, plan.result)
+	}
+
+	debugf(stderr, flags, dbgExec, "Executing agent (process replacement)")
+	// Execute agent (replaces current process) - command already validated
+	return plan.env.Executor.ExecuteCommand(plan.cmdStr, plan.execConfig)
+}
+
+// composeStart loads config, resolves flags, composes the prompt, and builds
+// the agent command without executing it.
+func composeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selection orchestration.ContextSelection, customText string) (*launchPlan, error) {
 	// Phase 1: Load config
 	cfg, workingDir, err := loadExecutionConfig(stdout, stderr, stdin, flags)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Phase 2: Resolve asset flags
@@ -317,7 +433,7 @@ func executeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selec
 	if agentName != "" {
 		agentName, err = r.resolveAgent(agentName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -326,7 +442,7 @@ func executeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selec
 	if roleName != "" && !flags.NoRole {
 		roleName, err = r.resolveRole(roleName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -334,7 +450,7 @@ func executeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selec
 	if len(selection.Tags) > 0 {
 		selection.Tags, err = r.resolveContexts(selection.Tags)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -342,7 +458,7 @@ func executeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selec
 	if r.didInstall {
 		debugf(stderr, flags, dbgConfig, "Reloading config after registry installs")
 		if err := r.reloadConfig(workingDir); err != nil {
-			return err
+			return nil, err
 		}
 		cfg = r.cfg
 	}
@@ -350,7 +466,7 @@ func executeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selec
 	// Phase 3: Build execution environment with resolved agent
 	env, err := buildExecutionEnv(cfg, workingDir, agentName, flags, stdout, stderr, stdin)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Resolve --model flag against agent's models map
@@ -376,7 +492,7 @@ func executeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selec
 		if !flags.Quiet && len(result.RoleResolutions) > 0 {
 			printComposeError(stdout, env.Agent, result)
 		}
-		return fmt.Errorf("composing prompt: %w", composeErr)
+		return nil, fmt.Errorf("composing prompt: %w", composeErr)
 	}
 
 	debugf(stderr, flags, dbgRole, "Selected %q", result.RoleName)
@@ -389,6 +505,11 @@ func executeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selec
 	// Print warnings
 	printWarnings(flags, stderr, result.Warnings)
 
+	// Scan for secrets before anything is handed to the agent
+	if err := applySecretPolicy(env, &result, flags, stderr); err != nil {
+		return nil, err
+	}
+
 	// Determine effective model and its source
 	model, modelSource := resolveModel(resolvedModel, env.Agent.DefaultModel)
 	if model != "" {
@@ -411,23 +532,18 @@ func executeStart(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, selec
 	// Build command and validate before proceeding
 	cmdStr, err := env.Executor.BuildCommand(execConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	debugf(stderr, flags, dbgExec, "Final command: %s", cmdStr)
 
-	if flags.DryRun {
-		debugf(stderr, flags, dbgExec, "Dry-run mode, skipping execution")
-		return executeDryRun(stdout, cmdStr, execConfig, result, env.Agent, model, modelSource)
-	}
-
-	// Print execution info
-	if !flags.Quiet {
-		printExecutionInfo(stdout, env.Agent, model, modelSource, result)
-	}
-
-	debugf(stderr, flags, dbgExec, "Executing agent (process replacement)")
-	// Execute agent (replaces current process) - command already validated
-	return env.Executor.ExecuteCommand(cmdStr, execConfig)
+	return &launchPlan{
+		env:         env,
+		result:      result,
+		execConfig:  execConfig,
+		cmdStr:      cmdStr,
+		model:       model,
+		modelSource: modelSource,
+	}, nil
 }
 
 // resolveModel determines the effective model and its source.
@@ -473,8 +589,8 @@ func executeDryRun(w io.Writer, cmdStr string, cfg orchestration.ExecuteConfig,
 	cmdContent := orchestration.GenerateDryRunCommand(agent, cfg.Model, result.RoleName, contextNames, cfg.WorkingDir, cmdStr)
 
 	// Write files
-	if err := tempMgr.WriteDryRunFiles(dir, result.Role, result.Prompt, cmdContent); err != nil {
-		return fmt.Errorf("writing dry-run files: %w", err)
+	if err := writeDryRunFiles(tempMgr, dir, result, cmdContent); err != nil {
+		return err
 	}
 
 	// Print summary
@@ -483,6 +599,22 @@ func executeDryRun(w io.Writer, cmdStr string, cfg orchestration.ExecuteConfig,
 	return nil
 }
 
+// writeDryRunFiles writes the role, prompt, command, and context manifest
+// into a dry-run directory.
+func writeDryRunFiles(tempMgr *temp.Manager, dir string, result orchestration.ComposeResult, cmdContent string) error {
+	if err := tempMgr.WriteDryRunFiles(dir, result.Role, result.Prompt, cmdContent); err != nil {
+		return fmt.Errorf("writing dry-run files: %w", err)
+	}
+	contexts, err := orchestration.GenerateDryRunContexts(result.Contexts)
+	if err != nil {
+		return fmt.Errorf("generating context manifest: %w", err)
+	}
+	if err := tempMgr.WriteDryRunFile(dir, orchestration.DryRunContextsFile, contexts); err != nil {
+		return fmt.Errorf("writing dry-run files: %w", err)
+	}
+	return nil
+}
+
 // printExecutionInfo prints the execution summary.
 func printExecutionInfo(w io.Writer, agent orchestration.Agent, model, modelSource string, result orchestration.ComposeResult) {
 	printHeader(w, "Starting AI Agent")
@@ -491,6 +623,7 @@ func printExecutionInfo(w io.Writer, agent orchestration.Agent, model, modelSour
 	printAgentModel(w, agent, model, modelSource)
 	printContextTable(w, result.Contexts, result.Selection)
 	printRoleTable(w, result.RoleResolutions)
+	printSecretFindings(w, result.Secrets)
 
 	_, _ = fmt.Fprintf(w, "Starting %s - awaiting response...\n", agent.Name)
 }
@@ -503,6 +636,7 @@ func printDryRunSummary(w io.Writer, agent orchestration.Agent, model, modelSour
 	printAgentModel(w, agent, model, modelSource)
 	printContextTable(w, result.Contexts, result.Selection)
 	printRoleTable(w, result.RoleResolutions)
+	printSecretFindings(w, result.Secrets)
 
 	// Show role preview
 	if result.Role != "" {
@@ -521,6 +655,7 @@ func printDryRunSummary(w io.Writer, agent orchestration.Agent, model, modelSour
 	_, _ = fmt.Fprintln(w, "  role.md")
 	_, _ = fmt.Fprintln(w, "  prompt.md")
 	_, _ = fmt.Fprintln(w, "  command.txt")
+	_, _ = fmt.Fprintln(w, "  contexts.json")
 }
 
 // printComposeError prints UI before a composition error.
@@ -579,8 +714,14 @@ func loadMergedConfigFromDirWithDebug(stdout, stderr io.Writer, stdin io.Reader,
 		return internalcue.LoadResult{}, fmt.Errorf("resolving config paths: %w", err)
 	}
 
+	for _, layer := range paths.Shared() {
+		debugf(stderr, flags, dbgConfig, "Shared (%s): %s", layer.Source, layer.Dir)
+	}
 	debugf(stderr, flags, dbgConfig, "Global: %s (exists: %t)", paths.Global, paths.GlobalExists)
 	debugf(stderr, flags, dbgConfig, "Local: %s (exists: %t)", paths.Local, paths.LocalExists)
+	if layers := paths.Locals(); len(layers) > 1 {
+		debugf(stderr, flags, dbgConfig, "Local layers: %s", strings.Join(layers, ", "))
+	}
 
 	// Load using the standard function
 	result, err := loadMergedConfigWithIO(stdout, stderr, stdin, workingDir)
@@ -588,13 +729,20 @@ func loadMergedConfigFromDirWithDebug(stdout, stderr io.Writer, stdin io.Reader,
 		return result, err
 	}
 
+	if err := checkPolicyViolations(stderr, paths, flags); err != nil {
+		return result, err
+	}
+
+	if err := checkConfigSchemas(stderr, result.Value, flags); err != nil {
+		return result, err
+	}
+
 	// Log what was loaded
 	var loaded []string
-	if result.GlobalLoaded {
-		loaded = append(loaded, "global")
-	}
-	if result.LocalLoaded {
-		loaded = append(loaded, "local")
+	for _, layer := range paths.Layers(config.ScopeMerged) {
+		if hasCUE, _ := internalcue.HasCUEFiles(layer.Dir); hasCUE && !slices.Contains(loaded, layer.Source) {
+			loaded = append(loaded, layer.Source)
+		}
 	}
 	if len(loaded) > 0 {
 		debugf(stderr, flags, dbgConfig, "Loaded from: %s", strings.Join(loaded, ", "))
@@ -603,6 +751,63 @@ func loadMergedConfigFromDirWithDebug(stdout, stderr io.Writer, stdin io.Reader,
 	return result, nil
 }
 
+// checkPolicyViolations warns about config layers and environment variables
+// that set a setting the policy locks. The locked value is already in effect.
+func checkPolicyViolations(stderr io.Writer, paths config.Paths, flags *Flags) error {
+	policy, err := config.LoadPolicy(paths)
+	if err != nil {
+		return err
+	}
+	for _, dir := range policy.Dirs {
+		debugf(stderr, flags, dbgConfig, "Policy: %s", dir)
+	}
+	violations, err := config.PolicyViolations(paths, policy)
+	if err != nil || flags.Quiet {
+		return err
+	}
+	for _, v := range violations {
+		printWarning(stderr, "%s", v)
+	}
+	return nil
+}
+
+// checkConfigSchemas validates the merged config against the cached asset
+// schemas. Unknown fields and schema errors are printed as warnings, or
+// returned as an error in strict mode (--strict or settings.strict). The
+// check is skipped when the schemas are not cached; it never fetches them.
+func checkConfigSchemas(stderr io.Writer, v cue.Value, flags *Flags) error {
+	schemas, err := cachedSchemas()
+	if err != nil {
+		debugf(stderr, flags, dbgConfig, "Schema check skipped: %v", err)
+		return nil
+	}
+
+	var problems []string
+	for _, issue := range schemas.Validate(v) {
+		problems = append(problems, issue.Error())
+	}
+	for _, field := range schemas.UnknownFields(v) {
+		problems = append(problems, field.Error())
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	strict := flags.Strict
+	if s, err := v.LookupPath(cue.ParsePath(internalcue.KeySettings + ".strict")).Bool(); err == nil && s {
+		strict = true
+	}
+	if strict {
+		return fmt.Errorf("config does not match the asset schemas:\n  %s", strings.Join(problems, "\n  "))
+	}
+	if !flags.Quiet {
+		for _, p := range problems {
+			printWarning(stderr, "%s", p)
+		}
+	}
+	return nil
+}
+
 // loadMergedConfigWithIO loads configuration with custom I/O streams.
 func loadMergedConfigWithIO(stdout, stderr io.Writer, stdin io.Reader, workingDir string) (internalcue.LoadResult, error) {
 	paths, err := config.ResolvePaths(workingDir)
@@ -643,7 +848,16 @@ func loadMergedConfigWithIO(stdout, stderr io.Writer, stdin io.Reader, workingDi
 
 	dirs := paths.ForScope(config.ScopeMerged)
 	loader := internalcue.NewLoader()
-	return loader.Load(dirs)
+	result, err := loader.Load(dirs)
+	if err != nil {
+		return result, err
+	}
+	result.Value, err = config.ApplyEnvSettings(loader, result.Value)
+	if err != nil {
+		return result, err
+	}
+	result.Value, err = config.ApplyPolicy(loader, result.Value, paths)
+	return result, err
 }
 
 // runAutoSetup runs the auto-setup flow.
@@ -38,6 +38,9 @@ func setupStartTestConfig(t *testing.T) string {
 	// Isolate from global config
 	t.Setenv("HOME", tmpDir)
 	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	// Keep session temp directories and the trust store inside the test directory
+	t.Setenv("XDG_RUNTIME_DIR", filepath.Join(tmpDir, "run"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
 
 	// CUE module cache writes read-only files; make them writable before cleanup.
 	t.Cleanup(func() {
@@ -660,7 +663,7 @@ settings: {
 	}
 }
 
-func TestTaskResolution_ExactMatchFallsThrough(t *testing.T) {
+func TestTaskResolution_ExactMatchWins(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// Isolate from global config
@@ -685,9 +688,8 @@ func TestTaskResolution_ExactMatchFallsThrough(t *testing.T) {
 	}
 
 	// Config where "review" is both an exact task name AND a substring
-	// matching "start/review". The multipleInstalled path should cause
-	// executeTask to fall through to selection instead of silently
-	// running the exact match.
+	// matching "start/review". The exact name is unambiguous, so executeTask
+	// runs it without falling through to selection.
 	config := `
 agents: {
 	echo: {
@@ -739,17 +741,16 @@ settings: {
 		t.Fatalf("expected 2 matches, got %d: %v", len(matches), matches)
 	}
 
-	// executeTask should NOT silently use the exact match.
-	// With non-TTY stdin, multiple matches produce an ambiguous error.
+	// executeTask uses the exact match even though the search term also
+	// matches another task. Dry run keeps the agent from executing.
 	stdout := new(bytes.Buffer)
 	stderr := new(bytes.Buffer)
-	flags := &Flags{Quiet: true}
-	err = executeTask(stdout, stderr, strings.NewReader(""), flags, "review", "", nil)
-	if err == nil {
-		t.Fatal("expected ambiguous task error, got nil")
+	flags := &Flags{DryRun: true}
+	if err := executeTask(stdout, stderr, strings.NewReader(""), flags, "review", "", nil); err != nil {
+		t.Fatalf("executeTask error: %v", err)
 	}
-	if !strings.Contains(err.Error(), "ambiguous") {
-		t.Errorf("expected error containing %q, got: %v", "ambiguous", err)
+	if !strings.Contains(stdout.String(), "Dry Run - Task: review") {
+		t.Errorf("expected exact match task %q, got:\n%s", "review", stdout.String())
 	}
 }
 
@@ -1584,11 +1585,10 @@ func TestRegistryAwareGuard_NilIndexNoEffect(t *testing.T) {
 	}
 }
 
-// TestTaskResolution_RegistryGuardAmbiguous tests the full executeTask flow
-// where a single installed exact match exists but registry tasks also match,
-// producing an ambiguous error in non-TTY mode. This requires a working
-// registry index (via cache or network).
-func TestTaskResolution_RegistryGuardAmbiguous(t *testing.T) {
+// TestTaskResolution_ExactMatchSkipsRegistry tests the full executeTask flow
+// where an installed exact match exists alongside other matches. The exact
+// match runs without a registry lookup.
+func TestTaskResolution_ExactMatchSkipsRegistry(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	t.Setenv("HOME", tmpDir)
@@ -1661,16 +1661,16 @@ settings: {
 		t.Fatalf("expected >= 2 installed matches, got %d", len(matches))
 	}
 
-	// executeTask should fall through to selection list (ambiguous in non-TTY).
+	// executeTask uses the exact match without consulting the registry.
+	// Dry run keeps the agent from executing.
 	stdout := new(bytes.Buffer)
 	stderr := new(bytes.Buffer)
-	flags := &Flags{Quiet: true}
-	err = executeTask(stdout, stderr, strings.NewReader(""), flags, "start", "", nil)
-	if err == nil {
-		t.Fatal("expected ambiguous task error, got nil")
+	flags := &Flags{DryRun: true}
+	if err := executeTask(stdout, stderr, strings.NewReader(""), flags, "start", "", nil); err != nil {
+		t.Fatalf("executeTask error: %v", err)
 	}
-	if !strings.Contains(err.Error(), "ambiguous") {
-		t.Errorf("expected error containing 'ambiguous', got: %v", err)
+	if !strings.Contains(stdout.String(), "Dry Run - Task: start") {
+		t.Errorf("expected exact match task 'start', got:\n%s", stdout.String())
 	}
 }
 
@@ -2178,6 +2178,85 @@ func TestBuildExecutionEnv_MultipleAgents_NonTTY(t *testing.T) {
 	}
 }
 
+// writeCachedSchemas puts a minimal asset schema module in the CUE module
+// cache under dir.
+func writeCachedSchemas(t *testing.T, dir string) {
+	t.Helper()
+	t.Setenv("CUE_CACHE_DIR", dir)
+	modDir := filepath.Join(dir, "mod", "extract", "github.com", "grantcarthew", "start-assets", "schemas@v1.0.0")
+	if err := os.MkdirAll(filepath.Join(modDir, "cue.mod"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"cue.mod/module.cue": `module: "github.com/grantcarthew/start-assets/schemas@v1"
+language: version: "v0.9.0"
+`,
+		"schemas.cue": `package schemas
+
+#Agent: {bin?: string, command: string & !="", default_model?: string, models?: [string]: string}
+#Role: {prompt?: string, file?: string}
+#Context: {prompt?: string, file?: string, required?: bool, default?: bool}
+#Task: {prompt?: string, role?: string, agent?: string}
+#Settings: {timeout?: int & >0}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(modDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadMergedConfig_SchemaCheck(t *testing.T) {
+	tmpDir := setupStartTestConfig(t)
+	chdir(t, tmpDir)
+	writeCachedSchemas(t, filepath.Join(tmpDir, "cue-cache"))
+	t.Setenv("CUE_REGISTRY", "::invalid")
+
+	load := func(flags *Flags) (string, error) {
+		var stderr bytes.Buffer
+		_, err := loadMergedConfigFromDirWithDebug(io.Discard, &stderr, strings.NewReader(""), tmpDir, flags)
+		return stderr.String(), err
+	}
+
+	// Valid config: no warnings
+	if stderr, err := load(&Flags{}); err != nil || stderr != "" {
+		t.Fatalf("valid config: err = %v, stderr = %q", err, stderr)
+	}
+
+	typo := "\ncontexts: env: requried: true\n"
+	f, err := os.OpenFile(filepath.Join(tmpDir, ".start", "settings.cue"), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(typo); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	// Default: warn with a suggestion and continue
+	stderr, err := load(&Flags{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stderr, `contexts.env: unknown field "requried"; did you mean "required"?`) {
+		t.Errorf("stderr = %q, want unknown field warning", stderr)
+	}
+
+	// --strict fails
+	if _, err := load(&Flags{Strict: true}); err == nil || !strings.Contains(err.Error(), `unknown field "requried"`) {
+		t.Errorf("--strict error = %v, want unknown field error", err)
+	}
+
+	// settings.strict fails
+	if err := os.WriteFile(filepath.Join(tmpDir, ".start", "strict.cue"), []byte("settings: strict: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := load(&Flags{}); err == nil {
+		t.Error("settings.strict: expected error, got nil")
+	}
+}
+
 // ensureIndex cache behaviour tests
 
 func TestEnsureIndex_FreshCacheSkipsFetchMessage(t *testing.T) {
@@ -2378,3 +2457,49 @@ func TestEnsureIndex_FreshCacheNotRewritten(t *testing.T) {
 		t.Errorf("fresh cache was rewritten (timestamp changed):\n%s", content)
 	}
 }
+
+func TestSessionTempManager_SessionMaxAge(t *testing.T) {
+	tmpDir := t.TempDir()
+	sessionsDir := filepath.Join(tmpDir, "sessions")
+
+	// Two exited sessions, one two hours old and one ten minutes old
+	oldSession := filepath.Join(sessionsDir, "session-20200101000000-99999998")
+	newSession := filepath.Join(sessionsDir, "session-20200101000000-99999999")
+	for dir, age := range map[string]time.Duration{oldSession: 2 * time.Hour, newSession: 10 * time.Minute} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		past := time.Now().Add(-age)
+		if err := os.Chtimes(dir, past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	configDir := filepath.Join(tmpDir, "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `settings: {
+	temp_dir: "` + sessionsDir + `"
+	session_max_age: "1h"
+}
+`
+	if err := os.WriteFile(filepath.Join(configDir, "settings.cue"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := internalcue.NewLoader().Load([]string{configDir})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	m := sessionTempManager(cfg, &Flags{}, io.Discard)
+	if filepath.Dir(m.BaseDir) != sessionsDir {
+		t.Errorf("BaseDir = %q, want a session under %q", m.BaseDir, sessionsDir)
+	}
+	if _, err := os.Stat(oldSession); !os.IsNotExist(err) {
+		t.Errorf("session older than session_max_age was kept (stat error = %v)", err)
+	}
+	if _, err := os.Stat(newSession); err != nil {
+		t.Errorf("session younger than session_max_age was removed: %v", err)
+	}
+}
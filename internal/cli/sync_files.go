@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grantcarthew/start/internal/config"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/orchestration"
+	"github.com/grantcarthew/start/internal/secrets"
+	"github.com/grantcarthew/start/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// syncFilesError signals that --check found out-of-date files.
+// The files have already been listed, so the error is not printed.
+type syncFilesError struct{}
+
+func (e *syncFilesError) Error() string { return "instruction files out of date" }
+func (e *syncFilesError) Silent() bool  { return true }
+
+// syncTarget is an instruction file and the agents that read it.
+type syncTarget struct {
+	Path   string
+	Agents []string
+}
+
+// addSyncFilesCommand adds the sync-files command to the parent command.
+func addSyncFilesCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:     "sync-files",
+		GroupID: "utilities",
+		Short:   "Write role and contexts into agent instruction files",
+		Long: `Render the role and contexts start would use into each agent's native
+instruction file, so running the agent directly gets the same instructions.
+
+The role and contexts are selected as for 'start': required and default
+contexts plus any --context tags, with the --role or default role.
+
+Each configured agent reads one instruction file, relative to the working
+directory:
+  claude           CLAUDE.md
+  gemini           GEMINI.md
+  other agents     AGENTS.md
+Set instructions_file on an agent to override its file. Use --agent to sync
+only that agent's file.
+
+Rendered content goes between managed block markers:
+  <!-- start:begin ... -->
+  <!-- start:end -->
+Content outside the block is preserved. The block is appended to files that
+do not have one. A context that reads the target file is left out of it.
+Files are referenced at their source paths, relative to the working directory
+when inside it, and {{.datetime}} renders empty, so the output only changes
+when the instructions do.
+
+The content is scanned for secrets first. Since instruction files are
+committed, any finding stops the sync unless settings.secret_policy is redact,
+which writes the secrets redacted.
+
+Exit codes (--check):
+  0 - All files are up to date
+  1 - One or more files are out of date
+
+Examples:
+  start sync-files                 Update all instruction files
+  start sync-files --agent claude  Update CLAUDE.md only
+  start sync-files --check         Fail if any file is out of date (CI)`,
+		Args: noArgsOrHelp,
+		RunE: runSyncFiles,
+	}
+
+	cmd.Flags().Bool("check", false, "Report out-of-date files without writing (exit 1 if any)")
+	parent.AddCommand(cmd)
+}
+
+// runSyncFiles renders the composed role and contexts into agent instruction files.
+func runSyncFiles(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	stdout := cmd.OutOrStdout()
+	stderr := cmd.ErrOrStderr()
+	flags := getFlags(cmd)
+	check, _ := cmd.Flags().GetBool("check")
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+	cfg, err := loadConfig(config.ScopeMerged)
+	if err != nil {
+		return err
+	}
+
+	targets, err := syncTargets(cfg, flags.Agent)
+	if err != nil {
+		return err
+	}
+
+	env, result, err := composeSyncFiles(cfg, workingDir, flags, stderr, cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	printWarnings(flags, stderr, result.Warnings)
+
+	// Instruction files are committed, so secrets are only written redacted
+	if err := applySecretPolicy(env, &result, flags, stderr); err != nil {
+		return err
+	}
+	if report := result.Secrets; len(report.Findings) > 0 && report.Policy != secrets.PolicyRedact {
+		report.Policy = secrets.PolicyBlock
+		printSecretFindings(stderr, report)
+		return fmt.Errorf("blocked: %d secret(s) detected in %s; instruction files are committed, so set secret_policy to redact to write them redacted",
+			len(report.Findings), secretSources(report.Findings))
+	}
+	for _, ctx := range result.Contexts {
+		if ctx.Status == "error" {
+			printWarning(stderr, "context %q: %s", ctx.Name, ctx.Error)
+		}
+	}
+
+	stale := 0
+	for _, t := range targets {
+		path := t.Path
+		if expanded, err := orchestration.ExpandTilde(path); err == nil {
+			path = expanded
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(workingDir, path)
+		}
+
+		changed, err := syncInstructionFile(path, orchestration.RenderInstructions(result, path), check)
+		if err != nil {
+			return err
+		}
+
+		agents := tui.Annotate("%s", strings.Join(t.Agents, ", "))
+		switch {
+		case !changed:
+			if !flags.Quiet {
+				_, _ = fmt.Fprintf(stdout, "Up to date %s %s\n", t.Path, agents)
+			}
+		case check:
+			stale++
+			_, _ = tui.ColorWarning.Fprint(stdout, "Out of date")
+			_, _ = fmt.Fprintf(stdout, " %s %s\n", t.Path, agents)
+		default:
+			if !flags.Quiet {
+				_, _ = fmt.Fprintf(stdout, "Updated %s %s\n", t.Path, agents)
+			}
+		}
+	}
+
+	if stale > 0 {
+		if !flags.Quiet {
+			_, _ = tui.ColorDim.Fprintln(stdout, "Run 'start sync-files' to update")
+		}
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &syncFilesError{}
+	}
+	return nil
+}
+
+// syncTargets returns the instruction file for each configured agent, or for
+// agentName alone when set, merging agents that share a file. With no agents
+// configured, AGENTS.md is the only target.
+func syncTargets(cfg internalcue.LoadResult, agentName string) ([]syncTarget, error) {
+	var names []string
+	if agentName != "" {
+		names = []string{agentName}
+	} else {
+		choices, err := getConfiguredAgents(cfg.Value)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range choices {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) == 0 {
+		return []syncTarget{{Path: orchestration.DefaultInstructionsFile}}, nil
+	}
+
+	var targets []syncTarget
+	index := make(map[string]int)
+	for _, name := range names {
+		agent, err := orchestration.ExtractAgent(cfg.Value, name)
+		if err != nil {
+			return nil, err
+		}
+		path := orchestration.InstructionsFile(agent)
+		if i, ok := index[path]; ok {
+			targets[i].Agents = append(targets[i].Agents, name)
+			continue
+		}
+		index[path] = len(targets)
+		targets = append(targets, syncTarget{Path: path, Agents: []string{name}})
+	}
+	return targets, nil
+}
+
+// composeSyncFiles composes the role and contexts selected by the flags, the
+// same way 'start' does but stable across runs: files are referenced at their
+// source paths and {{.datetime}} is empty. Commands from untrusted local
+// config do not run.
+func composeSyncFiles(cfg internalcue.LoadResult, workingDir string, flags *Flags, stderr io.Writer, stdin io.Reader) (*ExecutionEnv, orchestration.ComposeResult, error) {
+	blocked, err := checkLocalTrust(workingDir, flags, stderr, stdin)
+	if err != nil {
+		return nil, orchestration.ComposeResult{}, err
+	}
+
+	env := &ExecutionEnv{
+		Cfg:         cfg,
+		WorkingDir:  workingDir,
+		TempManager: sessionTempManager(cfg, flags, stderr),
+	}
+	processor := orchestration.NewTemplateProcessor(nil, newShellRunner(blocked), workingDir)
+	env.Composer = orchestration.NewComposer(processor, env.TempManager, workingDir)
+	env.Composer.SetStable()
+	selection := orchestration.ContextSelection{
+		IncludeRequired: true,
+		IncludeDefaults: true,
+		Tags:            flags.Context,
+	}
+	var result orchestration.ComposeResult
+	if flags.NoRole {
+		result, err = env.Composer.Compose(cfg.Value, selection, "")
+	} else {
+		result, err = env.Composer.ComposeWithRole(cfg.Value, selection, flags.Role, "")
+	}
+	return env, result, err
+}
+
+// syncInstructionFile replaces the managed block in the file at path with
+// body. Returns whether the file differs from the synced content. With check
+// set, the file is never written.
+func syncInstructionFile(path, body string, check bool) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	updated := orchestration.ReplaceManagedBlock(string(existing), body)
+	if updated == string(existing) {
+		return false, nil
+	}
+	if check {
+		return true, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return true, fmt.Errorf("creating directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return true, fmt.Errorf("writing %s: %w", path, err)
+	}
+	return true, nil
+}
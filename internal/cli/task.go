@@ -362,6 +362,11 @@ func executeTask(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, taskNa
 	printWarnings(flags, stderr, taskResult.Warnings)
 	printWarnings(flags, stderr, composeResult.Warnings)
 
+	// Scan for secrets before anything is handed to the agent
+	if err := applySecretPolicy(env, &composeResult, flags, stderr); err != nil {
+		return err
+	}
+
 	// Determine effective model and its source
 	model, modelSource := resolveModel(resolvedModel, env.Agent.DefaultModel)
 	if model != "" {
@@ -393,6 +398,10 @@ func executeTask(stdout, stderr io.Writer, stdin io.Reader, flags *Flags, taskNa
 		return executeTaskDryRun(stdout, cmdStr, execConfig, composeResult, env.Agent, model, modelSource, resolvedName, instructions)
 	}
 
+	if err := env.checkAgentTrusted(); err != nil {
+		return err
+	}
+
 	// Print execution info
 	if !flags.Quiet {
 		printTaskExecutionInfo(stdout, env.Agent, model, modelSource, composeResult, resolvedName, instructions, taskResult)
@@ -423,8 +432,8 @@ func executeTaskDryRun(w io.Writer, cmdStr string, cfg orchestration.ExecuteConf
 	cmdContent := orchestration.GenerateDryRunCommand(agent, cfg.Model, result.RoleName, contextNames, cfg.WorkingDir, cmdStr)
 
 	// Write files
-	if err := tempMgr.WriteDryRunFiles(dir, result.Role, result.Prompt, cmdContent); err != nil {
-		return fmt.Errorf("writing dry-run files: %w", err)
+	if err := writeDryRunFiles(tempMgr, dir, result, cmdContent); err != nil {
+		return err
 	}
 
 	// Print summary
@@ -441,6 +450,7 @@ func printTaskExecutionInfo(w io.Writer, agent orchestration.Agent, model, model
 	printAgentModel(w, agent, model, modelSource)
 	printContextTable(w, result.Contexts, result.Selection)
 	printRoleTable(w, result.RoleResolutions)
+	printSecretFindings(w, result.Secrets)
 
 	if taskResult.CommandExecuted {
 		_, _ = fmt.Fprintln(w, "Command: executed")
@@ -462,6 +472,7 @@ func printTaskDryRunSummary(w io.Writer, agent orchestration.Agent, model, model
 	printAgentModel(w, agent, model, modelSource)
 	printContextTable(w, result.Contexts, result.Selection)
 	printRoleTable(w, result.RoleResolutions)
+	printSecretFindings(w, result.Secrets)
 
 	if instructions != "" {
 		_, _ = fmt.Fprintf(w, "Instructions:\n%s\n", instructions)
@@ -485,6 +496,7 @@ func printTaskDryRunSummary(w io.Writer, agent orchestration.Agent, model, model
 	_, _ = fmt.Fprintln(w, "  role.md")
 	_, _ = fmt.Fprintln(w, "  prompt.md")
 	_, _ = fmt.Fprintln(w, "  command.txt")
+	_, _ = fmt.Fprintln(w, "  contexts.json")
 }
 
 // taskInMatches returns true if a task name appears in the match list.
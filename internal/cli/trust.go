@@ -0,0 +1,295 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/grantcarthew/start/internal/config"
+	"github.com/grantcarthew/start/internal/orchestration"
+	"github.com/grantcarthew/start/internal/shell"
+	"github.com/grantcarthew/start/internal/trust"
+	"github.com/grantcarthew/start/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// addTrustCommand adds the trust command to the parent command.
+func addTrustCommand(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:     "trust [path]",
+		GroupID: "utilities",
+		Short:   "Allow commands from project-local config",
+		Long: `Approve the project-local config (./.start/) so its commands may run.
+
+Contexts, roles, tasks, and agents in local config can define commands that
+start executes on launch. Because a cloned repository can ship its own
+./.start/, commands from local config only run once the config is trusted.
+Trust records a hash of the config files: any change to them requires
+trusting again.
+
+Commands that also appear in your global config are always allowed. Untrusted
+commands are skipped with a warning; on a terminal start asks first.
+
+Every .start directory from the project up to the git root is trusted
+together. Review the commands listed before trusting.
+
+Examples:
+  start trust                  Trust ./.start/ in the current directory
+  start trust ~/src/project    Trust another project's config
+  start trust --list           List trusted directories and their status
+  start trust --revoke         Revoke trust for ./.start/ and its parents`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runTrust,
+	}
+
+	cmd.Flags().Bool("list", false, "List trusted config directories")
+	cmd.Flags().Bool("revoke", false, "Revoke trust for the config directory")
+	cmd.MarkFlagsMutuallyExclusive("list", "revoke")
+	parent.AddCommand(cmd)
+}
+
+// runTrust trusts, revokes, or lists local config directories.
+func runTrust(cmd *cobra.Command, args []string) error {
+	if shown, err := checkHelpArg(cmd, args); shown || err != nil {
+		return err
+	}
+
+	store, err := trust.LoadDefault()
+	if err != nil {
+		return err
+	}
+	w := cmd.OutOrStdout()
+
+	if list, _ := cmd.Flags().GetBool("list"); list {
+		printTrustList(w, store)
+		return nil
+	}
+
+	projectDir := ""
+	if len(args) > 0 {
+		projectDir = args[0]
+	}
+	paths, err := config.ResolvePaths(projectDir)
+	if err != nil {
+		return fmt.Errorf("resolving config paths: %w", err)
+	}
+
+	if revoke, _ := cmd.Flags().GetBool("revoke"); revoke {
+		dirs := paths.Locals()
+		if len(dirs) == 0 {
+			dirs = []string{paths.Local}
+		}
+		var revoked []string
+		for _, dir := range dirs {
+			removed, err := store.Revoke(dir)
+			if err != nil {
+				return err
+			}
+			if removed {
+				revoked = append(revoked, dir)
+			}
+		}
+		if len(revoked) == 0 {
+			return fmt.Errorf("%s is not trusted", paths.Local)
+		}
+		if err := store.Save(); err != nil {
+			return err
+		}
+		for _, dir := range revoked {
+			_, _ = fmt.Fprintf(w, "Revoked trust for %s\n", dir)
+		}
+		return nil
+	}
+
+	if !paths.LocalExists {
+		return fmt.Errorf("no local config found at %s", paths.Local)
+	}
+
+	// Trust every layer from the project up to the git root: an outer
+	// layer's commands run just like the nearest one's.
+	for _, dir := range paths.Locals() {
+		commands, err := trust.LocalCommands(dir, paths.Global)
+		if err != nil {
+			return fmt.Errorf("loading local config %s: %w", dir, err)
+		}
+		if len(commands) > 0 && !getFlags(cmd).Quiet {
+			printTrustCommands(w, commands)
+		}
+		if err := store.Trust(dir); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "Trusted %s\n", dir)
+	}
+	return store.Save()
+}
+
+// printTrustList prints trusted directories with their current status.
+func printTrustList(w io.Writer, store *trust.Store) {
+	dirs := store.Dirs()
+	if len(dirs) == 0 {
+		_, _ = fmt.Fprintln(w, "No trusted config directories")
+		return
+	}
+
+	for _, dir := range dirs {
+		entry := store.Entries[dir]
+		var status string
+		if _, err := os.Stat(dir); err != nil {
+			status = tui.ColorDim.Sprint("missing")
+		} else if s, err := store.Check(dir); err != nil {
+			status = tui.ColorError.Sprint("error")
+		} else if s == trust.StatusChanged {
+			status = tui.ColorWarning.Sprint("changed")
+		} else {
+			status = tui.ColorSuccess.Sprint("trusted")
+		}
+		_, _ = fmt.Fprintf(w, "%s  %s %s\n", dir, status, tui.Annotate("since %s", entry.TrustedAt.Local().Format("2006-01-02 15:04")))
+	}
+}
+
+// printTrustCommands lists the commands a local config would run.
+func printTrustCommands(w io.Writer, commands []trust.Command) {
+	_, _ = tui.ColorWarning.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		_, _ = fmt.Fprintf(w, "  %s\n", c)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// checkLocalTrust returns the set of local config commands that must not run.
+// Each local config layer is trusted when its hash matches the trust store,
+// or when it defines no commands beyond those in global config. Otherwise, on
+// a terminal the user is asked to trust it; elsewhere the commands are
+// blocked with a warning.
+func checkLocalTrust(workingDir string, flags *Flags, stderr io.Writer, stdin io.Reader) (*untrustedConfig, error) {
+	paths, err := config.ResolvePaths(workingDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving config paths: %w", err)
+	}
+	if !paths.LocalExists {
+		return nil, nil
+	}
+
+	store, err := trust.LoadDefault()
+	if err != nil {
+		return nil, err
+	}
+
+	var blocked *untrustedConfig
+	for _, dir := range paths.Locals() {
+		commands, err := checkLayerTrust(store, dir, paths.Global, flags, stderr, stdin)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range commands {
+			if blocked == nil {
+				blocked = &untrustedConfig{commands: make(map[string]bool), shells: make(map[string]bool), agents: make(map[string]bool)}
+			}
+			blocked.add(c)
+		}
+	}
+	return blocked, nil
+}
+
+// untrustedConfig holds what untrusted local config defines, so it is refused
+// when it would run. A nil *untrustedConfig blocks nothing.
+type untrustedConfig struct {
+	commands map[string]bool // UTD command text
+	shells   map[string]bool // UTD shells
+	agents   map[string]bool // agent names
+}
+
+// add blocks an item from untrusted local config. An agent is blocked by name,
+// since the local layer may set only its bin and merge the command from
+// global config.
+func (u *untrustedConfig) add(c trust.Command) {
+	if c.Kind == "agent" {
+		u.agents[c.Name] = true
+		return
+	}
+	if c.Command != "" {
+		u.commands[c.Command] = true
+	}
+	if c.Shell != "" {
+		u.shells[c.Shell] = true
+	}
+}
+
+// agentBlocked reports whether untrusted local config defines the agent.
+func (u *untrustedConfig) agentBlocked(name string) bool {
+	return u != nil && u.agents[name]
+}
+
+// checkLayerTrust checks a single local config directory and returns the
+// commands to block, asking the user first on a terminal.
+func checkLayerTrust(store *trust.Store, dir, globalDir string, flags *Flags, stderr io.Writer, stdin io.Reader) ([]trust.Command, error) {
+	status, err := store.Check(dir)
+	if err != nil {
+		return nil, err
+	}
+	debugf(stderr, flags, dbgConfig, "Local config trust: %s (%s)", status, dir)
+	if status == trust.StatusTrusted {
+		return nil, nil
+	}
+
+	commands, err := trust.LocalCommands(dir, globalDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading local config: %w", err)
+	}
+	if len(commands) == 0 {
+		return nil, nil
+	}
+
+	reason := "is not trusted"
+	if status == trust.StatusChanged {
+		reason = "changed since it was trusted"
+	}
+
+	if isTerminal(stdin) {
+		_, _ = fmt.Fprintf(stderr, "Local config %s %s.\n", dir, reason)
+		printTrustCommands(stderr, commands)
+		_, _ = fmt.Fprintf(stderr, "Trust and run these commands? %s: ", tui.Bracket("y/N"))
+		input, _ := bufio.NewReader(stdin).ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input == "y" || input == "yes" {
+			if err := store.Trust(dir); err != nil {
+				return nil, err
+			}
+			if err := store.Save(); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		}
+	} else {
+		printWarning(stderr, "local config %s %s; skipping %d command(s). Review and run 'start trust' to allow them.",
+			dir, reason, len(commands))
+	}
+	return commands, nil
+}
+
+// newShellRunner returns the shell runner for UTD commands, refusing the
+// blocked commands from untrusted local config.
+func newShellRunner(blocked *untrustedConfig) orchestration.ShellRunner {
+	var runner orchestration.ShellRunner = shell.NewRunner()
+	if blocked != nil && (len(blocked.commands) > 0 || len(blocked.shells) > 0) {
+		runner = &untrustedRunner{runner: runner, blocked: blocked}
+	}
+	return runner
+}
+
+// untrustedRunner refuses commands from untrusted local config and passes
+// all others to the wrapped runner.
+type untrustedRunner struct {
+	runner  orchestration.ShellRunner
+	blocked *untrustedConfig
+}
+
+// Run implements orchestration.ShellRunner.
+func (r *untrustedRunner) Run(command, workingDir, shell string, timeout int) (string, error) {
+	if r.blocked.commands[command] || r.blocked.shells[shell] {
+		return "", fmt.Errorf("command from untrusted local config not run (see 'start trust')")
+	}
+	return r.runner.Run(command, workingDir, shell, timeout)
+}
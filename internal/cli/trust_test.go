@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grantcarthew/start/internal/trust"
+)
+
+// setupTrustTestConfig adds a default context with a command to the local config.
+func setupTrustTestConfig(t *testing.T) string {
+	t.Helper()
+	tmpDir := setupStartTestConfig(t)
+	contexts := `contexts: status: {
+	default: true
+	command: "echo local-command-output"
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".start", "contexts.cue"), []byte(contexts), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TMPDIR", tmpDir)
+	return tmpDir
+}
+
+// dryRunPrompt runs a dry-run start and returns the composed prompt and stderr.
+func dryRunPrompt(t *testing.T, tmpDir string) (prompt, stderr string) {
+	t.Helper()
+	errBuf := new(bytes.Buffer)
+	if err := executeStart(new(bytes.Buffer), errBuf, strings.NewReader(""), &Flags{DryRun: true}, defaultSelection(), ""); err != nil {
+		t.Fatalf("executeStart() error = %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(tmpDir, "start-*"))
+	if len(matches) == 0 {
+		t.Fatal("no dry-run directory written")
+	}
+	dir := matches[len(matches)-1]
+	data, err := os.ReadFile(filepath.Join(dir, "prompt.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	return string(data), errBuf.String()
+}
+
+// trustLocalConfig records dir as trusted in the test's trust store.
+func trustLocalConfig(t *testing.T, dir string) {
+	t.Helper()
+	store, err := trust.LoadDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Trust(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runTrustCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewRootCmd()
+	stdout := new(bytes.Buffer)
+	cmd.SetOut(stdout)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs(append([]string{"trust"}, args...))
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestTrust_UntrustedCommandsSkipped(t *testing.T) {
+	tmpDir := setupTrustTestConfig(t)
+	chdir(t, tmpDir)
+
+	prompt, stderr := dryRunPrompt(t, tmpDir)
+	if strings.Contains(prompt, "local-command-output") {
+		t.Errorf("untrusted command ran:\n%s", prompt)
+	}
+	if !strings.Contains(stderr, "is not trusted") || !strings.Contains(stderr, "start trust") {
+		t.Errorf("expected untrusted warning, got:\n%s", stderr)
+	}
+	// Contexts without commands are unaffected
+	if !strings.Contains(prompt, "Project context") {
+		t.Errorf("expected prompt context in output:\n%s", prompt)
+	}
+}
+
+func TestTrust_ApproveChangeRevoke(t *testing.T) {
+	tmpDir := setupTrustTestConfig(t)
+	chdir(t, tmpDir)
+
+	out, err := runTrustCmd(t)
+	if err != nil {
+		t.Fatalf("trust error = %v", err)
+	}
+	if !strings.Contains(out, "context status: echo local-command-output") || !strings.Contains(out, "Trusted ") {
+		t.Errorf("expected command listing and confirmation, got:\n%s", out)
+	}
+
+	prompt, stderr := dryRunPrompt(t, tmpDir)
+	if !strings.Contains(prompt, "local-command-output") {
+		t.Errorf("trusted command did not run:\n%s", prompt)
+	}
+	if strings.Contains(stderr, "trust") {
+		t.Errorf("unexpected trust warning:\n%s", stderr)
+	}
+
+	// Editing the config invalidates trust
+	contextsFile := filepath.Join(tmpDir, ".start", "contexts.cue")
+	data, _ := os.ReadFile(contextsFile)
+	if err := os.WriteFile(contextsFile, append(data, []byte("// edited\n")...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prompt, stderr = dryRunPrompt(t, tmpDir)
+	if strings.Contains(prompt, "local-command-output") || !strings.Contains(stderr, "changed since it was trusted") {
+		t.Errorf("changed config should block commands; stderr:\n%s", stderr)
+	}
+
+	out, err = runTrustCmd(t, "--list")
+	if err != nil {
+		t.Fatalf("trust --list error = %v", err)
+	}
+	if !strings.Contains(out, filepath.Join(tmpDir, ".start")) || !strings.Contains(out, "changed") {
+		t.Errorf("expected changed entry in list, got:\n%s", out)
+	}
+
+	if _, err := runTrustCmd(t, "--revoke"); err != nil {
+		t.Fatalf("trust --revoke error = %v", err)
+	}
+	if out, _ := runTrustCmd(t, "--list"); !strings.Contains(out, "No trusted config directories") {
+		t.Errorf("expected empty list after revoke, got:\n%s", out)
+	}
+	if _, err := runTrustCmd(t, "--revoke"); err == nil {
+		t.Error("revoking an untrusted directory should fail")
+	}
+}
+
+func TestTrust_UntrustedLocalAgentRefused(t *testing.T) {
+	tmpDir := setupStartTestConfig(t)
+	chdir(t, tmpDir)
+
+	flags := &Flags{}
+	cfg, workingDir, err := loadExecutionConfig(io.Discard, io.Discard, strings.NewReader(""), flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The test agent is only defined in ./.start
+	env, err := buildExecutionEnv(cfg, workingDir, "", flags, io.Discard, io.Discard, strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("buildExecutionEnv() error = %v", err)
+	}
+	if err := env.checkAgentTrusted(); err == nil || !strings.Contains(err.Error(), "untrusted local config") {
+		t.Fatalf("expected untrusted agent error, got %v", err)
+	}
+
+	if _, err := runTrustCmd(t); err != nil {
+		t.Fatal(err)
+	}
+	env, err = buildExecutionEnv(cfg, workingDir, "", flags, io.Discard, io.Discard, strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := env.checkAgentTrusted(); err != nil {
+		t.Errorf("trusted agent refused: %v", err)
+	}
+}
+
+func TestTrust_LocalAgentReusingGlobalCommand(t *testing.T) {
+	tmpDir := setupStartTestConfig(t)
+	chdir(t, tmpDir)
+
+	globalDir := filepath.Join(tmpDir, "start")
+	if err := os.MkdirAll(globalDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	agentUntrusted := func(global string) bool {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(globalDir, "agents.cue"), []byte(global), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		flags := &Flags{}
+		cfg, workingDir, err := loadExecutionConfig(io.Discard, io.Discard, strings.NewReader(""), flags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := buildExecutionEnv(cfg, workingDir, "echo", flags, io.Discard, io.Discard, strings.NewReader(""))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return env.checkAgentTrusted() != nil
+	}
+
+	// Same command text under another bin is not the agent the user trusts.
+	if !agentUntrusted(`agents: echo: {bin: "printf", command: "{{.bin}} 'Agent executed'"}`) {
+		t.Error("local agent with a different bin should be refused")
+	}
+	// An identical item in global config needs no approval.
+	if agentUntrusted(`agents: echo: {bin: "echo", command: "{{.bin}} 'Agent executed'"}`) {
+		t.Error("local agent identical to global config should run")
+	}
+}
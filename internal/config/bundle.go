@@ -0,0 +1,215 @@
+package config
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// BundleFormat is the layout version of the archives written by PackBundle.
+const BundleFormat = 1
+
+// bundleManifestName is the manifest entry in a bundle archive.
+const bundleManifestName = "manifest.json"
+
+// maxBundleSize limits the total size of the files read from a bundle.
+const maxBundleSize = 16 << 20
+
+// BundleManifest describes the config files in a bundle.
+type BundleManifest struct {
+	Format        int          `json:"format"`
+	ConfigVersion int          `json:"config_version"` // config format version of the files
+	Scope         string       `json:"scope"`          // scope the files were packed from
+	Created       time.Time    `json:"created"`
+	Files         []BundleFile `json:"files"`
+}
+
+// BundleFile is a config file listed in a bundle manifest.
+type BundleFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+}
+
+// Bundle is a verified bundle read by ReadBundle.
+type Bundle struct {
+	Manifest BundleManifest
+	Files    map[string][]byte // content by file name
+}
+
+// PackBundle writes the CUE files in dir to w as a gzipped tar archive with a
+// manifest listing each file and its SHA-256 checksum. Returns the manifest.
+func PackBundle(w io.Writer, dir, scope string) (BundleManifest, error) {
+	files, err := CUEFilesInDir(dir)
+	if err != nil {
+		return BundleManifest{}, fmt.Errorf("reading %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return BundleManifest{}, fmt.Errorf("no config files in %s", dir)
+	}
+	version, err := DirVersion(dir)
+	if err != nil {
+		return BundleManifest{}, err
+	}
+
+	manifest := BundleManifest{
+		Format:        BundleFormat,
+		ConfigVersion: version,
+		Scope:         scope,
+		Created:       time.Now().UTC().Truncate(time.Second),
+	}
+	contents := make([][]byte, len(files))
+	for i, path := range files {
+		if contents[i], err = os.ReadFile(path); err != nil {
+			return BundleManifest{}, fmt.Errorf("reading %s: %w", path, err)
+		}
+		manifest.Files = append(manifest.Files, BundleFile{Name: filepath.Base(path), SHA256: checksum(contents[i])})
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return BundleManifest{}, fmt.Errorf("encoding manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	// The manifest comes first so readers can check the files against it.
+	if err := writeTarFile(tw, bundleManifestName, append(manifestData, '\n'), manifest.Created); err != nil {
+		return BundleManifest{}, err
+	}
+	for i, f := range manifest.Files {
+		if err := writeTarFile(tw, f.Name, contents[i], manifest.Created); err != nil {
+			return BundleManifest{}, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return BundleManifest{}, fmt.Errorf("writing bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return BundleManifest{}, fmt.Errorf("writing bundle: %w", err)
+	}
+	return manifest, nil
+}
+
+// writeTarFile adds a regular file to a tar archive.
+func writeTarFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: modTime}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+	return nil
+}
+
+// ReadBundle reads a bundle written by PackBundle. The archive must hold
+// exactly the files its manifest lists, each matching its checksum, and be
+// in a format and config version this release can read.
+func ReadBundle(r io.Reader) (Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("reading bundle: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	bundle := Bundle{Files: make(map[string][]byte)}
+	var manifestData []byte
+	total := int64(0)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Bundle{}, fmt.Errorf("reading bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return Bundle{}, fmt.Errorf("bundle entry %q is not a regular file", hdr.Name)
+		}
+		name := hdr.Name
+		if name != bundleManifestName && (name != filepath.Base(name) || !strings.HasSuffix(name, ".cue")) {
+			return Bundle{}, fmt.Errorf("unexpected bundle entry %q", hdr.Name)
+		}
+		total += hdr.Size
+		if total > maxBundleSize {
+			return Bundle{}, fmt.Errorf("bundle is larger than %d MiB", maxBundleSize>>20)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return Bundle{}, fmt.Errorf("reading %s from bundle: %w", name, err)
+		}
+		if name == bundleManifestName {
+			manifestData = data
+			continue
+		}
+		if _, dup := bundle.Files[name]; dup {
+			return Bundle{}, fmt.Errorf("bundle contains %s more than once", name)
+		}
+		bundle.Files[name] = data
+	}
+
+	if manifestData == nil {
+		return Bundle{}, fmt.Errorf("bundle has no %s", bundleManifestName)
+	}
+	if err := json.Unmarshal(manifestData, &bundle.Manifest); err != nil {
+		return Bundle{}, fmt.Errorf("parsing %s: %w", bundleManifestName, err)
+	}
+	if err := bundle.verify(); err != nil {
+		return Bundle{}, err
+	}
+	return bundle, nil
+}
+
+// verify checks the bundle files against the manifest.
+func (b Bundle) verify() error {
+	m := b.Manifest
+	if m.Format != BundleFormat {
+		return fmt.Errorf("unsupported bundle format %d (this release reads format %d)", m.Format, BundleFormat)
+	}
+	if m.ConfigVersion > CurrentVersion {
+		return fmt.Errorf("bundle uses config format version %d; this release supports up to %d", m.ConfigVersion, CurrentVersion)
+	}
+	var listed []string
+	for _, f := range m.Files {
+		data, ok := b.Files[f.Name]
+		if !ok {
+			return fmt.Errorf("bundle is missing %s", f.Name)
+		}
+		if checksum(data) != f.SHA256 {
+			return fmt.Errorf("checksum mismatch for %s", f.Name)
+		}
+		listed = append(listed, f.Name)
+	}
+	for name := range b.Files {
+		if !slices.Contains(listed, name) {
+			return fmt.Errorf("bundle file %s is not in the manifest", name)
+		}
+	}
+	return nil
+}
+
+// Extract writes the bundle files into dir, which must exist.
+func (b Bundle) Extract(dir string) error {
+	for name, data := range b.Files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return fmt.Errorf("extracting %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// checksum returns the hex SHA-256 of data.
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBundle_RoundTrip(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "roles.cue"), "roles: dev: prompt: \"You are a developer.\"\n")
+	writeTestFile(t, filepath.Join(dir, "settings.cue"), "settings: timeout: 60\n")
+	writeTestFile(t, filepath.Join(dir, "notes.txt"), "not config")
+
+	var buf bytes.Buffer
+	manifest, err := PackBundle(&buf, dir, "global")
+	if err != nil {
+		t.Fatalf("PackBundle: %v", err)
+	}
+	if len(manifest.Files) != 2 || manifest.ConfigVersion != 1 || manifest.Scope != "global" {
+		t.Errorf("manifest = %+v", manifest)
+	}
+
+	bundle, err := ReadBundle(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadBundle: %v", err)
+	}
+	if got := string(bundle.Files["roles.cue"]); got != readTestFile(t, filepath.Join(dir, "roles.cue")) {
+		t.Errorf("roles.cue = %q", got)
+	}
+
+	out := t.TempDir()
+	if err := bundle.Extract(out); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, filepath.Join(out, "settings.cue")); got != "settings: timeout: 60\n" {
+		t.Errorf("extracted settings.cue = %q", got)
+	}
+}
+
+func TestReadBundle_Rejects(t *testing.T) {
+	t.Parallel()
+	manifest := `{"format": 1, "config_version": 1, "files": [{"name": "roles.cue", "sha256": "%s"}]}`
+	roles := "roles: {}\n"
+	sum := checksum([]byte(roles))
+
+	tests := []struct {
+		name    string
+		entries map[string]string
+		wantErr string
+	}{
+		{"tampered file", map[string]string{"manifest.json": strings.Replace(manifest, "%s", sum, 1), "roles.cue": "roles: x: {}\n"}, "checksum mismatch"},
+		{"missing file", map[string]string{"manifest.json": strings.Replace(manifest, "%s", sum, 1)}, "missing roles.cue"},
+		{"unlisted file", map[string]string{"manifest.json": strings.Replace(manifest, "%s", sum, 1), "roles.cue": roles, "extra.cue": ""}, "not in the manifest"},
+		{"path in name", map[string]string{"../roles.cue": roles}, "unexpected bundle entry"},
+		{"no manifest", map[string]string{"roles.cue": roles}, "no manifest.json"},
+		{"newer config", map[string]string{"manifest.json": `{"format": 1, "config_version": 99}`}, "supports up to"},
+		{"newer format", map[string]string{"manifest.json": `{"format": 9}`}, "unsupported bundle format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ReadBundle(bytes.NewReader(tarGz(t, tt.entries)))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// tarGz builds a gzipped tar archive of the entries.
+func tarGz(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"slices"
+
+	"cuelang.org/go/cue"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+)
+
+// DisabledItem is a collection item removed from the merged config by
+// enabled: false.
+type DisabledItem struct {
+	Key    string // Collection key, e.g. "agents"
+	Name   string
+	Source string // Layer source that disabled the item
+	Dir    string // Config directory that disabled the item
+}
+
+// collectionKeys are the collection keys in display order.
+var collectionKeys = []string{internalcue.KeyAgents, internalcue.KeyRoles, internalcue.KeyContexts, internalcue.KeyTasks}
+
+// DisabledItems returns the items disabled in the merged config for scope,
+// in collection then definition order. An item is disabled by the last layer
+// that defines it with enabled: false, unless a later layer redefines it
+// without merge: true.
+func DisabledItems(paths Paths, scope Scope) []DisabledItem {
+	disabled := make(map[string]DisabledItem)
+	var order []string
+
+	loader := internalcue.NewLoader()
+	for _, layer := range paths.Layers(scope) {
+		v, err := loader.LoadSingle(layer.Dir)
+		if err != nil {
+			continue
+		}
+		for _, key := range collectionKeys {
+			iter, err := v.LookupPath(cue.ParsePath(key)).Fields()
+			if err != nil {
+				continue
+			}
+			for iter.Next() {
+				name := iter.Selector().Unquoted()
+				id := key + "." + name
+				item := iter.Value()
+				switch {
+				case internalcue.IsDisabled(item):
+					if !slices.Contains(order, id) {
+						order = append(order, id)
+					}
+					disabled[id] = DisabledItem{Key: key, Name: name, Source: layer.Source, Dir: layer.Dir}
+				case item.LookupPath(cue.ParsePath(internalcue.FieldEnabled)).Exists():
+					// enabled: true re-enables the item
+					delete(disabled, id)
+				default:
+					merge, _ := item.LookupPath(cue.ParsePath(internalcue.FieldMerge)).Bool()
+					if !merge {
+						delete(disabled, id)
+					}
+				}
+			}
+		}
+	}
+
+	var items []DisabledItem
+	for _, key := range collectionKeys {
+		for _, id := range order {
+			if d, ok := disabled[id]; ok && d.Key == key {
+				items = append(items, d)
+			}
+		}
+	}
+	return items
+}
+
+// FindDisabled returns the disabled item with the given collection key and
+// name, if any.
+func FindDisabled(items []DisabledItem, key, name string) (DisabledItem, bool) {
+	for _, d := range items {
+		if d.Key == key && d.Name == name {
+			return d, true
+		}
+	}
+	return DisabledItem{}, false
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDisabledItems(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	global := filepath.Join(root, "global")
+	outer := filepath.Join(root, "repo", ".start")
+	inner := filepath.Join(root, "repo", "api", ".start")
+	files := map[string]string{
+		global: `agents: {gemini: bin: "gemini", claude: bin: "claude"}
+contexts: readme: file: "README.md"`,
+		outer: `agents: gemini: enabled: false
+contexts: readme: enabled: false
+agents: claude: enabled: false`,
+		inner: `contexts: readme: file: "docs/README.md"
+agents: claude: {merge: true, description: "still disabled"}`,
+	}
+	for dir, content := range files {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "config.cue"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths := Paths{
+		Global:       global,
+		GlobalExists: true,
+		Local:        inner,
+		LocalLayers:  []string{outer, inner},
+		LocalExists:  true,
+	}
+	items := DisabledItems(paths, ScopeMerged)
+
+	if len(items) != 2 {
+		t.Fatalf("DisabledItems() = %+v, want gemini and claude", items)
+	}
+	if d, ok := FindDisabled(items, "agents", "gemini"); !ok || d.Source != SourceLocal || d.Dir != outer {
+		t.Errorf("gemini = %+v, found %v", d, ok)
+	}
+	if _, ok := FindDisabled(items, "agents", "claude"); !ok {
+		t.Error("claude should stay disabled when a later layer merges into it")
+	}
+	if _, ok := FindDisabled(items, "contexts", "readme"); ok {
+		t.Error("readme is redefined by the inner layer and should be enabled")
+	}
+	if got := DisabledItems(paths, ScopeGlobal); len(got) != 0 {
+		t.Errorf("DisabledItems(ScopeGlobal) = %+v, want none", got)
+	}
+}
@@ -0,0 +1,287 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+)
+
+// CurrentVersion is the config format version written by this release.
+const CurrentVersion = 2
+
+// VersionFile is the file in a config directory that records its format
+// version. A directory without one is at version 1.
+const VersionFile = "version.cue"
+
+// versionField is the top-level field in VersionFile holding the version.
+const versionField = "version"
+
+// Migration rewrites config files from the previous format version to
+// Version. Apply changes the parsed file in place and reports whether it
+// changed anything; it must leave a file already in the new form untouched.
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(f *ast.File) bool
+}
+
+// Migrations lists every format change in order. Append new migrations with
+// the next version and bump CurrentVersion to match.
+var Migrations = []Migration{
+	{
+		Version:     2,
+		Description: `convert agent models from {id: "..."} to plain strings`,
+		Apply:       migrateModelIDs,
+	},
+}
+
+// FileChange is a config file rewritten by a migration.
+type FileChange struct {
+	Path   string
+	Before []byte // nil if the migration creates the file
+	After  []byte
+	// Applied lists the descriptions of the migrations that changed the
+	// file; it is empty for the version file.
+	Applied []string
+}
+
+// MigrationPlan holds the changes that bring a config directory to
+// CurrentVersion.
+type MigrationPlan struct {
+	Dir   string
+	From  int
+	Files []FileChange
+}
+
+// Pending reports whether any migration would change a config file, beyond
+// recording the new version.
+func (p MigrationPlan) Pending() bool {
+	for _, f := range p.Files {
+		if len(f.Applied) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// DirVersion returns the config format version recorded in dir, or 1 if it
+// has no version file.
+func DirVersion(dir string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(dir, VersionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading config version: %w", err)
+	}
+	f, err := parser.ParseFile(VersionFile, data)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", filepath.Join(dir, VersionFile), err)
+	}
+	if field := internalcue.FindField(f, []string{versionField}); field != nil {
+		if lit, ok := field.Value.(*ast.BasicLit); ok && lit.Kind == token.INT {
+			if v, err := strconv.Atoi(lit.Value); err == nil {
+				return v, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%s: %s must be an integer", filepath.Join(dir, VersionFile), versionField)
+}
+
+// PlanMigration returns the changes that migrating dir to CurrentVersion
+// would make, without writing anything. A directory at the current version,
+// or without config files, has an empty plan; otherwise the plan also
+// records the new version.
+func PlanMigration(dir string) (MigrationPlan, error) {
+	from, err := DirVersion(dir)
+	if err != nil {
+		return MigrationPlan{}, err
+	}
+	plan := MigrationPlan{Dir: dir, From: from}
+	if from >= CurrentVersion {
+		return plan, nil
+	}
+
+	files, err := CUEFilesInDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return plan, fmt.Errorf("reading %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		// No config here to migrate
+		return plan, nil
+	}
+	for _, path := range files {
+		if filepath.Base(path) == VersionFile {
+			continue
+		}
+		change, err := migrateFile(path, from)
+		if err != nil {
+			return plan, err
+		}
+		if change != nil {
+			plan.Files = append(plan.Files, *change)
+		}
+	}
+
+	change, err := versionFileChange(dir)
+	if err != nil {
+		return plan, err
+	}
+	plan.Files = append(plan.Files, change)
+	return plan, nil
+}
+
+// ApplyMigration writes the files in plan. If the migrated directory no
+// longer loads, every file is put back and the load error is returned.
+func ApplyMigration(plan MigrationPlan) error {
+	for i, f := range plan.Files {
+		if err := WriteFile(f.Path, f.After); err != nil {
+			return errors.Join(err, restoreFiles(plan.Files[:i]))
+		}
+	}
+	if _, err := internalcue.NewLoader().LoadSingle(plan.Dir); err != nil {
+		return errors.Join(fmt.Errorf("migrated config in %s does not load: %w", plan.Dir, err), restoreFiles(plan.Files))
+	}
+	return nil
+}
+
+// restoreFiles puts files back as they were before a migration.
+func restoreFiles(files []FileChange) error {
+	var errs []error
+	for _, f := range files {
+		if f.Before == nil {
+			if err := os.Remove(f.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err := WriteFile(f.Path, f.Before); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// migrateFile applies the migrations after version from to one file.
+// Returns nil if none of them changes it.
+func migrateFile(path string, from int) (*FileChange, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	f, err := parser.ParseFile(path, data, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	var applied []string
+	for _, m := range Migrations {
+		if m.Version > from && m.Apply(f) {
+			applied = append(applied, m.Description)
+		}
+	}
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	out, err := format.Node(f)
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %w", path, err)
+	}
+	if bytes.Equal(out, data) {
+		return nil, nil
+	}
+	return &FileChange{Path: path, Before: data, After: out, Applied: applied}, nil
+}
+
+// versionFileChange returns the change that records CurrentVersion in dir.
+func versionFileChange(dir string) (FileChange, error) {
+	path := filepath.Join(dir, VersionFile)
+	before, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return FileChange{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	f := &ast.File{}
+	if before != nil {
+		if f, err = parser.ParseFile(path, before, parser.ParseComments); err != nil {
+			return FileChange{}, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	} else {
+		comment := &ast.CommentGroup{Doc: true, List: []*ast.Comment{
+			{Text: "// Config format version, updated by 'start config migrate'."},
+		}}
+		field := &ast.Field{Label: ast.NewIdent(versionField)}
+		ast.AddComment(field, comment)
+		f.Decls = append(f.Decls, field)
+	}
+	if err := internalcue.SetField(f, []string{versionField}, ast.NewLit(token.INT, strconv.Itoa(CurrentVersion))); err != nil {
+		return FileChange{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	after, err := format.Node(f)
+	if err != nil {
+		return FileChange{}, fmt.Errorf("formatting %s: %w", path, err)
+	}
+	return FileChange{Path: path, Before: before, After: after}, nil
+}
+
+// migrateModelIDs rewrites agent models given as {id: "model-id"} to the
+// plain string form. Only id was ever read from the object form, so any
+// other fields in it are dropped.
+func migrateModelIDs(f *ast.File) bool {
+	changed := false
+	eachField(f.Decls, []string{internalcue.KeyAgents, "*", "models", "*"}, func(model *ast.Field) {
+		s, ok := model.Value.(*ast.StructLit)
+		if !ok {
+			return
+		}
+		for _, decl := range s.Elts {
+			field, ok := decl.(*ast.Field)
+			if !ok {
+				continue
+			}
+			if name, _, _ := ast.LabelName(field.Label); name != "id" {
+				continue
+			}
+			if lit, ok := field.Value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				model.Value = lit
+				changed = true
+				return
+			}
+		}
+	})
+	return changed
+}
+
+// eachField calls fn for every field in decls at the label path, where "*"
+// matches any label. Shorthand fields (a: b: c) and repeated declarations of
+// the same struct are all visited.
+func eachField(decls []ast.Decl, path []string, fn func(*ast.Field)) {
+	for _, decl := range decls {
+		field, ok := decl.(*ast.Field)
+		if !ok {
+			continue
+		}
+		name, _, err := ast.LabelName(field.Label)
+		if err != nil || (path[0] != "*" && name != path[0]) {
+			continue
+		}
+		if len(path) == 1 {
+			fn(field)
+			continue
+		}
+		if s, ok := field.Value.(*ast.StructLit); ok {
+			eachField(s.Elts, path[1:], fn)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateModelIDs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	agents := filepath.Join(dir, "agents.cue")
+	writeTestFile(t, agents, `// Agents
+agents: claude: {
+	bin: "claude"
+	models: {
+		// Fast model
+		haiku: {id: "claude-haiku"}
+		sonnet: "claude-sonnet"
+	}
+}
+agents: gemini: models: pro: id: "gemini-pro"
+`)
+	writeTestFile(t, filepath.Join(dir, "roles.cue"), "roles: dev: prompt: \"Developer\"\n")
+
+	plan, err := PlanMigration(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.From != 1 || !plan.Pending() {
+		t.Fatalf("plan = %+v, want pending migration from version 1", plan)
+	}
+	if len(plan.Files) != 2 || plan.Files[0].Path != agents || plan.Files[1].Path != filepath.Join(dir, VersionFile) {
+		t.Fatalf("plan files = %+v, want agents.cue and %s", plan.Files, VersionFile)
+	}
+	if len(plan.Files[0].Applied) != 1 || plan.Files[1].Before != nil {
+		t.Errorf("plan files = %+v", plan.Files)
+	}
+
+	// Planning writes nothing
+	if _, err := os.Stat(filepath.Join(dir, VersionFile)); !os.IsNotExist(err) {
+		t.Fatal("PlanMigration wrote the version file")
+	}
+
+	if err := ApplyMigration(plan); err != nil {
+		t.Fatal(err)
+	}
+	got := readTestFile(t, agents)
+	for _, want := range []string{"// Agents", "// Fast model", `haiku:  "claude-haiku"`, `pro: "gemini-pro"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("agents.cue missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "id:") {
+		t.Errorf("agents.cue still has object models:\n%s", got)
+	}
+	if v, err := DirVersion(dir); err != nil || v != CurrentVersion {
+		t.Errorf("DirVersion() = %d, %v; want %d", v, err, CurrentVersion)
+	}
+
+	// Migrated config has nothing left to do
+	plan, err = PlanMigration(dir)
+	if err != nil || len(plan.Files) != 0 {
+		t.Errorf("second plan = %+v, %v; want empty", plan, err)
+	}
+}
+
+func TestPlanMigration_VersionOnly(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "agents.cue"), "agents: claude: models: sonnet: \"claude-sonnet\"\n")
+
+	plan, err := PlanMigration(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Pending() || len(plan.Files) != 1 || !strings.Contains(string(plan.Files[0].After), "version: 2") {
+		t.Errorf("plan = %+v, want only the version file", plan)
+	}
+
+	// A directory without config has nothing to migrate
+	plan, err = PlanMigration(filepath.Join(dir, "missing"))
+	if err != nil || len(plan.Files) != 0 {
+		t.Errorf("missing dir plan = %+v, %v; want empty", plan, err)
+	}
+}
+
+func TestDirVersion(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if v, err := DirVersion(dir); err != nil || v != 1 {
+		t.Errorf("unmarked DirVersion() = %d, %v; want 1", v, err)
+	}
+	writeTestFile(t, filepath.Join(dir, VersionFile), "version: 7\n")
+	if v, err := DirVersion(dir); err != nil || v != 7 {
+		t.Errorf("DirVersion() = %d, %v; want 7", v, err)
+	}
+	writeTestFile(t, filepath.Join(dir, VersionFile), "version: \"two\"\n")
+	if _, err := DirVersion(dir); err == nil {
+		t.Error("DirVersion() with a string version: expected error")
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -2,8 +2,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 // Scope represents the configuration scope to load.
@@ -12,10 +15,14 @@ type Scope int
 const (
 	// ScopeMerged loads and merges both global and local configs.
 	ScopeMerged Scope = iota
-	// ScopeGlobal loads only global config (~/.config/start/).
+	// ScopeGlobal loads only global config (~/.config/start/) and the shared
+	// layers beneath it.
 	ScopeGlobal
-	// ScopeLocal loads only local config (./.start/).
+	// ScopeLocal loads only local config (.start/ layers up to the git root,
+	// then the personal overlay).
 	ScopeLocal
+	// ScopePersonal loads only the personal overlay (.start/local/).
+	ScopePersonal
 )
 
 // String returns the string representation of the scope.
@@ -25,26 +32,91 @@ func (s Scope) String() string {
 		return "global"
 	case ScopeLocal:
 		return "local"
+	case ScopePersonal:
+		return "personal"
 	default:
 		return "merged"
 	}
 }
 
+// Layer sources, in precedence order from lowest to highest.
+const (
+	SourceSystem     = "system"
+	SourceInclude    = "include"
+	SourceConfigPath = "config-path"
+	SourceGlobal     = "global"
+	SourceLocal      = "local"
+	SourcePersonal   = "personal"
+	// SourceEnv marks settings set by a START_<KEY> environment variable.
+	// It is not a layer: the variables override every layer.
+	SourceEnv = "env"
+	// SourcePolicy marks settings locked by an organisation policy. It is
+	// not a layer either: locked settings override every layer and START_<KEY>.
+	SourcePolicy = "policy"
+)
+
+// Layer is a configuration directory and the source it belongs to.
+type Layer struct {
+	Source string
+	Dir    string
+}
+
 // Paths holds the resolved configuration directory paths.
 type Paths struct {
+	// System are the existing system-wide config directories
+	// ($XDG_CONFIG_DIRS/start, default /etc/xdg/start), lowest priority first.
+	System []string
+	// Policy are the existing policy directories (policy/ in each system
+	// config directory, always including /etc/xdg/start/policy), lowest
+	// priority first. They are not config layers; see LoadPolicy.
+	Policy []string
+	// Include are the existing directories listed in the include_dirs setting
+	// of the system, config-path, and global layers, lowest priority first.
+	Include []string
+	// ConfigPath are the existing directories listed in START_CONFIG_PATH,
+	// lowest priority first. Like PATH, earlier entries in the variable win.
+	ConfigPath []string
 	// Global is the path to the global config directory (~/.config/start/).
 	Global string
-	// Local is the path to the local config directory (./.start/).
+	// Local is the nearest local config directory: the innermost existing
+	// .start directory between the working directory and the git root, or
+	// ./.start when none exists. Local config is written here.
 	Local string
+	// LocalLayers are the existing local config directories, outermost first.
+	// Inner layers take precedence over outer ones.
+	LocalLayers []string
+	// Personal is the personal overlay inside the nearest local config
+	// directory (.start/local/). It loads after every local layer and is
+	// gitignored, for one person's settings in a shared project.
+	Personal string
 	// GlobalExists indicates whether the global config directory exists.
 	GlobalExists bool
-	// LocalExists indicates whether the local config directory exists.
+	// LocalExists indicates whether any local config directory exists.
 	LocalExists bool
+	// PersonalExists indicates whether the personal overlay exists.
+	PersonalExists bool
 }
 
-// Dir returns the config directory for the given scope.
-func (p Paths) Dir(local bool) string {
-	if local {
+// Dir returns the config directory written for the given scope: the
+// nearest local directory, the personal overlay, or global config for the
+// global and merged scopes.
+func (p Paths) Dir(scope Scope) string {
+	switch scope {
+	case ScopeLocal:
+		return p.Local
+	case ScopePersonal:
+		return p.Personal
+	}
+	return p.Global
+}
+
+// SourceDir returns the writable config directory for a layer source:
+// the personal overlay, the nearest local directory, or global config.
+func (p Paths) SourceDir(source string) string {
+	switch source {
+	case SourcePersonal:
+		return p.Personal
+	case SourceLocal:
 		return p.Local
 	}
 	return p.Global
@@ -63,20 +135,86 @@ func ResolvePaths(workingDir string) (Paths, error) {
 	}
 	p.Global = globalPath
 	p.GlobalExists = dirExists(globalPath)
+	p.System = systemConfigDirs()
+	p.Policy = policyDirs()
+	p.ConfigPath = configPathDirs()
+	p.Include = includeDirs(p)
 
-	// Resolve local config path
+	// Resolve local config paths
 	if workingDir == "" {
 		workingDir, err = os.Getwd()
 		if err != nil {
 			return p, err
 		}
 	}
-	p.Local = filepath.Join(workingDir, ".start")
-	p.LocalExists = dirExists(p.Local)
+	workingDir, err = filepath.Abs(workingDir)
+	if err != nil {
+		return p, err
+	}
+	p.LocalLayers = findLocalLayers(workingDir)
+	p.LocalExists = len(p.LocalLayers) > 0
+	if p.LocalExists {
+		p.Local = p.LocalLayers[len(p.LocalLayers)-1]
+	} else {
+		p.Local = filepath.Join(workingDir, localDirName)
+	}
+	p.Personal = filepath.Join(p.Local, personalDirName)
+	p.PersonalExists = dirExists(p.Personal)
 
 	return p, nil
 }
 
+// defaultSystemConfigDir is the XDG_CONFIG_DIRS default.
+const defaultSystemConfigDir = "/etc/xdg"
+
+// localDirName is the name of local config directories.
+const localDirName = ".start"
+
+// personalDirName is the name of the personal overlay inside a local config
+// directory.
+const personalDirName = "local"
+
+// IsPersonalDir reports whether dir is a personal overlay directory.
+func IsPersonalDir(dir string) bool {
+	return filepath.Base(dir) == personalDirName && filepath.Base(filepath.Dir(dir)) == localDirName
+}
+
+// EnsurePersonalGitignore writes a .gitignore that ignores everything in the
+// personal overlay dir, so its config is never committed. An existing
+// .gitignore is left alone.
+func EnsurePersonalGitignore(dir string) error {
+	path := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(path); err == nil || !os.IsNotExist(err) {
+		return err
+	}
+	content := "# Personal start config, not shared with the project\n*\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// findLocalLayers returns the .start directories from dir up to the git root
+// (the first directory containing .git), or the filesystem root outside a
+// repository, ordered outermost first.
+func findLocalLayers(dir string) []string {
+	var layers []string
+	for {
+		if candidate := filepath.Join(dir, localDirName); dirExists(candidate) {
+			layers = append([]string{candidate}, layers...)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return layers
+}
+
 // globalConfigDir returns the global config directory path.
 // Uses XDG_CONFIG_HOME if set, otherwise ~/.config/start/.
 func globalConfigDir() (string, error) {
@@ -91,6 +229,124 @@ func globalConfigDir() (string, error) {
 	return filepath.Join(home, ".config", "start"), nil
 }
 
+// systemConfigDirs returns the existing system-wide config directories,
+// lowest priority first. XDG_CONFIG_DIRS lists directories most important
+// first and defaults to /etc/xdg.
+func systemConfigDirs() []string {
+	xdg := os.Getenv("XDG_CONFIG_DIRS")
+	if xdg == "" {
+		xdg = defaultSystemConfigDir
+	}
+	var dirs []string
+	for _, dir := range filepath.SplitList(xdg) {
+		if dir == "" || !filepath.IsAbs(dir) {
+			continue
+		}
+		if candidate := filepath.Join(dir, "start"); dirExists(candidate) {
+			dirs = append([]string{candidate}, dirs...)
+		}
+	}
+	return dirs
+}
+
+// policyDirs returns the existing policy directories, lowest priority first.
+// The default location is always checked, so clearing XDG_CONFIG_DIRS does
+// not drop the policy.
+func policyDirs() []string {
+	candidates := []string{filepath.Join(defaultSystemConfigDir, "start", policyDirName)}
+	for _, dir := range systemConfigDirs() {
+		candidates = append(candidates, filepath.Join(dir, policyDirName))
+	}
+	var dirs []string
+	for _, dir := range candidates {
+		if dirExists(dir) && !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// configPathDirs returns the existing directories listed in START_CONFIG_PATH,
+// lowest priority first.
+func configPathDirs() []string {
+	return existingDirs(filepath.SplitList(os.Getenv("START_CONFIG_PATH")))
+}
+
+// includeDirs returns the existing directories listed in the include_dirs
+// setting of the system, config-path, and global layers and in START_INCLUDE_DIRS,
+// lowest priority first. Local config cannot include directories. Config that fails to load is
+// skipped here and reported when it is loaded. A policy that locks
+// include_dirs replaces all of these.
+func includeDirs(p Paths) []string {
+	if lock, ok := lockedSetting(p, "include_dirs"); ok {
+		return existingDirs(ParseDirList(lock.Value))
+	}
+
+	var sources []string
+	sources = append(sources, p.System...)
+	sources = append(sources, p.ConfigPath...)
+	if p.GlobalExists {
+		sources = append(sources, p.Global)
+	}
+
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, src := range sources {
+		settings, err := LoadSettingsFromDir(src)
+		if err != nil {
+			continue
+		}
+		for _, dir := range existingDirs(ParseDirList(settings["include_dirs"])) {
+			if !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	for _, dir := range existingDirs(ParseDirList(os.Getenv(SettingEnvVar("include_dirs")))) {
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// ParseDirList splits a list of directories separated by the OS path list
+// separator or newlines, expanding a leading ~.
+func ParseDirList(list string) []string {
+	var dirs []string
+	for _, line := range strings.Split(list, "\n") {
+		for _, dir := range filepath.SplitList(line) {
+			dir = strings.TrimSpace(dir)
+			if dir == "" {
+				continue
+			}
+			if dir == "~" || strings.HasPrefix(dir, "~/") {
+				if home, err := os.UserHomeDir(); err == nil {
+					dir = filepath.Join(home, dir[1:])
+				}
+			}
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// existingDirs returns the absolute paths of the directories that exist,
+// reversed so the first entry has the highest priority.
+func existingDirs(list []string) []string {
+	var dirs []string
+	for _, dir := range list {
+		abs, err := filepath.Abs(dir)
+		if err != nil || !dirExists(abs) {
+			continue
+		}
+		dirs = append([]string{abs}, dirs...)
+	}
+	return dirs
+}
+
 // dirExists checks if a directory exists.
 func dirExists(path string) bool {
 	info, err := os.Stat(path)
@@ -103,31 +359,75 @@ func dirExists(path string) bool {
 // ForScope returns the paths that should be loaded for the given scope.
 // Returns a slice of paths in load order (lowest priority first).
 func (p Paths) ForScope(scope Scope) []string {
-	switch scope {
-	case ScopeGlobal:
-		if p.GlobalExists {
-			return []string{p.Global}
-		}
-		return nil
-	case ScopeLocal:
-		if p.LocalExists {
-			return []string{p.Local}
+	var dirs []string
+	for _, l := range p.Layers(scope) {
+		dirs = append(dirs, l.Dir)
+	}
+	return dirs
+}
+
+// Layers returns the config layers for the given scope in load order
+// (lowest priority first): system, include, env, global, local layers from
+// outermost to innermost, then the personal overlay.
+func (p Paths) Layers(scope Scope) []Layer {
+	var layers []Layer
+	add := func(source string, dirs ...string) {
+		for _, dir := range dirs {
+			layers = append(layers, Layer{Source: source, Dir: dir})
 		}
-		return nil
-	default:
-		// Merged: global first (lower priority), then local (higher priority)
-		var paths []string
+	}
+	if scope == ScopeMerged || scope == ScopeGlobal {
+		add(SourceSystem, p.System...)
+		add(SourceInclude, p.Include...)
+		add(SourceConfigPath, p.ConfigPath...)
 		if p.GlobalExists {
-			paths = append(paths, p.Global)
+			add(SourceGlobal, p.Global)
+		}
+	}
+	if scope != ScopeGlobal {
+		for _, dir := range p.Locals() {
+			switch {
+			case dir == p.Personal:
+				add(SourcePersonal, dir)
+			case scope == ScopePersonal:
+				// Only the personal overlay is in scope
+			default:
+				add(SourceLocal, dir)
+			}
 		}
-		if p.LocalExists {
-			paths = append(paths, p.Local)
+	}
+	return layers
+}
+
+// Shared returns the read-only layers beneath global config, lowest
+// priority first.
+func (p Paths) Shared() []Layer {
+	var shared []Layer
+	for _, l := range p.Layers(ScopeGlobal) {
+		if l.Source != SourceGlobal {
+			shared = append(shared, l)
 		}
-		return paths
 	}
+	return shared
+}
+
+// Locals returns the existing local config directories, outermost first,
+// followed by the personal overlay when it exists. Paths without LocalLayers
+// fall back to Local when it exists.
+func (p Paths) Locals() []string {
+	var dirs []string
+	if len(p.LocalLayers) > 0 {
+		dirs = append(dirs, p.LocalLayers...)
+	} else if p.LocalExists {
+		dirs = append(dirs, p.Local)
+	}
+	if p.PersonalExists {
+		dirs = append(dirs, p.Personal)
+	}
+	return dirs
 }
 
 // AnyExists returns true if any configuration directory exists.
 func (p Paths) AnyExists() bool {
-	return p.GlobalExists || p.LocalExists
+	return p.GlobalExists || p.LocalExists || len(p.Shared()) > 0
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -17,6 +18,7 @@ func TestScope_String(t *testing.T) {
 		{"merged scope", ScopeMerged, "merged"},
 		{"global scope", ScopeGlobal, "global"},
 		{"local scope", ScopeLocal, "local"},
+		{"personal scope", ScopePersonal, "personal"},
 	}
 
 	for _, tt := range tests {
@@ -70,6 +72,124 @@ func TestResolvePaths(t *testing.T) {
 	})
 }
 
+func TestResolvePaths_LocalLayers(t *testing.T) {
+	t.Parallel()
+
+	// outside/.start/  repo/.git  repo/.start/  repo/svc/api/.start/
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	api := filepath.Join(repo, "svc", "api")
+	for _, dir := range []string{
+		filepath.Join(root, ".start"),
+		filepath.Join(repo, ".git"),
+		filepath.Join(repo, ".start"),
+		filepath.Join(api, ".start"),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := ResolvePaths(api)
+	if err != nil {
+		t.Fatalf("ResolvePaths() error = %v", err)
+	}
+	want := []string{filepath.Join(repo, ".start"), filepath.Join(api, ".start")}
+	if len(p.LocalLayers) != len(want) || p.LocalLayers[0] != want[0] || p.LocalLayers[1] != want[1] {
+		t.Errorf("LocalLayers = %v, want %v (stopping at the git root)", p.LocalLayers, want)
+	}
+	if p.Local != want[1] || !p.LocalExists {
+		t.Errorf("Local = %q (exists %t), want nearest layer %q", p.Local, p.LocalExists, want[1])
+	}
+
+	// A directory without its own .start uses the nearest parent layer
+	p, err = ResolvePaths(filepath.Join(repo, "svc"))
+	if err != nil {
+		t.Fatalf("ResolvePaths() error = %v", err)
+	}
+	if p.Local != want[0] || len(p.LocalLayers) != 1 {
+		t.Errorf("Local = %q, LocalLayers = %v, want %q", p.Local, p.LocalLayers, want[0])
+	}
+
+	// Merged scope loads global, then layers outermost first
+	p.Global, p.GlobalExists = "/global", true
+	got := p.ForScope(ScopeMerged)
+	if len(got) != 2 || got[0] != "/global" || got[1] != want[0] {
+		t.Errorf("ForScope(ScopeMerged) = %v", got)
+	}
+}
+
+func TestResolvePaths_SharedLayers(t *testing.T) {
+	root := t.TempDir()
+	mkdir := func(dir string) string {
+		t.Helper()
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	sysHigh := mkdir(filepath.Join(root, "etc1", "start"))
+	sysLow := mkdir(filepath.Join(root, "etc2", "start"))
+	envFirst := mkdir(filepath.Join(root, "env1"))
+	envSecond := mkdir(filepath.Join(root, "env2"))
+	team := mkdir(filepath.Join(root, "team"))
+	global := mkdir(filepath.Join(root, "config", "start"))
+	if err := os.WriteFile(filepath.Join(global, "settings.cue"),
+		[]byte(`settings: include_dirs: "`+team+`:`+filepath.Join(root, "missing")+`"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	t.Setenv("XDG_CONFIG_DIRS", filepath.Join(root, "etc1")+":"+filepath.Join(root, "etc2"))
+	t.Setenv("START_CONFIG_PATH", envFirst+":"+envSecond)
+
+	p, err := ResolvePaths(mkdir(filepath.Join(root, "work")))
+	if err != nil {
+		t.Fatalf("ResolvePaths() error = %v", err)
+	}
+
+	want := []Layer{
+		{SourceSystem, sysLow},
+		{SourceSystem, sysHigh},
+		{SourceInclude, team},
+		{SourceConfigPath, envSecond},
+		{SourceConfigPath, envFirst},
+		{SourceGlobal, global},
+	}
+	got := p.Layers(ScopeMerged)
+	if len(got) != len(want) {
+		t.Fatalf("Layers(ScopeMerged) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Layers(ScopeMerged)[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if len(p.Layers(ScopeLocal)) != 0 {
+		t.Errorf("Layers(ScopeLocal) = %v, want none", p.Layers(ScopeLocal))
+	}
+	if len(p.Shared()) != 5 {
+		t.Errorf("Shared() = %v, want 5 layers", p.Shared())
+	}
+}
+
+func TestParseDirList(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	got := ParseDirList("/a:~/b\n /c \n\n")
+	want := []string{"/a", filepath.Join(home, "b"), "/c"}
+	if len(got) != len(want) {
+		t.Fatalf("ParseDirList() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ParseDirList()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
 func TestResolvePaths_XDGConfigHome(t *testing.T) {
 	// Create a temporary XDG config directory
 	xdgDir := t.TempDir()
@@ -207,29 +327,69 @@ func TestPaths_ForScope(t *testing.T) {
 func TestPaths_Dir(t *testing.T) {
 	t.Parallel()
 	p := Paths{
-		Global: "/home/user/.config/start",
-		Local:  "/project/.start",
+		Global:   "/home/user/.config/start",
+		Local:    "/project/.start",
+		Personal: "/project/.start/local",
 	}
 
 	tests := []struct {
 		name  string
-		local bool
+		scope Scope
 		want  string
 	}{
-		{"global scope", false, "/home/user/.config/start"},
-		{"local scope", true, "/project/.start"},
+		{"merged scope", ScopeMerged, "/home/user/.config/start"},
+		{"global scope", ScopeGlobal, "/home/user/.config/start"},
+		{"local scope", ScopeLocal, "/project/.start"},
+		{"personal scope", ScopePersonal, "/project/.start/local"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := p.Dir(tt.local)
+			got := p.Dir(tt.scope)
 			if got != tt.want {
-				t.Errorf("Dir(%v) = %q, want %q", tt.local, got, tt.want)
+				t.Errorf("Dir(%v) = %q, want %q", tt.scope, got, tt.want)
 			}
 		})
 	}
 }
 
+func TestResolvePaths_Personal(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	personal := filepath.Join(repo, ".start", "local")
+	for _, dir := range []string{filepath.Join(repo, ".git"), personal} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := ResolvePaths(repo)
+	if err != nil {
+		t.Fatalf("ResolvePaths() error = %v", err)
+	}
+	if p.Personal != personal || !p.PersonalExists {
+		t.Errorf("Personal = %q (exists %t), want %q", p.Personal, p.PersonalExists, personal)
+	}
+	// The overlay loads after the project layer, with its own source
+	want := []Layer{{SourceLocal, filepath.Join(repo, ".start")}, {SourcePersonal, personal}}
+	if got := p.Layers(ScopeLocal); !slices.Equal(got, want) {
+		t.Errorf("Layers(ScopeLocal) = %v, want %v", got, want)
+	}
+	if p.Dir(ScopeLocal) != filepath.Join(repo, ".start") || p.SourceDir(SourcePersonal) != personal {
+		t.Errorf("Dir(ScopeLocal) = %q, SourceDir(personal) = %q", p.Dir(ScopeLocal), p.SourceDir(SourcePersonal))
+	}
+	if p.Dir(ScopePersonal) != personal {
+		t.Errorf("Dir(ScopePersonal) = %q, want personal overlay", p.Dir(ScopePersonal))
+	}
+	if got := p.Layers(ScopePersonal); !slices.Equal(got, want[1:]) {
+		t.Errorf("Layers(ScopePersonal) = %v, want only the overlay", got)
+	}
+	if got := p.Layers(ScopeMerged); !slices.Equal(got[len(got)-2:], want) {
+		t.Errorf("Layers(ScopeMerged) = %v, want project layer then overlay", got)
+	}
+}
+
 func TestPaths_AnyExists(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cuelang.org/go/cue"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+)
+
+// policyDirName is the name of the policy directory inside a system config
+// directory.
+const policyDirName = "policy"
+
+// Policy is the organisation policy read from the policy directories. Its
+// locked settings override every config layer and START_<KEY> variable, and
+// its deny rules refuse matching agent commands at launch.
+//
+// A policy directory holds .cue files such as:
+//
+//	locked: {
+//		secret_policy: "block"
+//		assets_index:  "github.com/acme/start-assets/index@v0"
+//	}
+//	deny: [{
+//		pattern: "--permission-mode[= ]+bypass"
+//		reason:  "Permission prompts must stay on"
+//	}]
+type Policy struct {
+	Dirs   []string        // policy directories that were read, lowest priority first
+	Locked map[string]Lock // locked settings by key
+	Deny   []DenyRule
+}
+
+// Lock is a setting value fixed by a policy.
+type Lock struct {
+	Value string
+	Dir   string // policy directory that locks the setting
+}
+
+// DenyRule refuses agent commands that match Pattern.
+type DenyRule struct {
+	Pattern string
+	Reason  string // optional explanation shown when a command is refused
+	Dir     string // policy directory that defines the rule
+
+	re *regexp.Regexp
+}
+
+// PolicyViolation is a config layer or START_<KEY> variable that sets a
+// locked setting to another value. The locked value is used instead.
+type PolicyViolation struct {
+	Key    string
+	Value  string // value the layer or variable sets
+	Source string // layer source, or SourceEnv
+	Dir    string // layer directory; empty for SourceEnv
+	Lock   Lock
+}
+
+// String describes the violation.
+func (v PolicyViolation) String() string {
+	from := fmt.Sprintf("%s config %s", v.Source, v.Dir)
+	if v.Source == SourceEnv {
+		from = SettingEnvVar(v.Key)
+	}
+	return fmt.Sprintf("settings.%s is locked to %q by policy %s; ignoring %q from %s",
+		v.Key, v.Lock.Value, v.Lock.Dir, v.Value, from)
+}
+
+// PolicyDeniedError is returned when an agent command matches a deny rule.
+type PolicyDeniedError struct {
+	Rule DenyRule
+}
+
+func (e *PolicyDeniedError) Error() string {
+	msg := fmt.Sprintf("agent command denied by policy: matches %q", e.Rule.Pattern)
+	if e.Rule.Reason != "" {
+		msg += "\n\n  " + e.Rule.Reason
+	}
+	return msg + "\n\nPolicy: " + e.Rule.Dir
+}
+
+// Empty reports whether the policy locks nothing and denies nothing.
+func (p Policy) Empty() bool {
+	return len(p.Locked) == 0 && len(p.Deny) == 0
+}
+
+// CheckCommand returns a *PolicyDeniedError for the first deny rule that
+// matches cmd.
+func (p Policy) CheckCommand(cmd string) error {
+	for _, rule := range p.Deny {
+		if rule.re.MatchString(cmd) {
+			return &PolicyDeniedError{Rule: rule}
+		}
+	}
+	return nil
+}
+
+// LoadPolicy reads the policy directories in paths. Locks in higher-priority
+// directories win; deny rules from every directory apply. A policy that cannot
+// be read is an error, so a broken policy is never silently skipped.
+func LoadPolicy(paths Paths) (Policy, error) {
+	policy := Policy{Locked: make(map[string]Lock)}
+	for _, dir := range paths.Policy {
+		loader := internalcue.NewLoader()
+		result, err := loader.Load([]string{dir})
+		if errors.Is(err, internalcue.ErrNoCUEFiles) {
+			continue
+		}
+		if err != nil {
+			return Policy{}, fmt.Errorf("loading policy %s: %w", dir, err)
+		}
+		if err := policy.add(dir, result.Value); err != nil {
+			return Policy{}, fmt.Errorf("policy %s: %w", dir, err)
+		}
+		policy.Dirs = append(policy.Dirs, dir)
+	}
+	return policy, nil
+}
+
+// add merges the policy in one directory.
+func (p *Policy) add(dir string, v cue.Value) error {
+	iter, err := v.Fields()
+	if err != nil {
+		return err
+	}
+	for iter.Next() {
+		switch name := iter.Selector().Unquoted(); name {
+		case "locked":
+			if err := p.addLocked(dir, iter.Value()); err != nil {
+				return err
+			}
+		case "deny":
+			if err := p.addDeny(dir, iter.Value()); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown field %q (a policy has locked and deny)", name)
+		}
+	}
+	return nil
+}
+
+// addLocked reads the locked settings struct.
+func (p *Policy) addLocked(dir string, v cue.Value) error {
+	iter, err := v.Fields(cue.Concrete(true))
+	if err != nil {
+		return fmt.Errorf("locked: %w", err)
+	}
+	for iter.Next() {
+		key := iter.Selector().Unquoted()
+		value, err := SettingString(iter.Value())
+		if err != nil {
+			return fmt.Errorf("locked.%s: %w", key, err)
+		}
+		if err := ValidateSetting(key, value); err != nil {
+			return fmt.Errorf("locked: %w", err)
+		}
+		p.Locked[key] = Lock{Value: value, Dir: dir}
+	}
+	return nil
+}
+
+// addDeny reads the deny rule list.
+func (p *Policy) addDeny(dir string, v cue.Value) error {
+	iter, err := v.List()
+	if err != nil {
+		return fmt.Errorf("deny must be a list of rules: %w", err)
+	}
+	for i := 0; iter.Next(); i++ {
+		rule := DenyRule{Dir: dir}
+		if rule.Pattern, err = iter.Value().LookupPath(cue.ParsePath("pattern")).String(); err != nil {
+			return fmt.Errorf("deny[%d]: pattern must be a string", i)
+		}
+		if reason := iter.Value().LookupPath(cue.ParsePath("reason")); reason.Exists() {
+			if rule.Reason, err = reason.String(); err != nil {
+				return fmt.Errorf("deny[%d]: reason must be a string", i)
+			}
+		}
+		if rule.re, err = regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("deny[%d]: invalid pattern: %w", i, err)
+		}
+		p.Deny = append(p.Deny, rule)
+	}
+	return nil
+}
+
+// ApplyPolicy returns the loaded config with the locked settings of the
+// policy in paths applied. Locked settings take precedence over every config
+// layer and START_<KEY> environment variables.
+func ApplyPolicy(loader *internalcue.Loader, v cue.Value, paths Paths) (cue.Value, error) {
+	policy, err := LoadPolicy(paths)
+	if err != nil {
+		return cue.Value{}, err
+	}
+	overrides := make(map[string]any, len(policy.Locked))
+	for key, lock := range policy.Locked {
+		overrides[key] = SettingCUEValue(key, lock.Value)
+	}
+	return loader.OverrideSettings(v, overrides)
+}
+
+// PolicyViolations returns every merged config layer and START_<KEY>
+// variable that sets a locked setting to a different value, in precedence
+// order.
+func PolicyViolations(paths Paths, policy Policy) ([]PolicyViolation, error) {
+	if len(policy.Locked) == 0 {
+		return nil, nil
+	}
+	var violations []PolicyViolation
+	check := func(settings map[string]string, source, dir string) {
+		for _, key := range SettingKeys() {
+			lock, locked := policy.Locked[key]
+			value, set := settings[key]
+			if locked && set && !sameSetting(key, value, lock.Value) {
+				violations = append(violations, PolicyViolation{Key: key, Value: value, Source: source, Dir: dir, Lock: lock})
+			}
+		}
+	}
+	for _, layer := range paths.Layers(ScopeMerged) {
+		settings, err := LoadSettingsFromDir(layer.Dir)
+		if err != nil {
+			return nil, err
+		}
+		check(settings, layer.Source, layer.Dir)
+	}
+	env, err := EnvSettings()
+	if err != nil {
+		return nil, err
+	}
+	check(env, SourceEnv, "")
+	return violations, nil
+}
+
+// sameSetting reports whether two values of a setting are equal. Enum values
+// compare without case, as ValidateSetting accepts them.
+func sameSetting(key, a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if SettingsRegistry[key].Type == TypeEnum {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// lockedSetting returns the lock on key in the policy in paths, if any.
+// A policy that cannot be read locks nothing here; callers that launch
+// agents load it with LoadPolicy and fail instead.
+func lockedSetting(paths Paths, key string) (Lock, bool) {
+	policy, err := LoadPolicy(paths)
+	if err != nil {
+		return Lock{}, false
+	}
+	lock, ok := policy.Locked[key]
+	return lock, ok
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+)
+
+// writePolicy writes policy.cue into a new policy directory.
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "policy")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "policy.cue"), content)
+	return dir
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Parallel()
+	low := writePolicy(t, `locked: {secret_policy: "redact", timeout: 90}
+deny: [{pattern: "--permission-mode[= ]+bypass", reason: "Permission prompts must stay on"}]
+`)
+	high := writePolicy(t, `locked: secret_policy: "block"
+deny: [{pattern: "--yolo"}]
+`)
+
+	policy, err := LoadPolicy(Paths{Policy: []string{low, high}})
+	if err != nil {
+		t.Fatalf("LoadPolicy: %v", err)
+	}
+	if got := policy.Locked["secret_policy"]; got.Value != "block" || got.Dir != high {
+		t.Errorf("secret_policy lock = %+v, want block from the higher-priority dir", got)
+	}
+	if got := policy.Locked["timeout"].Value; got != "90" {
+		t.Errorf("timeout lock = %q, want 90", got)
+	}
+	if len(policy.Deny) != 2 {
+		t.Fatalf("deny rules = %d, want 2", len(policy.Deny))
+	}
+
+	var denied *PolicyDeniedError
+	if err := policy.CheckCommand("claude --permission-mode bypass"); !errors.As(err, &denied) || denied.Rule.Dir != low {
+		t.Errorf("CheckCommand() = %v, want denied by %s", err, low)
+	} else if !strings.Contains(err.Error(), "Permission prompts must stay on") {
+		t.Errorf("error %q does not include the reason", err)
+	}
+	if err := policy.CheckCommand("claude --permission-mode plan"); err != nil {
+		t.Errorf("CheckCommand() = %v, want nil", err)
+	}
+}
+
+func TestLoadPolicy_Rejects(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown field", `lock: timeout: 90`, `unknown field "lock"`},
+		{"unknown setting", `locked: colour: "red"`, `unknown setting "colour"`},
+		{"invalid value", `locked: secret_policy: "off"`, "must be one of"},
+		{"invalid pattern", `deny: [{pattern: "("}]`, "invalid pattern"},
+		{"missing pattern", `deny: [{reason: "no"}]`, "pattern must be a string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := writePolicy(t, tt.content)
+			_, err := LoadPolicy(Paths{Policy: []string{dir}})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyPolicy(t *testing.T) {
+	t.Setenv("START_SECRET_POLICY", "warn")
+	global := t.TempDir()
+	writeTestFile(t, filepath.Join(global, "settings.cue"), "settings: {secret_policy: \"redact\", timeout: 30}\n")
+	paths := Paths{
+		Global:       global,
+		GlobalExists: true,
+		Policy:       []string{writePolicy(t, `locked: {secret_policy: "block", strict: true}`)},
+	}
+
+	loader := internalcue.NewLoader()
+	result, err := loader.Load([]string{global})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := ApplyEnvSettings(loader, result.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err = ApplyPolicy(loader, v, paths)
+	if err != nil {
+		t.Fatalf("ApplyPolicy: %v", err)
+	}
+	if got, _ := v.LookupPath(cue.ParsePath("settings.secret_policy")).String(); got != "block" {
+		t.Errorf("secret_policy = %q, want block", got)
+	}
+	if got, _ := v.LookupPath(cue.ParsePath("settings.strict")).Bool(); !got {
+		t.Error("strict = false, want the locked true")
+	}
+	if got, _ := v.LookupPath(cue.ParsePath("settings.timeout")).Int64(); got != 30 {
+		t.Errorf("timeout = %d, want 30 from global config", got)
+	}
+
+	policy, err := LoadPolicy(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations, err := PolicyViolations(paths, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 2 || violations[0].Source != SourceGlobal || violations[1].Source != SourceEnv {
+		t.Fatalf("violations = %+v, want global then env", violations)
+	}
+	want := `settings.secret_policy is locked to "block" by policy ` + paths.Policy[0] + `; ignoring "warn" from START_SECRET_POLICY`
+	if got := violations[1].String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	entries, err := ResolveAllSettings(paths, ScopeMerged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := entries["secret_policy"]; got.Value != "block" || got.Source != SourcePolicy {
+		t.Errorf("resolved secret_policy = %+v, want block from policy", got)
+	}
+}
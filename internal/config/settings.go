@@ -3,65 +3,274 @@ package config
 import (
 	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"cuelang.org/go/cue"
 	internalcue "github.com/grantcarthew/start/internal/cue"
 	"github.com/grantcarthew/start/internal/registry"
+	"github.com/grantcarthew/start/internal/secrets"
 	"github.com/grantcarthew/start/internal/shell"
+	"github.com/grantcarthew/start/internal/temp"
 )
 
-// SettingInfo describes a valid settings key with its type.
+// SettingType is the type of a setting value.
+type SettingType string
+
+// Setting types. Int and bool settings are stored as CUE numbers and bools,
+// list settings as CUE lists of strings, and the others as strings.
+const (
+	TypeString   SettingType = "string"
+	TypeInt      SettingType = "int"
+	TypeBool     SettingType = "bool"
+	TypeDuration SettingType = "duration" // Go duration such as "30s" or "168h"
+	TypeEnum     SettingType = "enum"     // one of SettingInfo.Values
+	TypeList     SettingType = "list"     // list of strings, one entry per line as a string value
+	TypePath     SettingType = "path"     // file or directory path
+)
+
+// SettingInfo describes a valid settings key.
 type SettingInfo struct {
-	Type string // "string" or "int"
+	Type        SettingType
+	Description string
+	Values      []string           // allowed values for enum settings
+	Default     func() string      // nil if the setting has no default
+	Check       func(string) error // extra validation after the type check
 }
 
 // SettingEntry holds a resolved setting value and its source.
 type SettingEntry struct {
 	Value  string `json:"value"`
-	Source string `json:"source"` // "default", "global", "local", or "not set"
+	Source string `json:"source"` // "default", a layer source such as "global" or "local", "env", "policy", or "not set"
 }
 
-// SettingsRegistry defines all valid settings keys and their types.
+// SettingsRegistry defines all valid settings keys.
 var SettingsRegistry = map[string]SettingInfo{
-	"assets_index":  {Type: "string"},
-	"default_agent": {Type: "string"},
-	"shell":         {Type: "string"},
-	"timeout":       {Type: "int"},
+	"assets_index": {
+		Type:        TypeString,
+		Description: "CUE module path for the assets index",
+		Default:     func() string { return registry.IndexModulePath },
+	},
+	"default_agent": {
+		Type:        TypeString,
+		Description: "Agent to use when --agent is not specified",
+	},
+	"include_dirs": {
+		Type:        TypeList,
+		Description: "Shared config directories loaded beneath global config",
+	},
+	"secret_patterns": {
+		Type:        TypeList,
+		Description: "Extra regular expressions that detect secrets",
+		Check: func(v string) error {
+			_, err := secrets.NewScanner(secrets.ParsePatterns(v))
+			return err
+		},
+	},
+	"secret_policy": {
+		Type:        TypeEnum,
+		Description: "Action when secrets are detected before launch",
+		Values:      []string{string(secrets.PolicyWarn), string(secrets.PolicyRedact), string(secrets.PolicyBlock)},
+		Default:     func() string { return string(secrets.DefaultPolicy) },
+	},
+	"session_max_age": {
+		Type:        TypeDuration,
+		Description: "Age after which session directories are garbage collected",
+		Default:     func() string { return strings.TrimSuffix(temp.DefaultSessionMaxAge.String(), "0m0s") },
+		Check: func(v string) error {
+			if d, _ := time.ParseDuration(v); d <= 0 {
+				return errors.New("must be greater than zero")
+			}
+			return nil
+		},
+	},
+	"shell": {
+		Type:        TypePath,
+		Description: "Shell for command execution",
+		Default: func() string {
+			if detected, err := shell.DetectShell(); err == nil {
+				return strings.TrimSuffix(detected, " -c")
+			}
+			return ""
+		},
+	},
+	"strict": {
+		Type:        TypeBool,
+		Description: "Fail instead of warning when config does not match the asset schemas",
+		Default:     func() string { return "false" },
+	},
+	"temp_dir": {
+		Type:        TypePath,
+		Description: "Base directory for session files",
+		Default:     func() string { return temp.SessionBaseDir("") },
+	},
+	"timeout": {
+		Type:        TypeInt,
+		Description: "Command timeout in seconds",
+		Default:     func() string { return strconv.Itoa(shell.DefaultTimeout) },
+		Check: func(v string) error {
+			if n, _ := strconv.Atoi(v); n <= 0 {
+				return errors.New("must be greater than zero")
+			}
+			return nil
+		},
+	},
 }
 
-// ValidSettingsKeysString returns a sorted, comma-separated list of valid setting keys.
-func ValidSettingsKeysString() string {
+// SettingKeys returns the valid setting keys in sorted order.
+func SettingKeys() []string {
 	keys := make([]string, 0, len(SettingsRegistry))
 	for k := range SettingsRegistry {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
-	return strings.Join(keys, ", ")
+	return keys
+}
+
+// ValidSettingsKeysString returns a sorted, comma-separated list of valid setting keys.
+func ValidSettingsKeysString() string {
+	return strings.Join(SettingKeys(), ", ")
 }
 
 // SettingDefault returns the default value for a setting key.
 // Returns empty string if the key has no default.
 func SettingDefault(key string) string {
-	switch key {
-	case "assets_index":
-		return registry.IndexModulePath
-	case "shell":
-		if detected, err := shell.DetectShell(); err == nil {
-			return strings.TrimSuffix(detected, " -c")
-		}
-		return ""
-	case "timeout":
-		return strconv.Itoa(shell.DefaultTimeout)
+	if info, ok := SettingsRegistry[key]; ok && info.Default != nil {
+		return info.Default()
+	}
+	return ""
+}
+
+// ValidateSetting checks that value is valid for the setting key: the key is
+// known, the value parses as the setting's type, and any per-key check passes.
+func ValidateSetting(key, value string) error {
+	info, ok := SettingsRegistry[key]
+	if !ok {
+		return fmt.Errorf("unknown setting %q\n\nValid settings: %s", key, ValidSettingsKeysString())
+	}
+	switch info.Type {
+	case TypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("setting %q requires an integer value", key)
+		}
+	case TypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("setting %q requires true or false", key)
+		}
+	case TypeDuration:
+		if _, err := time.ParseDuration(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("setting %q requires a duration such as 30m or 168h", key)
+		}
+	case TypeEnum:
+		if !slices.ContainsFunc(info.Values, func(v string) bool { return strings.EqualFold(v, strings.TrimSpace(value)) }) {
+			return fmt.Errorf("setting %q must be one of: %s", key, strings.Join(info.Values, ", "))
+		}
+	case TypePath:
+		if strings.TrimSpace(value) == "" || strings.Contains(value, "\n") {
+			return fmt.Errorf("setting %q requires a single path", key)
+		}
+	}
+	if info.Check != nil {
+		if err := info.Check(value); err != nil {
+			return fmt.Errorf("setting %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// SettingCUEValue converts a setting value to the Go value stored in CUE:
+// an int for int settings, a bool for bool settings, a []string for list
+// settings, otherwise the string. Values that do not parse as their type are
+// returned unchanged.
+func SettingCUEValue(key, value string) any {
+	switch SettingsRegistry[key].Type {
+	case TypeList:
+		return SplitSettingList(value)
+	case TypeInt:
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	case TypeBool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// SplitSettingList splits a list setting value into its entries, one per
+// line. Blank lines are dropped and entries are trimmed.
+func SplitSettingList(value string) []string {
+	entries := []string{}
+	for _, line := range strings.Split(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries
+}
+
+// SettingValues returns the suggested values for a setting: the allowed
+// values of an enum, or true and false for a bool. Returns nil otherwise.
+func SettingValues(key string) []string {
+	info := SettingsRegistry[key]
+	switch info.Type {
+	case TypeEnum:
+		return info.Values
+	case TypeBool:
+		return []string{"true", "false"}
 	default:
-		return ""
+		return nil
+	}
+}
+
+// SettingEnvVar returns the environment variable that overrides a setting,
+// for example START_DEFAULT_AGENT for default_agent.
+func SettingEnvVar(key string) string {
+	return "START_" + strings.ToUpper(key)
+}
+
+// EnvSettings returns the settings set by START_<KEY> environment variables.
+// Empty variables are ignored; other values must be valid for the setting.
+func EnvSettings() (map[string]string, error) {
+	settings := make(map[string]string)
+	for key := range SettingsRegistry {
+		value := os.Getenv(SettingEnvVar(key))
+		if value == "" {
+			continue
+		}
+		if err := ValidateSetting(key, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", SettingEnvVar(key), err)
+		}
+		settings[key] = value
+	}
+	return settings, nil
+}
+
+// ApplyEnvSettings returns the loaded config with START_<KEY> environment
+// overrides applied to its settings. Environment variables take precedence
+// over every config layer.
+func ApplyEnvSettings(loader *internalcue.Loader, v cue.Value) (cue.Value, error) {
+	env, err := EnvSettings()
+	if err != nil {
+		return cue.Value{}, err
 	}
+	overrides := make(map[string]any, len(env))
+	for key, value := range env {
+		overrides[key] = SettingCUEValue(key, value)
+	}
+	return loader.OverrideSettings(v, overrides)
 }
 
-// ResolveAllSettings resolves all valid settings with their values and sources.
-func ResolveAllSettings(paths Paths, localOnly bool) (map[string]SettingEntry, error) {
+// ResolveAllSettings resolves all valid settings with their values and sources
+// in the given scope. START_<KEY> environment variables and policy locks
+// override the merged scope; they are not part of the local and personal
+// views.
+func ResolveAllSettings(paths Paths, scope Scope) (map[string]SettingEntry, error) {
 	entries := make(map[string]SettingEntry, len(SettingsRegistry))
 
 	// Start with defaults
@@ -73,34 +282,31 @@ func ResolveAllSettings(paths Paths, localOnly bool) (map[string]SettingEntry, e
 		}
 	}
 
-	if localOnly {
-		if paths.LocalExists {
-			localSettings, err := LoadSettingsFromDir(paths.Local)
-			if err != nil {
-				return nil, err
-			}
-			for k, v := range localSettings {
-				entries[k] = SettingEntry{Value: v, Source: "local"}
-			}
+	for _, layer := range paths.Layers(scope) {
+		settings, err := LoadSettingsFromDir(layer.Dir)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		if paths.GlobalExists {
-			globalSettings, err := LoadSettingsFromDir(paths.Global)
-			if err != nil {
-				return nil, err
-			}
-			for k, v := range globalSettings {
-				entries[k] = SettingEntry{Value: v, Source: "global"}
-			}
+		for k, v := range settings {
+			entries[k] = SettingEntry{Value: v, Source: layer.Source}
 		}
-		if paths.LocalExists {
-			localSettings, err := LoadSettingsFromDir(paths.Local)
-			if err != nil {
-				return nil, err
-			}
-			for k, v := range localSettings {
-				entries[k] = SettingEntry{Value: v, Source: "local"}
-			}
+	}
+
+	if scope == ScopeMerged {
+		env, err := EnvSettings()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range env {
+			entries[k] = SettingEntry{Value: v, Source: SourceEnv}
+		}
+
+		policy, err := LoadPolicy(paths)
+		if err != nil {
+			return nil, err
+		}
+		for k, lock := range policy.Locked {
+			entries[k] = SettingEntry{Value: lock.Value, Source: SourcePolicy}
 		}
 	}
 
@@ -134,18 +340,59 @@ func LoadSettingsFromDir(dir string) (map[string]string, error) {
 
 	for iter.Next() {
 		key := iter.Selector().Unquoted()
+		value, err := SettingString(iter.Value())
+		if errors.Is(err, errSettingKind) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("settings.%s: %w", key, err)
+		}
+		settings[key] = value
+	}
 
-		switch iter.Value().Kind() {
-		case cue.StringKind:
-			if str, err := iter.Value().String(); err == nil {
-				settings[key] = str
+	return settings, nil
+}
+
+// errSettingKind is returned by SettingString for values that cannot hold a
+// setting, such as structs.
+var errSettingKind = errors.New("must be a string, number, bool, or list of strings")
+
+// SettingString returns a string, int, bool, or list setting value as a
+// string. List entries are joined one per line; each entry must be a
+// non-empty string on a single line.
+func SettingString(v cue.Value) (string, error) {
+	switch v.Kind() {
+	case cue.ListKind:
+		iter, err := v.List()
+		if err != nil {
+			return "", err
+		}
+		var entries []string
+		for i := 0; iter.Next(); i++ {
+			entry, err := iter.Value().String()
+			if err != nil {
+				return "", fmt.Errorf("entry %d must be a string", i)
 			}
-		case cue.IntKind:
-			if i, err := iter.Value().Int64(); err == nil {
-				settings[key] = strconv.FormatInt(i, 10)
+			if strings.TrimSpace(entry) == "" || strings.Contains(entry, "\n") {
+				return "", fmt.Errorf("entry %d must be a non-empty single line", i)
 			}
+			entries = append(entries, strings.TrimSpace(entry))
 		}
+		return strings.Join(entries, "\n"), nil
+	case cue.StringKind:
+		return v.String()
+	case cue.IntKind:
+		i, err := v.Int64()
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(i, 10), nil
+	case cue.BoolKind:
+		b, err := v.Bool()
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(b), nil
 	}
-
-	return settings, nil
+	return "", errSettingKind
 }
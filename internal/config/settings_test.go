@@ -3,10 +3,13 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
 
+	"cuelang.org/go/cue"
+	internalcue "github.com/grantcarthew/start/internal/cue"
 	"github.com/grantcarthew/start/internal/registry"
 	"github.com/grantcarthew/start/internal/shell"
 )
@@ -64,6 +67,98 @@ func TestValidSettingsKeysString(t *testing.T) {
 	}
 }
 
+func TestSettingsRegistry_Complete(t *testing.T) {
+	t.Parallel()
+
+	for key, info := range SettingsRegistry {
+		if info.Description == "" {
+			t.Errorf("%s has no description", key)
+		}
+		if info.Type == TypeEnum && len(info.Values) == 0 {
+			t.Errorf("%s is an enum with no values", key)
+		}
+		if def := SettingDefault(key); def != "" {
+			if err := ValidateSetting(key, def); err != nil {
+				t.Errorf("%s default %q is invalid: %v", key, def, err)
+			}
+		}
+	}
+}
+
+func TestValidateSetting(t *testing.T) {
+	// Registers settings of every type for the test
+	testSettings := map[string]SettingInfo{
+		"test_bool": {Type: TypeBool},
+		"test_enum": {Type: TypeEnum, Values: []string{"on", "off"}},
+		"test_path": {Type: TypePath},
+	}
+	for key, info := range testSettings {
+		SettingsRegistry[key] = info
+	}
+	t.Cleanup(func() {
+		for key := range testSettings {
+			delete(SettingsRegistry, key)
+		}
+	})
+
+	tests := []struct {
+		key     string
+		value   string
+		wantErr string
+	}{
+		{"timeout", "120", ""},
+		{"timeout", "abc", "requires an integer value"},
+		{"timeout", "0", "must be greater than zero"},
+		{"session_max_age", "24h", ""},
+		{"session_max_age", "7 days", "requires a duration"},
+		{"session_max_age", "-1h", "must be greater than zero"},
+		{"secret_policy", "redact", ""},
+		{"secret_policy", "Block", ""},
+		{"secret_policy", "ignore", "must be one of: warn, redact, block"},
+		{"secret_patterns", "ghp_[a-z]+\nsk-[a-z]+", ""},
+		{"secret_patterns", "([", "secret_patterns"},
+		{"default_agent", "claude", ""},
+		{"nope", "x", `unknown setting "nope"`},
+		{"test_bool", "true", ""},
+		{"test_bool", "yes", "requires true or false"},
+		{"test_enum", "off", ""},
+		{"test_enum", "auto", "must be one of: on, off"},
+		{"test_path", "~/bin/zsh", ""},
+		{"test_path", " ", "requires a single path"},
+	}
+	for _, tt := range tests {
+		err := ValidateSetting(tt.key, tt.value)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateSetting(%q, %q) error = %v", tt.key, tt.value, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateSetting(%q, %q) error = %v, want containing %q", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+
+	if got := SettingCUEValue("test_bool", "true"); got != true {
+		t.Errorf("SettingCUEValue(test_bool) = %#v, want true", got)
+	}
+	if got := SettingCUEValue("timeout", "30"); got != 30 {
+		t.Errorf("SettingCUEValue(timeout) = %#v, want 30", got)
+	}
+	if got := SettingCUEValue("session_max_age", "5m"); got != "5m" {
+		t.Errorf("SettingCUEValue(session_max_age) = %#v, want \"5m\"", got)
+	}
+	if got := SettingDefault("session_max_age"); got != "168h" {
+		t.Errorf("SettingDefault(session_max_age) = %q, want \"168h\"", got)
+	}
+	if got := SettingValues("test_bool"); len(got) != 2 {
+		t.Errorf("SettingValues(test_bool) = %v, want true and false", got)
+	}
+	if got := SettingValues("secret_policy"); len(got) != 3 {
+		t.Errorf("SettingValues(secret_policy) = %v, want the policies", got)
+	}
+}
+
 func TestResolveAllSettings_DefaultsOnly(t *testing.T) {
 	t.Parallel()
 
@@ -74,7 +169,7 @@ func TestResolveAllSettings_DefaultsOnly(t *testing.T) {
 		LocalExists:  false,
 	}
 
-	entries, err := ResolveAllSettings(paths, false)
+	entries, err := ResolveAllSettings(paths, ScopeMerged)
 	if err != nil {
 		t.Fatalf("ResolveAllSettings() error = %v", err)
 	}
@@ -118,7 +213,7 @@ func TestResolveAllSettings_GlobalOverride(t *testing.T) {
 		LocalExists:  false,
 	}
 
-	entries, err := ResolveAllSettings(paths, false)
+	entries, err := ResolveAllSettings(paths, ScopeMerged)
 	if err != nil {
 		t.Fatalf("ResolveAllSettings() error = %v", err)
 	}
@@ -159,7 +254,7 @@ func TestResolveAllSettings_LocalOverridesGlobal(t *testing.T) {
 		LocalExists:  true,
 	}
 
-	entries, err := ResolveAllSettings(paths, false)
+	entries, err := ResolveAllSettings(paths, ScopeMerged)
 	if err != nil {
 		t.Fatalf("ResolveAllSettings() error = %v", err)
 	}
@@ -200,7 +295,7 @@ func TestResolveAllSettings_LocalOnly(t *testing.T) {
 		LocalExists:  true,
 	}
 
-	entries, err := ResolveAllSettings(paths, true)
+	entries, err := ResolveAllSettings(paths, ScopeLocal)
 	if err != nil {
 		t.Fatalf("ResolveAllSettings() error = %v", err)
 	}
@@ -256,6 +351,48 @@ func TestLoadSettingsFromDir_Valid(t *testing.T) {
 	}
 }
 
+func TestLoadSettingsFromDir_List(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "settings.cue"),
+		[]byte(`settings: { include_dirs: ["/a", "/b"], secret_patterns: "ghp_[a-z]+" }`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadSettingsFromDir(dir)
+	if err != nil {
+		t.Fatalf("LoadSettingsFromDir() error = %v", err)
+	}
+	if settings["include_dirs"] != "/a\n/b" {
+		t.Errorf("include_dirs = %q, want one entry per line", settings["include_dirs"])
+	}
+	if settings["secret_patterns"] != "ghp_[a-z]+" {
+		t.Errorf("secret_patterns = %q, want the string value", settings["secret_patterns"])
+	}
+	if got := SettingCUEValue("include_dirs", settings["include_dirs"]); !slices.Equal(got.([]string), []string{"/a", "/b"}) {
+		t.Errorf("SettingCUEValue() = %v, want [/a /b]", got)
+	}
+}
+
+func TestLoadSettingsFromDir_InvalidListEntry(t *testing.T) {
+	t.Parallel()
+
+	for _, content := range []string{
+		`settings: include_dirs: ["/a", 2]`,
+		`settings: include_dirs: ["/a", ""]`,
+	} {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "settings.cue"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadSettingsFromDir(dir)
+		if err == nil || !strings.Contains(err.Error(), "settings.include_dirs: entry 1") {
+			t.Errorf("LoadSettingsFromDir(%s) error = %v, want entry 1 rejected", content, err)
+		}
+	}
+}
+
 func TestLoadSettingsFromDir_NoSettingsBlock(t *testing.T) {
 	t.Parallel()
 
@@ -273,3 +410,76 @@ func TestLoadSettingsFromDir_NoSettingsBlock(t *testing.T) {
 		t.Errorf("got %d settings, want 0", len(settings))
 	}
 }
+
+func TestResolveAllSettings_EnvOverride(t *testing.T) {
+	localDir := filepath.Join(t.TempDir(), "local")
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(localDir, "settings.cue"),
+		[]byte(`settings: { default_agent: "gemini", timeout: 120 }`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("START_DEFAULT_AGENT", "claude")
+	t.Setenv("START_ASSETS_INDEX", "")
+
+	paths := Paths{
+		Global:      filepath.Join(t.TempDir(), "global"),
+		Local:       localDir,
+		LocalExists: true,
+	}
+
+	entries, err := ResolveAllSettings(paths, ScopeMerged)
+	if err != nil {
+		t.Fatalf("ResolveAllSettings() error = %v", err)
+	}
+	if e := entries["default_agent"]; e.Value != "claude" || e.Source != SourceEnv {
+		t.Errorf("default_agent = %+v, want claude from env", e)
+	}
+	if e := entries["timeout"]; e.Source != "local" {
+		t.Errorf("timeout source = %q, want %q", e.Source, "local")
+	}
+	if e := entries["assets_index"]; e.Source != "default" {
+		t.Errorf("empty START_ASSETS_INDEX should be ignored, source = %q", e.Source)
+	}
+
+	// The local-only view shows what the local files define
+	entries, err = ResolveAllSettings(paths, ScopeLocal)
+	if err != nil {
+		t.Fatalf("ResolveAllSettings(localOnly) error = %v", err)
+	}
+	if e := entries["default_agent"]; e.Value != "gemini" || e.Source != "local" {
+		t.Errorf("local default_agent = %+v, want gemini from local", e)
+	}
+
+	t.Setenv("START_TIMEOUT", "soon")
+	if _, err := ResolveAllSettings(paths, ScopeMerged); err == nil || !strings.Contains(err.Error(), `START_TIMEOUT: setting "timeout" requires an integer value`) {
+		t.Errorf("invalid START_TIMEOUT error = %v", err)
+	}
+}
+
+func TestApplyEnvSettings(t *testing.T) {
+	t.Setenv("START_TIMEOUT", "30")
+	t.Setenv("START_SHELL", "/bin/zsh")
+
+	loader := internalcue.NewLoader()
+	v := loader.Context().CompileString(`settings: { shell: "/bin/bash", default_agent: "claude" }
+agents: claude: command: "claude"`)
+
+	got, err := ApplyEnvSettings(loader, v)
+	if err != nil {
+		t.Fatalf("ApplyEnvSettings() error = %v", err)
+	}
+	if s, _ := got.LookupPath(cue.ParsePath("settings.shell")).String(); s != "/bin/zsh" {
+		t.Errorf("shell = %q, want %q", s, "/bin/zsh")
+	}
+	if n, _ := got.LookupPath(cue.ParsePath("settings.timeout")).Int64(); n != 30 {
+		t.Errorf("timeout = %d, want 30", n)
+	}
+	if s, _ := got.LookupPath(cue.ParsePath("settings.default_agent")).String(); s != "claude" {
+		t.Errorf("default_agent = %q, want %q", s, "claude")
+	}
+	if !got.LookupPath(cue.ParsePath("agents.claude.command")).Exists() {
+		t.Error("ApplyEnvSettings() dropped agents")
+	}
+}
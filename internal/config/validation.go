@@ -10,13 +10,15 @@ import (
 
 // ValidationResult holds the result of validating configuration directories.
 type ValidationResult struct {
-	// GlobalValid indicates the global config directory has valid CUE files.
+	// GlobalValid indicates the global config directory, or a shared layer
+	// beneath it, has valid CUE files.
 	GlobalValid bool
-	// LocalValid indicates the local config directory has valid CUE files.
+	// LocalValid indicates a local config directory has valid CUE files.
 	LocalValid bool
-	// GlobalError contains the validation error for global config, if any.
+	// GlobalError contains the first validation error for global config and
+	// the shared layers beneath it, if any.
 	GlobalError *internalcue.ValidationError
-	// LocalError contains the validation error for local config, if any.
+	// LocalError contains the first validation error across local layers, if any.
 	LocalError *internalcue.ValidationError
 }
 
@@ -40,18 +42,22 @@ func (r ValidationResult) HasErrors() bool {
 func ValidateConfig(paths Paths) ValidationResult {
 	var result ValidationResult
 
-	// Validate global config if directory exists
-	if paths.GlobalExists {
-		valid, err := validateDirectory(paths.Global)
-		result.GlobalValid = valid
-		result.GlobalError = err
+	// Validate global config and the shared layers beneath it
+	for _, layer := range paths.Layers(ScopeGlobal) {
+		valid, err := validateDirectory(layer.Dir)
+		result.GlobalValid = result.GlobalValid || valid
+		if err != nil && result.GlobalError == nil {
+			result.GlobalError = err
+		}
 	}
 
-	// Validate local config if directory exists
-	if paths.LocalExists {
-		valid, err := validateDirectory(paths.Local)
-		result.LocalValid = valid
-		result.LocalError = err
+	// Validate each local layer; the first error is reported
+	for _, dir := range paths.Locals() {
+		valid, err := validateDirectory(dir)
+		result.LocalValid = result.LocalValid || valid
+		if err != nil && result.LocalError == nil {
+			result.LocalError = err
+		}
 	}
 
 	return result
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// LockDir takes an exclusive advisory lock on a config directory, creating
+// the directory if needed, and returns a function that releases it. It
+// blocks while another process holds the lock. A personal overlay is given
+// its .gitignore before anything is written to it.
+func LockDir(dir string) (unlock func(), err error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating config directory: %w", err)
+	}
+	if IsPersonalDir(dir) {
+		if err := EnsurePersonalGitignore(dir); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening config directory: %w", err)
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("locking %s: %w", dir, err)
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
+
+// UpdateFile changes a config file while holding the lock on its directory.
+// edit receives the file's current content, read under the lock (nil if the
+// file does not exist), and returns the new content, which replaces the file
+// atomically; if it returns nil the file is left as it is. Concurrent updates
+// to files in the same directory are applied one after another, each to the
+// latest content. A symlinked file is written through to its target.
+func UpdateFile(path string, edit func(current []byte) ([]byte, error)) error {
+	return UpdateFileChecked(path, edit, nil)
+}
+
+// UpdateFileChecked is UpdateFile with a check run after the new content is
+// written, still under the lock. If check fails the file is restored, or
+// removed if it did not exist, before the lock is released, and the check
+// error is returned along with any error restoring the file.
+func UpdateFileChecked(path string, edit func(current []byte) ([]byte, error), check func() error) error {
+	unlock, err := LockDir(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+
+	current, err := os.ReadFile(path)
+	existed := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	data, err := edit(current)
+	if err != nil || data == nil {
+		return err
+	}
+	if err := writeAtomic(path, data); err != nil {
+		return err
+	}
+	if check == nil {
+		return nil
+	}
+
+	checkErr := check()
+	if checkErr == nil {
+		return nil
+	}
+	var restoreErr error
+	if existed {
+		restoreErr = writeAtomic(path, current)
+	} else if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		restoreErr = err
+	}
+	if restoreErr != nil {
+		return errors.Join(checkErr, fmt.Errorf("restoring %s failed; it keeps the rejected change: %w", path, restoreErr))
+	}
+	return checkErr
+}
+
+// WriteFile replaces a config file atomically while holding the lock on its
+// directory.
+func WriteFile(path string, data []byte) error {
+	return UpdateFile(path, func([]byte) ([]byte, error) { return data, nil })
+}
+
+// writeAtomic writes data to a temporary file beside path and renames it
+// into place, so readers see either the old or the new content. An existing
+// file keeps its permissions; a new file is created 0644.
+func writeAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
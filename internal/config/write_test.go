@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUpdateFile(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "start")
+	path := filepath.Join(dir, "tasks.cue")
+
+	// Missing file: edit sees nil and the directory is created
+	err := UpdateFile(path, func(current []byte) ([]byte, error) {
+		if current != nil {
+			t.Errorf("current = %q, want nil", current)
+		}
+		return []byte("tasks: {}\n"), nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateFile() error = %v", err)
+	}
+
+	// nil result leaves the file unchanged; errors are returned as is
+	if err := UpdateFile(path, func([]byte) ([]byte, error) { return nil, nil }); err != nil {
+		t.Fatalf("UpdateFile(nil) error = %v", err)
+	}
+	wantErr := errors.New("bad edit")
+	if err := UpdateFile(path, func([]byte) ([]byte, error) { return nil, wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("UpdateFile() error = %v, want %v", err, wantErr)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "tasks: {}\n" {
+		t.Errorf("content = %q", data)
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only tasks.cue", len(entries))
+	}
+}
+
+func TestUpdateFileChecked(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tasks.cue")
+	if err := os.WriteFile(path, []byte("tasks: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	replace := func([]byte) ([]byte, error) { return []byte("tasks: bad\n"), nil }
+
+	// A failed check restores the file and returns the check error
+	wantErr := errors.New("invalid")
+	var seen string
+	err := UpdateFileChecked(path, replace, func() error {
+		data, _ := os.ReadFile(path)
+		seen = string(data)
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("UpdateFileChecked() error = %v, want %v", err, wantErr)
+	}
+	if seen != "tasks: bad\n" {
+		t.Errorf("check saw %q, want the new content", seen)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "tasks: {}\n" {
+		t.Errorf("content = %q, want the original restored", data)
+	}
+
+	// A file created by the edit is removed
+	created := filepath.Join(dir, "roles.cue")
+	if err := UpdateFileChecked(created, replace, func() error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("UpdateFileChecked() error = %v, want %v", err, wantErr)
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Error("created file should be removed when the check fails")
+	}
+}
+
+func TestUpdateFile_Concurrent(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "tasks.cue")
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- UpdateFile(path, func(current []byte) ([]byte, error) {
+				return append(current, fmt.Sprintf("line %d\n", i)...), nil
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateFile() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != writers {
+		t.Fatalf("got %d lines, want %d: concurrent updates were lost\n%s", len(lines), writers, data)
+	}
+	for i := 0; i < writers; i++ {
+		if !strings.Contains(string(data), fmt.Sprintf("line %d\n", i)) {
+			t.Errorf("missing line %d", i)
+		}
+	}
+}
+
+func TestUpdateFile_Symlink(t *testing.T) {
+	t.Parallel()
+	target := filepath.Join(t.TempDir(), "agents.cue")
+	if err := os.WriteFile(target, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(t.TempDir(), "agents.cue")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFile(link, []byte("new\n")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Error("WriteFile() replaced the symlink")
+	}
+	if data, _ := os.ReadFile(target); string(data) != "new\n" {
+		t.Errorf("target content = %q, want %q", data, "new\n")
+	}
+}
+
+func TestWriteFile_KeepsMode(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	private := filepath.Join(dir, "settings.cue")
+	if err := os.WriteFile(private, []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(private, []byte("new\n")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if info, err := os.Stat(private); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0600 {
+		t.Errorf("existing file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	created := filepath.Join(dir, "agents.cue")
+	if err := WriteFile(created, []byte("new\n")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if info, err := os.Stat(created); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0644 {
+		t.Errorf("new file mode = %v, want 0644", info.Mode().Perm())
+	}
+}
+
+func TestUpdateFile_PersonalGitignore(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), ".start", "local")
+	if err := WriteFile(filepath.Join(dir, "roles.cue"), []byte("roles: {}\n")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil || !strings.Contains(string(data), "*") {
+		t.Errorf(".gitignore = %q, %v; want one ignoring everything", data, err)
+	}
+
+	// An existing .gitignore is kept
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.cue\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(filepath.Join(dir, "roles.cue"), []byte("roles: {}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, ".gitignore")); string(data) != "*.cue\n" {
+		t.Errorf(".gitignore = %q, want it unchanged", data)
+	}
+}
@@ -0,0 +1,180 @@
+package cue
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/parser"
+)
+
+// FindField returns the field at the label path in a parsed CUE file, or nil
+// if the file does not declare it. A path may be split across shorthand
+// fields (agents: claude: command: ...) or repeated declarations of the same
+// struct; the last declaration wins, matching how later fields read.
+func FindField(f *ast.File, path []string) *ast.Field {
+	if len(path) == 0 {
+		return nil
+	}
+	return findField(f.Decls, path)
+}
+
+func findField(decls []ast.Decl, path []string) *ast.Field {
+	var found *ast.Field
+	for _, field := range fieldsNamed(decls, path[0]) {
+		if len(path) == 1 {
+			found = field
+			continue
+		}
+		if s, ok := field.Value.(*ast.StructLit); ok {
+			if f := findField(s.Elts, path[1:]); f != nil {
+				found = f
+			}
+		}
+	}
+	return found
+}
+
+// SetField sets the field at the label path in a parsed CUE file to value.
+// An existing field keeps its position and comments; a missing field is
+// added to the deepest struct on the path that the file already declares.
+func SetField(f *ast.File, path []string, value ast.Expr) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty path")
+	}
+	if field := FindField(f, path); field != nil {
+		field.Value = value
+		return nil
+	}
+
+	decls := &f.Decls
+	for i, label := range path {
+		fields := fieldsNamed(*decls, label)
+		next := structFor(fields, path[i+1:])
+		if next == nil {
+			if len(fields) > 0 {
+				return fmt.Errorf("%s is not a struct", strings.Join(path[:i+1], "."))
+			}
+			*decls = append(*decls, nestedField(path[i:], value))
+			return nil
+		}
+		decls = &next.Elts
+	}
+	return nil
+}
+
+// DeleteField removes the field at the label path from a parsed CUE file.
+// Returns false if the file does not declare the field.
+func DeleteField(f *ast.File, path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+	return deleteField(&f.Decls, path)
+}
+
+func deleteField(decls *[]ast.Decl, path []string) bool {
+	deleted := false
+	kept := (*decls)[:0]
+	for _, decl := range *decls {
+		field, ok := decl.(*ast.Field)
+		if !ok || labelName(field) != path[0] {
+			kept = append(kept, decl)
+			continue
+		}
+		if len(path) == 1 {
+			deleted = true
+			continue
+		}
+		if s, ok := field.Value.(*ast.StructLit); ok && deleteField(&s.Elts, path[1:]) {
+			deleted = true
+		}
+		kept = append(kept, decl)
+	}
+	*decls = kept
+	return deleted
+}
+
+// ParseLiteral parses s as a CUE literal value: a number, quoted string,
+// bool, null, list, or struct. Anything else, including bare words, is taken
+// as a plain string, so "opus" and "claude {{.prompt}}" need no quoting.
+func ParseLiteral(s string) ast.Expr {
+	expr, err := parser.ParseExpr("value", s)
+	if err != nil {
+		return ast.NewString(s)
+	}
+	switch e := expr.(type) {
+	case *ast.BasicLit, *ast.ListLit, *ast.StructLit:
+		return expr
+	case *ast.UnaryExpr:
+		if _, ok := e.X.(*ast.BasicLit); ok {
+			return expr
+		}
+	case *ast.Ident:
+		switch e.Name {
+		case "true", "false", "null":
+			return expr
+		}
+	}
+	return ast.NewString(s)
+}
+
+// structFor returns the struct among fields to descend into for the rest of
+// a path: the last one that declares the next label, otherwise the last
+// struct. Returns nil if none of the fields is a struct.
+func structFor(fields []*ast.Field, rest []string) *ast.StructLit {
+	var last *ast.StructLit
+	for i := len(fields) - 1; i >= 0; i-- {
+		s, ok := fields[i].Value.(*ast.StructLit)
+		if !ok {
+			continue
+		}
+		if len(rest) > 0 && len(fieldsNamed(s.Elts, rest[0])) > 0 {
+			return s
+		}
+		if last == nil {
+			last = s
+		}
+	}
+	return last
+}
+
+// fieldsNamed returns the regular fields in decls with the given label.
+func fieldsNamed(decls []ast.Decl, label string) []*ast.Field {
+	var fields []*ast.Field
+	for _, decl := range decls {
+		if field, ok := decl.(*ast.Field); ok && labelName(field) == label {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// labelName returns a field's label, or "" for labels that are not names.
+func labelName(field *ast.Field) string {
+	name, _, err := ast.LabelName(field.Label)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// nestedField builds label1: label2: ...: value for the given path.
+func nestedField(path []string, value ast.Expr) *ast.Field {
+	field := &ast.Field{Label: fieldLabel(path[len(path)-1]), Value: value}
+	for i := len(path) - 2; i >= 0; i-- {
+		field = &ast.Field{
+			Label: fieldLabel(path[i]),
+			Value: &ast.StructLit{Elts: []ast.Decl{field}},
+		}
+	}
+	return field
+}
+
+// fieldLabel returns an identifier label when name is a valid identifier,
+// otherwise a quoted string label.
+func fieldLabel(name string) ast.Label {
+	if ast.IsValidIdent(name) {
+		return ast.NewIdent(name)
+	}
+	return ast.NewString(name)
+}
@@ -0,0 +1,131 @@
+package cue
+
+import (
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+)
+
+const editSource = `// Agents
+agents: {
+	// The main agent
+	"claude": {
+		bin:     "claude"
+		command: "claude"
+	}
+}
+agents: gemini: bin: "gemini"
+`
+
+func parseEditSource(t *testing.T) *ast.File {
+	t.Helper()
+	f, err := parser.ParseFile("agents.cue", editSource, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func formatFile(t *testing.T, f *ast.File) string {
+	t.Helper()
+	b, err := format.Node(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestFindField(t *testing.T) {
+	t.Parallel()
+	f := parseEditSource(t)
+
+	if field := FindField(f, []string{"agents", "gemini", "bin"}); field == nil {
+		t.Error("field in a repeated shorthand declaration not found")
+	}
+	if field := FindField(f, []string{"agents", "claude", "command"}); field == nil {
+		t.Error("field under a quoted label not found")
+	}
+	if field := FindField(f, []string{"agents", "claude", "models"}); field != nil {
+		t.Error("missing field found")
+	}
+}
+
+func TestSetField(t *testing.T) {
+	t.Parallel()
+	f := parseEditSource(t)
+
+	if err := SetField(f, []string{"agents", "claude", "command"}, ast.NewString("claude {{.prompt}}")); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetField(f, []string{"agents", "claude", "models", "fast"}, ast.NewString("haiku")); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetField(f, []string{"roles", "gemini/x", "prompt"}, ast.NewString("hi")); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetField(f, []string{"agents", "claude", "bin", "x"}, ast.NewString("y")); err == nil {
+		t.Error("expected error setting a field below a non-struct")
+	}
+
+	got := formatFile(t, f)
+	for _, want := range []string{
+		"// The main agent\n",
+		`command: "claude {{.prompt}}"`,
+		`fast: "haiku"`,
+		`"gemini/x": {`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "bin:") > strings.Index(got, "command:") {
+		t.Errorf("field order changed:\n%s", got)
+	}
+}
+
+func TestDeleteField(t *testing.T) {
+	t.Parallel()
+	f := parseEditSource(t)
+
+	if !DeleteField(f, []string{"agents", "claude", "bin"}) {
+		t.Fatal("DeleteField() = false, want true")
+	}
+	if DeleteField(f, []string{"agents", "claude", "bin"}) {
+		t.Error("second DeleteField() = true, want false")
+	}
+	got := formatFile(t, f)
+	if strings.Contains(got, `bin:     "claude"`) || !strings.Contains(got, `gemini: bin: "gemini"`) {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+}
+
+func TestParseLiteral(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"120", "120"},
+		{"-1", "-1"},
+		{"true", "true"},
+		{`"quoted"`, `"quoted"`},
+		{`["a", "b"]`, `["a", "b"]`},
+		{"opus", `"opus"`},
+		{"claude {{.prompt}}", `"claude {{.prompt}}"`},
+		{"./ROLE.md", `"./ROLE.md"`},
+		{"a.b", `"a.b"`},
+	}
+	for _, tt := range tests {
+		b, err := format.Node(ParseLiteral(tt.in))
+		if err != nil {
+			t.Fatalf("format(%q): %v", tt.in, err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("ParseLiteral(%q) = %s, want %s", tt.in, b, tt.want)
+		}
+	}
+}
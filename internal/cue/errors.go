@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -112,11 +113,83 @@ func FormatErrorWithContext(err error) *ValidationError {
 	// Add source context if we have file and line info
 	if ve.Filename != "" && ve.Line > 0 {
 		ve.Context = generateSourceContext(ve.Filename, ve.Line, ve.Column)
+		ve.Hint = ErrorHint(ve.Message, sourceLine(ve.Filename, ve.Line), ve.Column)
+	} else {
+		ve.Hint = ErrorHint(ve.Message, "", 0)
 	}
 
 	return ve
 }
 
+// referenceNotFound matches CUE's error for an unquoted word used as a value.
+var referenceNotFound = regexp.MustCompile(`^reference "([^"]+)" not found$`)
+
+// ErrorHint returns a likely fix for a CUE error message, or "" if none is
+// known. line is the source line the error points at and column its 1-based
+// byte column; when they are given, fixable mistakes (a missing colon, an
+// extra comma, a semicolon, '=' for ':', an unquoted string) are shown as
+// the corrected line.
+func ErrorHint(message, line string, column int) string {
+	// at returns line with text replacing n bytes from the error column.
+	at := func(n int, text string) string {
+		i := column - 1
+		if line == "" || i < 0 || i+n > len(line) {
+			return ""
+		}
+		return strings.TrimSpace(line[:i] + text + line[i+n:])
+	}
+	didYouMean := func(fixed, fallback string) string {
+		if fixed == "" {
+			return fallback
+		}
+		return fmt.Sprintf("did you mean %s?", fixed)
+	}
+
+	switch {
+	case strings.HasPrefix(message, "expected label or ':'"):
+		fixed := ""
+		if i := column - 1; line != "" && i > 0 && i <= len(line) {
+			fixed = strings.TrimSpace(strings.TrimRight(line[:i], " \t") + ": " + line[i:])
+		}
+		return didYouMean(fixed, "a field needs a colon between its name and value, as in name: value")
+	case strings.HasPrefix(message, "expected operand, found ','"):
+		return didYouMean(at(1, ""), "remove the extra comma")
+	case strings.HasPrefix(message, "missing ',' in struct literal"):
+		if column > 0 && column <= len(line) && line[column-1] == ';' {
+			return didYouMean(at(1, ""), "CUE separates fields with commas or newlines, not semicolons")
+		}
+		return "put each field on its own line, or separate fields with commas"
+	case strings.HasPrefix(message, "missing ',' in list literal"):
+		return "separate list elements with commas"
+	case strings.HasPrefix(message, "use of deprecated old-style alias"):
+		fixed := ""
+		if i := strings.Index(line, "="); i > 0 {
+			fixed = strings.TrimSpace(strings.TrimRight(line[:i], " \t") + ":" + line[i+1:])
+		}
+		return didYouMean(fixed, "set fields with ':' rather than '='")
+	case strings.HasSuffix(message, "found 'EOF'"):
+		return "a '{' or '[' is not closed"
+	}
+
+	if m := referenceNotFound.FindStringSubmatch(message); m != nil {
+		return didYouMean(at(len(m[1]), strconv.Quote(m[1])), "quote string values")
+	}
+	return ""
+}
+
+// sourceLine returns the given 1-based line of a file, or "" if unreadable.
+func sourceLine(filename string, line int) string {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(string(data), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return lines[line-1]
+}
+
 // generateSourceContext reads a file and generates a context snippet around the given line.
 // It shows 2 lines before and after the error line, with line numbers and a pointer to the column.
 func generateSourceContext(filename string, line, column int) string {
@@ -428,6 +428,46 @@ extra: true
 	})
 }
 
+func TestFormatErrorWithContext_Hints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		hint string
+	}{
+		{"missing colon before value", "agents: claude: {\n\tbin \"claude\"\n}\n", `did you mean bin: "claude"?`},
+		{"missing colon before struct", "agents: {\n\tclaude {\n\t\tbin: \"claude\"\n\t}\n}\n", "did you mean claude: {?"},
+		{"extra comma", "agents: claude: {\n\tbin: \"claude\",,\n}\n", `did you mean bin: "claude",?`},
+		{"semicolon", "agents: claude: {\n\tbin: \"claude\";\n}\n", `did you mean bin: "claude"?`},
+		{"equals", "agents: claude: {\n\tbin = \"claude\"\n}\n", `did you mean bin: "claude"?`},
+		{"unquoted string", "agents: claude: {\n\tbin: claudex\n}\n", `did you mean bin: "claudex"?`},
+		{"list comma", "tags: [\"a\" \"b\"]\n", "separate list elements with commas"},
+		{"unclosed brace", "agents: claude: {\n\tbin: \"claude\"\n", "a '{' or '[' is not closed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "agents.cue"), []byte(tt.src), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := NewLoader().LoadSingle(dir)
+			if err == nil {
+				t.Fatal("expected load error")
+			}
+			ve := FormatErrorWithContext(err)
+			if ve.Hint != tt.hint {
+				t.Errorf("Hint = %q, want %q (message %q)", ve.Hint, tt.hint, ve.Message)
+			}
+			if !strings.Contains(ve.DetailedError(), "Hint: "+tt.hint) {
+				t.Errorf("DetailedError() missing hint:\n%s", ve.DetailedError())
+			}
+		})
+	}
+}
+
 func TestErrorSummary_MultipleErrors(t *testing.T) {
 	t.Parallel()
 	ctx := cuecontext.New()
@@ -0,0 +1,199 @@
+package cue
+
+import (
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/grantcarthew/start/internal/suggest"
+)
+
+// Item directive fields for agents, roles, contexts, and tasks.
+const (
+	// FieldExtends names another item in the same collection to inherit
+	// fields from. The item's own fields override inherited ones.
+	FieldExtends = "extends"
+	// FieldMerge, when true, merges an item field by field into the
+	// same-named item from an earlier config layer instead of replacing it.
+	FieldMerge = "merge"
+	// FieldEnabled, when false, drops the item from the merged config. A
+	// higher layer uses it to disable an item defined by a lower one; the
+	// disabled item merges into that definition, so it can still be extended.
+	FieldEnabled = "enabled"
+)
+
+// notInherited are fields an item never inherits through extends.
+// origin marks a registry install and belongs to the base item only, and a
+// disabled base can still be extended.
+var notInherited = map[string]bool{"origin": true, FieldEnabled: true}
+
+// usesDirectives reports whether any collection item in v has an extends or
+// enabled field, which need resolving even for a single config directory.
+func usesDirectives(v cue.Value) bool {
+	for key := range collectionKeys {
+		iter, err := v.LookupPath(cue.ParsePath(key)).Fields()
+		if err != nil {
+			continue
+		}
+		for iter.Next() {
+			if iter.Value().LookupPath(cue.ParsePath(FieldExtends)).Exists() ||
+				iter.Value().LookupPath(cue.ParsePath(FieldEnabled)).Exists() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsDisabled reports whether an item sets enabled: false.
+func IsDisabled(item cue.Value) bool {
+	v := item.LookupPath(cue.ParsePath(FieldEnabled))
+	if !v.Exists() {
+		return false
+	}
+	enabled, err := v.Bool()
+	return err == nil && !enabled
+}
+
+// setsEnabled reports whether an item sets the enabled field, either way.
+// Such an item toggles an earlier definition rather than replacing it.
+func setsEnabled(item cue.Value) bool {
+	return item.LookupPath(cue.ParsePath(FieldEnabled)).Exists()
+}
+
+// dropDisabled removes disabled items from a collection and returns the
+// remaining order.
+func dropDisabled(items map[string]cue.Value, order []string) []string {
+	kept := order[:0:0]
+	for _, sel := range order {
+		if IsDisabled(items[sel]) {
+			delete(items, sel)
+			continue
+		}
+		kept = append(kept, sel)
+	}
+	return kept
+}
+
+// wantsMerge reports whether an item sets merge: true.
+func wantsMerge(item cue.Value) bool {
+	merge, _ := item.LookupPath(cue.ParsePath(FieldMerge)).Bool()
+	return merge
+}
+
+// mergeItem returns base with the fields of over replacing same-named fields.
+// Fields are replaced whole: nested structs such as models are not merged.
+// When inherit is true, base fields that are never inherited are dropped.
+func (l *Loader) mergeItem(base, over cue.Value, inherit bool) (cue.Value, error) {
+	fields := make(map[string]cue.Value)
+	var order []string
+	for i, v := range []cue.Value{base, over} {
+		iter, err := v.Fields(cue.All())
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("iterating fields: %w", err)
+		}
+		for iter.Next() {
+			name := iter.Selector().String()
+			if i == 0 && inherit && notInherited[name] {
+				continue
+			}
+			if _, exists := fields[name]; !exists {
+				order = append(order, name)
+			}
+			fields[name] = iter.Value()
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	for _, name := range order {
+		formatted, err := formatValue(fields[name])
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("formatting %s: %w", name, err)
+		}
+		sb.WriteString(name)
+		sb.WriteString(": ")
+		sb.WriteString(formatted)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}")
+
+	merged := l.ctx.CompileString(sb.String())
+	if err := merged.Err(); err != nil {
+		return cue.Value{}, fmt.Errorf("compiling merged item: %w", err)
+	}
+	return merged, nil
+}
+
+// resolveExtends replaces each item in a collection that extends another with
+// the base item's fields overridden by its own. items is keyed by selector
+// string in the order given.
+func (l *Loader) resolveExtends(key string, items map[string]cue.Value, order []string) error {
+	// extends names items unquoted; selectors may be quoted
+	selectors := make(map[string]string, len(order))
+	var names []string
+	for _, sel := range order {
+		name := unquoteSelector(sel)
+		selectors[name] = sel
+		names = append(names, name)
+	}
+
+	resolved := make(map[string]bool)
+	var resolve func(name string, chain []string) error
+	resolve = func(name string, chain []string) error {
+		if resolved[name] {
+			return nil
+		}
+		sel := selectors[name]
+		item := items[sel]
+		extends := item.LookupPath(cue.ParsePath(FieldExtends))
+		if !extends.Exists() {
+			resolved[name] = true
+			return nil
+		}
+		base, err := extends.String()
+		if err != nil {
+			return fmt.Errorf("%s.%s: extends must be a string", key, name)
+		}
+
+		chain = append(chain, name)
+		for i, c := range chain {
+			if c == base {
+				return fmt.Errorf("%s.%s: extends cycle %s -> %s", key, name, strings.Join(chain[i:], " -> "), base)
+			}
+		}
+		if _, ok := selectors[base]; !ok {
+			msg := fmt.Sprintf("%s.%s: extends unknown item %q", key, name, base)
+			if s := suggest.Closest(base, names); s != "" {
+				msg += fmt.Sprintf("; did you mean %q?", s)
+			}
+			return fmt.Errorf("%s", msg)
+		}
+		if err := resolve(base, chain); err != nil {
+			return err
+		}
+
+		merged, err := l.mergeItem(items[selectors[base]], item, true)
+		if err != nil {
+			return fmt.Errorf("resolving %s.%s: %w", key, name, err)
+		}
+		items[sel] = merged
+		resolved[name] = true
+		return nil
+	}
+
+	for _, name := range names {
+		if err := resolve(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unquoteSelector returns the plain name of a selector string.
+func unquoteSelector(sel string) string {
+	if sels := cue.ParsePath(sel).Selectors(); len(sels) == 1 {
+		return sels[0].Unquoted()
+	}
+	return sel
+}
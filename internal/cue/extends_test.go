@@ -0,0 +1,207 @@
+package cue
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoader_Extends(t *testing.T) {
+	t.Parallel()
+
+	t.Run("derived item inherits and overrides across layers", func(t *testing.T) {
+		t.Parallel()
+		globalDir := t.TempDir()
+		localDir := t.TempDir()
+
+		writeCUEFile(t, globalDir, "agents.cue", `
+			agents: claude: {
+				origin: "github.com/example/agents/claude@v0"
+				bin: "claude"
+				command: "{{.bin}} --model {{.model}}"
+				default_model: "sonnet"
+			}
+		`)
+		writeCUEFile(t, localDir, "agents.cue", `
+			agents: "claude-opus": {
+				extends: "claude"
+				default_model: "opus"
+			}
+		`)
+
+		result, err := NewLoader().Load([]string{globalDir, localDir})
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		v := result.Value.LookupPath(parsePath(`agents."claude-opus"`))
+		if got, _ := v.LookupPath(parsePath("bin")).String(); got != "claude" {
+			t.Errorf("bin = %q, want inherited %q", got, "claude")
+		}
+		if got, _ := v.LookupPath(parsePath("default_model")).String(); got != "opus" {
+			t.Errorf("default_model = %q, want %q", got, "opus")
+		}
+		if v.LookupPath(parsePath("origin")).Exists() {
+			t.Error("origin should not be inherited")
+		}
+	})
+
+	t.Run("chains resolve in a single directory", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeCUEFile(t, dir, "roles.cue", `
+			roles: {
+				c: {extends: "b", description: "C"}
+				b: {extends: "a", prompt: "B prompt"}
+				a: {prompt: "A prompt", optional: true}
+			}
+		`)
+
+		result, err := NewLoader().Load([]string{dir})
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got, _ := result.Value.LookupPath(parsePath("roles.c.prompt")).String(); got != "B prompt" {
+			t.Errorf("roles.c.prompt = %q, want %q", got, "B prompt")
+		}
+		if got, _ := result.Value.LookupPath(parsePath("roles.c.optional")).Bool(); !got {
+			t.Error("roles.c.optional should be inherited from a")
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeCUEFile(t, dir, "roles.cue", `
+			roles: {
+				a: {extends: "b"}
+				b: {extends: "a"}
+			}
+		`)
+		_, err := NewLoader().Load([]string{dir})
+		if err == nil || !strings.Contains(err.Error(), "extends cycle a -> b -> a") {
+			t.Errorf("Load() error = %v, want extends cycle", err)
+		}
+	})
+
+	t.Run("unknown base", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeCUEFile(t, dir, "tasks.cue", `
+			tasks: {
+				review: prompt: "Review"
+				quick: extends: "reveiw"
+			}
+		`)
+		_, err := NewLoader().Load([]string{dir})
+		if err == nil || !strings.Contains(err.Error(), `tasks.quick: extends unknown item "reveiw"; did you mean "review"?`) {
+			t.Errorf("Load() error = %v", err)
+		}
+	})
+}
+
+func TestLoader_MergeDirective(t *testing.T) {
+	t.Parallel()
+	globalDir := t.TempDir()
+	localDir := t.TempDir()
+
+	writeCUEFile(t, globalDir, "agents.cue", `
+		agents: claude: {
+			bin: "claude"
+			command: "{{.bin}} --model {{.model}}"
+			default_model: "sonnet"
+		}
+		roles: dev: {description: "Global dev", prompt: "Dev"}
+	`)
+	writeCUEFile(t, localDir, "agents.cue", `
+		agents: claude: {
+			merge: true
+			default_model: "opus"
+		}
+		roles: dev: description: "Local dev"
+	`)
+
+	result, err := NewLoader().Load([]string{globalDir, localDir})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, _ := result.Value.LookupPath(parsePath("agents.claude.bin")).String(); got != "claude" {
+		t.Errorf("agents.claude.bin = %q, want merged %q", got, "claude")
+	}
+	if got, _ := result.Value.LookupPath(parsePath("agents.claude.default_model")).String(); got != "opus" {
+		t.Errorf("agents.claude.default_model = %q, want %q", got, "opus")
+	}
+	// Without merge: true, items are still replaced entirely
+	if result.Value.LookupPath(parsePath("roles.dev.prompt")).Exists() {
+		t.Error("roles.dev.prompt should not exist without merge: true")
+	}
+}
+
+func TestLoader_Disabled(t *testing.T) {
+	t.Parallel()
+	globalDir := t.TempDir()
+	localDir := t.TempDir()
+
+	writeCUEFile(t, globalDir, "agents.cue", `
+		agents: {
+			claude: {bin: "claude", command: "claude"}
+			gemini: {bin: "gemini", command: "gemini"}
+		}
+	`)
+	writeCUEFile(t, localDir, "agents.cue", `
+		agents: {
+			gemini: enabled: false
+			claude: enabled: false
+			"claude-local": {extends: "claude", description: "Local claude"}
+		}
+	`)
+
+	result, err := NewLoader().Load([]string{globalDir, localDir})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for _, name := range []string{"gemini", "claude"} {
+		if result.Value.LookupPath(parsePath("agents." + name)).Exists() {
+			t.Errorf("agents.%s should be dropped by enabled: false", name)
+		}
+	}
+	// A disabled base can still be extended, and enabled is not inherited
+	derived := result.Value.LookupPath(parsePath(`agents."claude-local"`))
+	if !derived.Exists() {
+		t.Fatal(`agents."claude-local" missing`)
+	}
+	if got, _ := derived.LookupPath(parsePath("bin")).String(); got != "claude" {
+		t.Errorf("bin = %q, want %q", got, "claude")
+	}
+}
+
+func TestLoader_DisabledThenReenabled(t *testing.T) {
+	t.Parallel()
+	globalDir := t.TempDir()
+	repoDir := t.TempDir()
+	localDir := t.TempDir()
+
+	writeCUEFile(t, globalDir, "contexts.cue", `
+		contexts: foo: {prompt: "Foo context", default: true}
+	`)
+	writeCUEFile(t, repoDir, "contexts.cue", `
+		contexts: foo: enabled: false
+	`)
+	writeCUEFile(t, localDir, "contexts.cue", `
+		contexts: foo: enabled: true
+	`)
+
+	result, err := NewLoader().Load([]string{globalDir, repoDir, localDir})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	foo := result.Value.LookupPath(parsePath("contexts.foo"))
+	if !foo.Exists() {
+		t.Fatal("contexts.foo should be re-enabled")
+	}
+	// Re-enabling restores the original definition
+	if got, _ := foo.LookupPath(parsePath("prompt")).String(); got != "Foo context" {
+		t.Errorf("prompt = %q, want %q", got, "Foo context")
+	}
+	if got, _ := foo.LookupPath(parsePath("default")).Bool(); !got {
+		t.Error("default = false, want true")
+	}
+}
@@ -32,10 +32,14 @@ func NewLoader() *Loader {
 type LoadResult struct {
 	// Value is the merged CUE value.
 	Value cue.Value
-	// GlobalLoaded indicates whether global config was loaded.
-	GlobalLoaded bool
-	// LocalLoaded indicates whether local config was loaded.
-	LocalLoaded bool
+	// Dirs are the directories that contributed CUE files, in load order.
+	// Skipped (missing or empty) directories are not listed.
+	Dirs []string
+
+	// definitions records where each item and setting is defined.
+	// Merged values lose file positions, so they are collected per directory.
+	definitions     map[defKey][]Definition
+	definitionOrder []defKey
 }
 
 // Load loads CUE configuration from the specified directories.
@@ -43,8 +47,9 @@ type LoadResult struct {
 // via CUE unification (later values override earlier for matching keys).
 // Empty or non-existent directories are skipped.
 //
-// The caller convention is: dirs[0] = global config, dirs[1] = local config.
-// GlobalLoaded/LocalLoaded indicate which of these were successfully loaded.
+// Callers pass the layers of config.Paths in precedence order (system,
+// include, START_CONFIG_PATH, global, local, personal); the loader does not
+// interpret positions. Dirs records which of them were loaded.
 func (l *Loader) Load(dirs []string) (LoadResult, error) {
 	var result LoadResult
 
@@ -52,11 +57,10 @@ func (l *Loader) Load(dirs []string) (LoadResult, error) {
 		return result, fmt.Errorf("no configuration directories provided")
 	}
 
-	// Track which directories were loaded by their original index
-	loaded := make([]bool, len(dirs))
+	result.definitions = make(map[defKey][]Definition)
 
 	var values []cue.Value
-	for i, dir := range dirs {
+	for _, dir := range dirs {
 		if dir == "" {
 			continue
 		}
@@ -89,15 +93,8 @@ func (l *Loader) Load(dirs []string) (LoadResult, error) {
 		}
 
 		values = append(values, v)
-		loaded[i] = true
-	}
-
-	// Set loaded flags based on original directory positions
-	if len(dirs) > 0 && loaded[0] {
-		result.GlobalLoaded = true
-	}
-	if len(dirs) > 1 && loaded[1] {
-		result.LocalLoaded = true
+		result.Dirs = append(result.Dirs, dir)
+		result.recordDefinitions(dir, v)
 	}
 
 	if len(values) == 0 {
@@ -136,12 +133,20 @@ var collectionKeys = map[string]bool{
 //   - Fields are merged additively
 //   - Same field: later value replaces earlier value
 //
+// Two item directives adjust this:
+//   - merge: true on a later item merges it field by field into the earlier one
+//   - extends: "name" inherits fields from another item in the same collection,
+//     resolved after all layers are merged
+//   - enabled: false drops the item from the merged result; a later item
+//     setting enabled either way merges like merge: true, so enabled: true
+//     restores a disabled definition
+//
 // This differs from CUE's native unification which requires compatible values.
 func (l *Loader) mergeWithReplacement(values []cue.Value) (cue.Value, error) {
 	if len(values) == 0 {
 		return cue.Value{}, fmt.Errorf("no values to merge")
 	}
-	if len(values) == 1 {
+	if len(values) == 1 && !usesDirectives(values[0]) {
 		return values[0], nil
 	}
 
@@ -177,9 +182,21 @@ func (l *Loader) mergeWithReplacement(values []cue.Value) (cue.Value, error) {
 				}
 				for itemIter.Next() {
 					itemName := itemIter.Selector().String()
-					if _, exists := topLevel[key][itemName]; !exists {
+					existing, exists := topLevel[key][itemName]
+					if !exists {
 						itemOrder[key] = append(itemOrder[key], itemName)
 					}
+					if exists && (wantsMerge(itemIter.Value()) || setsEnabled(itemIter.Value())) {
+						// Later item merges into earlier item field by field.
+						// Disabling keeps the definition so it can be extended,
+						// and re-enabling restores it.
+						merged, err := l.mergeItem(existing, itemIter.Value(), false)
+						if err != nil {
+							return cue.Value{}, fmt.Errorf("merging %s.%s: %w", key, itemName, err)
+						}
+						topLevel[key][itemName] = merged
+						continue
+					}
 					// Later item replaces earlier item entirely
 					topLevel[key][itemName] = itemIter.Value()
 				}
@@ -209,6 +226,17 @@ func (l *Loader) mergeWithReplacement(values []cue.Value) (cue.Value, error) {
 		}
 	}
 
+	// Resolve extends within each collection, then drop disabled items
+	for _, key := range topLevelOrder {
+		if !collectionKeys[key] {
+			continue
+		}
+		if err := l.resolveExtends(key, topLevel[key], itemOrder[key]); err != nil {
+			return cue.Value{}, err
+		}
+		itemOrder[key] = dropDisabled(topLevel[key], itemOrder[key])
+	}
+
 	// Build CUE source from merged structure
 	var sb strings.Builder
 	sb.WriteString("{\n")
@@ -263,6 +291,49 @@ func (l *Loader) mergeWithReplacement(values []cue.Value) (cue.Value, error) {
 	return merged, nil
 }
 
+// OverrideSettings returns v with the given settings fields replaced,
+// leaving every other field as loaded. It is used for overrides that sit above
+// all config layers, such as START_* environment variables.
+func (l *Loader) OverrideSettings(v cue.Value, overrides map[string]any) (cue.Value, error) {
+	if len(overrides) == 0 {
+		return v, nil
+	}
+
+	settings := l.ctx.CompileString("{}")
+	if existing := v.LookupPath(cue.ParsePath(KeySettings)); existing.Exists() {
+		iter, err := existing.Fields(cue.All())
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("iterating settings: %w", err)
+		}
+		for iter.Next() {
+			if _, ok := overrides[iter.Selector().Unquoted()]; !ok {
+				settings = settings.FillPath(cue.MakePath(iter.Selector()), iter.Value())
+			}
+		}
+	}
+	for key, value := range overrides {
+		settings = settings.FillPath(cue.MakePath(cue.Str(key)), value)
+	}
+
+	result := l.ctx.CompileString("{}")
+	if v.Exists() {
+		iter, err := v.Fields(cue.All())
+		if err != nil {
+			return cue.Value{}, fmt.Errorf("iterating fields: %w", err)
+		}
+		for iter.Next() {
+			if iter.Selector().String() != KeySettings {
+				result = result.FillPath(cue.MakePath(iter.Selector()), iter.Value())
+			}
+		}
+	}
+	result = result.FillPath(cue.ParsePath(KeySettings), settings)
+	if err := result.Err(); err != nil {
+		return cue.Value{}, fmt.Errorf("applying settings overrides: %w", err)
+	}
+	return result, nil
+}
+
 // formatValue formats a CUE value as CUE syntax string.
 func formatValue(v cue.Value) (string, error) {
 	// Use CUE's native formatting
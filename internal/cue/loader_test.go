@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
@@ -36,8 +37,8 @@ func TestLoader_Load(t *testing.T) {
 			t.Fatalf("Load() error = %v", err)
 		}
 
-		if !result.GlobalLoaded {
-			t.Error("GlobalLoaded = false, want true")
+		if !slices.Equal(result.Dirs, []string{dir}) {
+			t.Errorf("Dirs = %v, want [%s]", result.Dirs, dir)
 		}
 
 		// Verify value was loaded
@@ -72,11 +73,8 @@ func TestLoader_Load(t *testing.T) {
 			t.Fatalf("Load() error = %v", err)
 		}
 
-		if !result.GlobalLoaded {
-			t.Error("GlobalLoaded = false, want true")
-		}
-		if !result.LocalLoaded {
-			t.Error("LocalLoaded = false, want true")
+		if want := []string{globalDir, localDir}; !slices.Equal(result.Dirs, want) {
+			t.Errorf("Dirs = %v, want %v", result.Dirs, want)
 		}
 
 		// Both unique fields should exist (additive for different keys)
@@ -158,13 +156,9 @@ func TestLoader_Load(t *testing.T) {
 			t.Fatalf("Load() error = %v", err)
 		}
 
-		// First directory (global position) doesn't exist, so GlobalLoaded is false
-		if result.GlobalLoaded {
-			t.Error("GlobalLoaded = true, want false (non-existent dir was at global position)")
-		}
-		// Second directory (local position) was loaded
-		if !result.LocalLoaded {
-			t.Error("LocalLoaded = false, want true (existing dir was at local position)")
+		// Only the existing directory was loaded
+		if !slices.Equal(result.Dirs, []string{existingDir}) {
+			t.Errorf("Dirs = %v, want [%s]", result.Dirs, existingDir)
 		}
 	})
 
@@ -179,13 +173,9 @@ func TestLoader_Load(t *testing.T) {
 			t.Fatalf("Load() error = %v", err)
 		}
 
-		// First directory (global position) has no CUE files, so GlobalLoaded is false
-		if result.GlobalLoaded {
-			t.Error("GlobalLoaded = true, want false (empty dir was at global position)")
-		}
-		// Second directory (local position) was loaded
-		if !result.LocalLoaded {
-			t.Error("LocalLoaded = false, want true (cue dir was at local position)")
+		// Only the directory with CUE files was loaded
+		if !slices.Equal(result.Dirs, []string{cueDir}) {
+			t.Errorf("Dirs = %v, want [%s]", result.Dirs, cueDir)
 		}
 	})
 
@@ -309,6 +299,29 @@ func TestLoader_LoadSingle(t *testing.T) {
 	})
 }
 
+func TestLoader_OverrideSettings(t *testing.T) {
+	t.Parallel()
+
+	l := NewLoader()
+	v := l.Context().CompileString(`agents: claude: command: "claude"`)
+
+	got, err := l.OverrideSettings(v, map[string]any{"timeout": 30})
+	if err != nil {
+		t.Fatalf("OverrideSettings() error = %v", err)
+	}
+	if n, _ := got.LookupPath(parsePath("settings.timeout")).Int64(); n != 30 {
+		t.Errorf("settings.timeout = %d, want 30", n)
+	}
+	if !got.LookupPath(parsePath("agents.claude.command")).Exists() {
+		t.Error("OverrideSettings() dropped agents")
+	}
+
+	same, err := l.OverrideSettings(v, nil)
+	if err != nil || !same.Equals(v) {
+		t.Errorf("OverrideSettings(nil) = %v, %v; want the input unchanged", same, err)
+	}
+}
+
 func TestLoader_Context(t *testing.T) {
 	t.Parallel()
 	l := NewLoader()
@@ -406,11 +419,8 @@ func TestLoader_MergeWithTestdataFixtures(t *testing.T) {
 	}
 
 	// Verify both directories loaded
-	if !result.GlobalLoaded {
-		t.Error("GlobalLoaded = false, want true")
-	}
-	if !result.LocalLoaded {
-		t.Error("LocalLoaded = false, want true")
+	if want := []string{globalDir, localDir}; !slices.Equal(result.Dirs, want) {
+		t.Errorf("Dirs = %v, want %v", result.Dirs, want)
 	}
 
 	// Verify agents from both sources exist (additive merge)
@@ -820,3 +830,35 @@ func writeCUEFile(t *testing.T, dir, name, content string) {
 func parsePath(path string) cuelib.Path {
 	return cuelib.ParsePath(path)
 }
+
+func TestLoadResult_Definitions(t *testing.T) {
+	t.Parallel()
+	globalDir := t.TempDir()
+	localDir := t.TempDir()
+
+	writeCUEFile(t, globalDir, "roles.cue", "roles: {\n\tdev: prompt: \"Global\"\n}\nsettings: timeout: 60\n")
+	writeCUEFile(t, localDir, "roles.cue", "\n\nroles: dev: prompt: \"Local\"\n")
+
+	result, err := NewLoader().Load([]string{globalDir, localDir})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	defs := result.Definitions(KeyRoles, "dev")
+	if len(defs) != 2 {
+		t.Fatalf("Definitions(roles, dev) = %+v, want 2", defs)
+	}
+	if defs[0].Dir != globalDir || defs[0].Line != 2 {
+		t.Errorf("overridden definition = %+v, want %s line 2", defs[0], globalDir)
+	}
+	if want := filepath.Join(localDir, "roles.cue") + ":3"; defs[1].Position() != want {
+		t.Errorf("effective Position() = %q, want %q", defs[1].Position(), want)
+	}
+
+	if defs := result.Definitions(KeySettings, "timeout"); len(defs) != 1 || defs[0].Line != 4 {
+		t.Errorf("Definitions(settings, timeout) = %+v", defs)
+	}
+	if defs := result.Definitions(KeyRoles, "missing"); defs != nil {
+		t.Errorf("Definitions(roles, missing) = %+v, want nil", defs)
+	}
+}
@@ -0,0 +1,78 @@
+package cue
+
+import (
+	"fmt"
+
+	"cuelang.org/go/cue"
+)
+
+// Definition is where a collection item or setting is defined.
+type Definition struct {
+	Dir  string `json:"dir"`
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// Position returns "file:line", "file", or the directory when the file is
+// unknown.
+func (d Definition) Position() string {
+	switch {
+	case d.File == "":
+		return d.Dir
+	case d.Line > 0:
+		return fmt.Sprintf("%s:%d", d.File, d.Line)
+	default:
+		return d.File
+	}
+}
+
+// defKey identifies an item or setting: key is the top-level key such as
+// "roles" or "settings", name the item or setting name.
+type defKey struct {
+	key  string
+	name string
+}
+
+// Definitions returns every definition of an item or setting in load order,
+// lowest priority first. The last definition is the effective one; earlier
+// ones were overridden, or merged into with merge: true.
+func (r LoadResult) Definitions(key, name string) []Definition {
+	return r.definitions[defKey{key, name}]
+}
+
+// DefinedNames returns the names defined under key in any loaded directory,
+// in the order first defined. Unlike the merged value, this includes items
+// dropped with enabled: false.
+func (r LoadResult) DefinedNames(key string) []string {
+	var names []string
+	for _, k := range r.definitionOrder {
+		if k.key == key {
+			names = append(names, k.name)
+		}
+	}
+	return names
+}
+
+// recordDefinitions records the position of every collection item and setting
+// defined in v, which was loaded from dir.
+func (r *LoadResult) recordDefinitions(dir string, v cue.Value) {
+	keys := []string{KeyAgents, KeyRoles, KeyContexts, KeyTasks, KeySettings}
+	for _, key := range keys {
+		iter, err := v.LookupPath(cue.ParsePath(key)).Fields()
+		if err != nil {
+			continue
+		}
+		for iter.Next() {
+			d := Definition{Dir: dir}
+			if pos := iter.Value().Pos(); pos.IsValid() {
+				d.File = pos.Filename()
+				d.Line = pos.Line()
+			}
+			k := defKey{key, iter.Selector().Unquoted()}
+			if _, seen := r.definitions[k]; !seen {
+				r.definitionOrder = append(r.definitionOrder, k)
+			}
+			r.definitions[k] = append(r.definitions[k], d)
+		}
+	}
+}
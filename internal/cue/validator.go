@@ -77,6 +77,7 @@ type ValidationError struct {
 	Column   int
 	Filename string
 	Context  string // Source context snippet around the error
+	Hint     string // Likely fix, such as a corrected line; empty if none
 }
 
 // Error returns a concise error string with file:line:message format.
@@ -121,5 +122,9 @@ func (e *ValidationError) DetailedError() string {
 		result += "\n" + e.Context
 	}
 
+	if e.Hint != "" {
+		result += "\n  Hint: " + e.Hint + "\n"
+	}
+
 	return result
 }
@@ -0,0 +1,240 @@
+// Package diff computes line-based differences and renders them as unified diffs.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op identifies the kind of change for a single line.
+type Op int
+
+const (
+	// Equal marks a line present in both inputs.
+	Equal Op = iota
+	// Delete marks a line present only in the old input.
+	Delete
+	// Insert marks a line present only in the new input.
+	Insert
+)
+
+// Edit is a single line in an edit script.
+type Edit struct {
+	Op   Op
+	Text string
+}
+
+// DefaultContext is the number of unchanged lines shown around each change.
+const DefaultContext = 3
+
+// Lines computes the shortest edit script that transforms a into b using
+// the Myers O(ND) algorithm.
+func Lines(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	total := n + m
+	if total == 0 {
+		return nil
+	}
+
+	// v[k+offset] holds the furthest x reached on diagonal k.
+	offset := total
+	v := make([]int, 2*total+2)
+	var trace [][]int
+
+	for d := 0; d <= total; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+				x = v[k+1+offset]
+			} else {
+				x = v[k-1+offset] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+offset] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, offset)
+			}
+		}
+	}
+
+	return backtrack(trace, a, b, offset)
+}
+
+// backtrack walks the Myers trace from the end to reconstruct the edit script.
+func backtrack(trace [][]int, a, b []string, offset int) []Edit {
+	x, y := len(a), len(b)
+	var edits []Edit
+
+	for d := len(trace) - 1; d >= 0 && (x > 0 || y > 0); d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[prevK+offset]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, Edit{Op: Equal, Text: a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				edits = append(edits, Edit{Op: Insert, Text: b[y]})
+			} else {
+				x--
+				edits = append(edits, Edit{Op: Delete, Text: a[x]})
+			}
+		}
+	}
+
+	// Reverse into forward order
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// HasChanges reports whether the edit script contains any insertions or deletions.
+func HasChanges(edits []Edit) bool {
+	for _, e := range edits {
+		if e.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// Hunk is a contiguous group of changes with surrounding context lines.
+type Hunk struct {
+	OldStart int // 1-based line number in the old input
+	OldLines int
+	NewStart int // 1-based line number in the new input
+	NewLines int
+	Edits    []Edit
+}
+
+// Header returns the unified diff hunk header (e.g., "@@ -1,3 +1,4 @@").
+func (h Hunk) Header() string {
+	return fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.OldStart, h.OldLines), hunkRange(h.NewStart, h.NewLines))
+}
+
+// hunkRange formats a hunk range, omitting the count when it is 1.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// An empty range refers to the line before the change
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// Hunks groups an edit script into hunks with the given number of context lines.
+// Changes separated by no more than 2*context unchanged lines share a hunk.
+func Hunks(edits []Edit, context int) []Hunk {
+	// Line numbers (1-based) at which each edit applies in the old and new inputs
+	oldNum := make([]int, len(edits))
+	newNum := make([]int, len(edits))
+	oldLine, newLine := 1, 1
+	for i, e := range edits {
+		oldNum[i] = oldLine
+		newNum[i] = newLine
+		if e.Op != Insert {
+			oldLine++
+		}
+		if e.Op != Delete {
+			newLine++
+		}
+	}
+
+	// Build merged [lo, hi] ranges of edits to include in each hunk
+	type span struct{ lo, hi int }
+	var spans []span
+	for i, e := range edits {
+		if e.Op == Equal {
+			continue
+		}
+		lo := max(i-context, 0)
+		hi := min(i+context, len(edits)-1)
+		if n := len(spans); n > 0 && lo <= spans[n-1].hi+1 {
+			spans[n-1].hi = hi
+			continue
+		}
+		spans = append(spans, span{lo, hi})
+	}
+
+	hunks := make([]Hunk, 0, len(spans))
+	for _, s := range spans {
+		h := Hunk{OldStart: oldNum[s.lo], NewStart: newNum[s.lo], Edits: edits[s.lo : s.hi+1]}
+		for _, e := range h.Edits {
+			if e.Op != Insert {
+				h.OldLines++
+			}
+			if e.Op != Delete {
+				h.NewLines++
+			}
+		}
+		hunks = append(hunks, h)
+	}
+	return hunks
+}
+
+// SplitLines splits text into lines without trailing newline characters.
+// An empty string yields no lines.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Unified renders a unified diff between two texts. Returns an empty string
+// when the texts are identical.
+func Unified(oldName, newName, oldText, newText string, context int) string {
+	edits := Lines(SplitLines(oldText), SplitLines(newText))
+	if !HasChanges(edits) {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n", oldName)
+	fmt.Fprintf(&sb, "+++ %s\n", newName)
+	for _, h := range Hunks(edits, context) {
+		sb.WriteString(h.Header())
+		sb.WriteString("\n")
+		for _, e := range h.Edits {
+			sb.WriteString(Prefix(e.Op))
+			sb.WriteString(e.Text)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// Prefix returns the unified diff line prefix for an operation.
+func Prefix(op Op) string {
+	switch op {
+	case Delete:
+		return "-"
+	case Insert:
+		return "+"
+	default:
+		return " "
+	}
+}
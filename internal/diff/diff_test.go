@@ -0,0 +1,147 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+// apply reconstructs both inputs from an edit script.
+func apply(edits []Edit) (oldLines, newLines []string) {
+	for _, e := range edits {
+		if e.Op != Insert {
+			oldLines = append(oldLines, e.Text)
+		}
+		if e.Op != Delete {
+			newLines = append(newLines, e.Text)
+		}
+	}
+	return oldLines, newLines
+}
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		a, b        []string
+		wantChanges int
+	}{
+		{"both empty", nil, nil, 0},
+		{"identical", []string{"a", "b"}, []string{"a", "b"}, 0},
+		{"insert only", nil, []string{"a", "b"}, 2},
+		{"delete only", []string{"a", "b"}, nil, 2},
+		{"replace middle", []string{"a", "b", "c"}, []string{"a", "x", "c"}, 2},
+		{"append", []string{"a"}, []string{"a", "b"}, 1},
+		{"classic", strings.Split("ABCABBA", ""), strings.Split("CBABAC", ""), 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			edits := Lines(tt.a, tt.b)
+
+			gotA, gotB := apply(edits)
+			if strings.Join(gotA, "\n") != strings.Join(tt.a, "\n") {
+				t.Errorf("old reconstruction = %v, want %v", gotA, tt.a)
+			}
+			if strings.Join(gotB, "\n") != strings.Join(tt.b, "\n") {
+				t.Errorf("new reconstruction = %v, want %v", gotB, tt.b)
+			}
+
+			changes := 0
+			for _, e := range edits {
+				if e.Op != Equal {
+					changes++
+				}
+			}
+			if changes != tt.wantChanges {
+				t.Errorf("changes = %d, want %d", changes, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestUnified(t *testing.T) {
+	t.Parallel()
+
+	t.Run("identical returns empty", func(t *testing.T) {
+		t.Parallel()
+		if got := Unified("a", "b", "same\n", "same\n", DefaultContext); got != "" {
+			t.Errorf("Unified() = %q, want empty", got)
+		}
+	})
+
+	t.Run("single change", func(t *testing.T) {
+		t.Parallel()
+		got := Unified("a/role.md", "b/role.md", "one\ntwo\nthree\n", "one\nTWO\nthree\n", DefaultContext)
+		want := `--- a/role.md
++++ b/role.md
+@@ -1,3 +1,3 @@
+ one
+-two
++TWO
+ three
+`
+		if got != want {
+			t.Errorf("Unified() =\n%s\nwant:\n%s", got, want)
+		}
+	})
+
+	t.Run("insert into empty", func(t *testing.T) {
+		t.Parallel()
+		got := Unified("a", "b", "", "new\n", DefaultContext)
+		if !strings.Contains(got, "@@ -0,0 +1 @@") {
+			t.Errorf("Unified() = %q, want empty-range header", got)
+		}
+	})
+}
+
+func TestHunks_SplitsDistantChanges(t *testing.T) {
+	t.Parallel()
+
+	var a, b []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		a = append(a, line)
+		b = append(b, line)
+	}
+	b[1] = "changed-1"
+	b[18] = "changed-18"
+
+	hunks := Hunks(Lines(a, b), 2)
+	if len(hunks) != 2 {
+		t.Fatalf("len(hunks) = %d, want 2", len(hunks))
+	}
+	if got := hunks[0].Header(); got != "@@ -1,4 +1,4 @@" {
+		t.Errorf("hunks[0].Header() = %q", got)
+	}
+	if got := hunks[1].Header(); got != "@@ -17,4 +17,4 @@" {
+		t.Errorf("hunks[1].Header() = %q", got)
+	}
+}
+
+func TestHunks_MergesNearbyChanges(t *testing.T) {
+	t.Parallel()
+
+	a := []string{"1", "2", "3", "4", "5", "6", "7", "8"}
+	b := []string{"1", "X", "3", "4", "5", "6", "Y", "8"}
+
+	hunks := Hunks(Lines(a, b), 2)
+	if len(hunks) != 1 {
+		t.Fatalf("len(hunks) = %d, want 1", len(hunks))
+	}
+	if got := hunks[0].Header(); got != "@@ -1,8 +1,8 @@" {
+		t.Errorf("Header() = %q", got)
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	t.Parallel()
+
+	if got := SplitLines(""); got != nil {
+		t.Errorf("SplitLines(\"\") = %v, want nil", got)
+	}
+	if got := SplitLines("a\nb\n"); len(got) != 2 {
+		t.Errorf("SplitLines trailing newline = %v, want 2 lines", got)
+	}
+}
@@ -1,6 +1,7 @@
 package doctor
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -14,6 +15,9 @@ import (
 	"github.com/grantcarthew/start/internal/cache"
 	"github.com/grantcarthew/start/internal/config"
 	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/secrets"
+	"github.com/grantcarthew/start/internal/suggest"
+	"github.com/grantcarthew/start/internal/trust"
 )
 
 // CheckIntro returns the intro section with repository info.
@@ -65,16 +69,55 @@ func CheckVersion(info BuildInfo) SectionResult {
 func CheckConfiguration(paths config.Paths) SectionResult {
 	section := SectionResult{Name: "Configuration"}
 
+	// Check shared layers beneath global config
+	for _, layer := range paths.Shared() {
+		scope := strings.ToUpper(layer.Source[:1]) + layer.Source[1:]
+		section.Results = append(section.Results, checkConfigDir(layer.Dir, scope, true)...)
+	}
+
 	// Check global config
 	globalResults := checkConfigDir(paths.Global, "Global", paths.GlobalExists)
 	section.Results = append(section.Results, globalResults...)
+	if paths.GlobalExists {
+		section.Results = append(section.Results, checkConfigVersion(paths.Global)...)
+	}
+
+	// Check local config layers, outermost first
+	var layers []string
+	for _, dir := range paths.Locals() {
+		if dir != paths.Personal {
+			layers = append(layers, dir)
+		}
+	}
+	if len(layers) == 0 {
+		section.Results = append(section.Results, checkConfigDir(paths.Local, "Local", false)...)
+	}
+	for i, dir := range layers {
+		scope := "Local"
+		if len(layers) > 1 {
+			scope = fmt.Sprintf("Local layer %d", i+1)
+		}
+		section.Results = append(section.Results, checkConfigDir(dir, scope, true)...)
+		section.Results = append(section.Results, checkConfigVersion(dir)...)
+	}
 
-	// Check local config
-	localResults := checkConfigDir(paths.Local, "Local", paths.LocalExists)
-	section.Results = append(section.Results, localResults...)
+	// Check the personal overlay, which should never be committed
+	if paths.PersonalExists {
+		section.Results = append(section.Results, checkConfigDir(paths.Personal, "Personal", true)...)
+		section.Results = append(section.Results, checkConfigVersion(paths.Personal)...)
+		if _, err := os.Stat(filepath.Join(paths.Personal, ".gitignore")); err != nil {
+			section.Results = append(section.Results, CheckResult{
+				Status:  StatusWarn,
+				Label:   "Not gitignored",
+				Message: "personal config may be committed",
+				Indent:  1,
+				Fix:     fmt.Sprintf("Add a .gitignore containing * to %s", shortenPath(paths.Personal)),
+			})
+		}
+	}
 
-	// If both exist, try to load and merge
-	if paths.GlobalExists || paths.LocalExists {
+	// If any exist, try to load and merge
+	if paths.AnyExists() {
 		loader := internalcue.NewLoader()
 		dirs := paths.ForScope(config.ScopeMerged)
 		_, err := loader.Load(dirs)
@@ -97,6 +140,33 @@ func CheckConfiguration(paths config.Paths) SectionResult {
 	return section
 }
 
+// checkConfigVersion warns when migrations would change the config files in
+// dir. A directory on an old format version with nothing to migrate passes
+// silently.
+func checkConfigVersion(dir string) []CheckResult {
+	if _, err := config.DirVersion(dir); err != nil {
+		return []CheckResult{{
+			Status:  StatusWarn,
+			Label:   "Format version",
+			Message: err.Error(),
+			Indent:  1,
+			Fix:     fmt.Sprintf("Set version to an integer in %s", config.VersionFile),
+		}}
+	}
+	plan, err := config.PlanMigration(dir)
+	if err != nil || !plan.Pending() {
+		// Syntax errors are reported by checkConfigDir.
+		return nil
+	}
+	return []CheckResult{{
+		Status:  StatusWarn,
+		Label:   fmt.Sprintf("Format version %d", plan.From),
+		Message: fmt.Sprintf("migrations pending (current %d)", config.CurrentVersion),
+		Indent:  1,
+		Fix:     "Run 'start config migrate' (preview with --dry-run)",
+	}}
+}
+
 // checkConfigDir checks a single configuration directory.
 func checkConfigDir(dir, scope string, exists bool) []CheckResult {
 	var results []CheckResult
@@ -156,13 +226,17 @@ func checkConfigDir(dir, scope string, exists bool) []CheckResult {
 	loader := internalcue.NewLoader()
 	_, err = loader.LoadSingle(dir)
 	if err != nil {
+		fix := "Fix CUE syntax errors in this directory"
+		if ve := internalcue.FormatErrorWithContext(err); ve != nil && ve.Hint != "" {
+			fix = fmt.Sprintf("%s: %s", ve.Error(), ve.Hint)
+		}
 		results = append(results, header)
 		results = append(results, CheckResult{
 			Status:  StatusFail,
 			Label:   "Invalid",
 			Message: fmt.Sprintf("%v", err),
 			Indent:  1,
-			Fix:     "Fix CUE syntax errors in this directory",
+			Fix:     fix,
 		})
 	} else {
 		results = append(results, header)
@@ -418,11 +492,15 @@ func checkTaskRole(taskVal cue.Value, taskName string, cfgValue cue.Value) *Chec
 		}
 	}
 
+	fix := fmt.Sprintf("Add %q to roles or fix the reference in task %q", roleName, taskName)
+	if s := suggest.Closest(roleName, fieldNames(roles)); s != "" {
+		fix = fmt.Sprintf("Did you mean %q? Fix the reference in task %q", s, taskName)
+	}
 	return &CheckResult{
 		Status:  StatusWarn,
 		Label:   fmt.Sprintf("role %q", roleName),
 		Message: "not found in roles config",
-		Fix:     fmt.Sprintf("Add %q to roles or fix the reference in task %q", roleName, taskName),
+		Fix:     fix,
 		Indent:  1,
 	}
 }
@@ -496,11 +574,11 @@ func checkFileField(v cue.Value, name string) *CheckResult {
 }
 
 // CheckSettings resolves and displays all settings with their sources,
-// and validates specific settings (default_agent, shell).
+// and validates specific settings (default_agent, shell, secret_policy).
 func CheckSettings(paths config.Paths, cfgValue cue.Value) SectionResult {
 	section := SectionResult{Name: "Settings"}
 
-	entries, err := config.ResolveAllSettings(paths, false)
+	entries, err := config.ResolveAllSettings(paths, config.ScopeMerged)
 	if err != nil {
 		section.Results = append(section.Results, CheckResult{
 			Status:  StatusWarn,
@@ -529,6 +607,7 @@ func CheckSettings(paths config.Paths, cfgValue cue.Value) SectionResult {
 }
 
 // settingResult creates a CheckResult for a setting with its value and source.
+// A value that fails the settings registry validation is a failure.
 func settingResult(key string, entry config.SettingEntry) CheckResult {
 	if entry.Source == "not set" {
 		return CheckResult{
@@ -537,11 +616,16 @@ func settingResult(key string, entry config.SettingEntry) CheckResult {
 			Message: "(not set)",
 		}
 	}
-	return CheckResult{
+	result := CheckResult{
 		Status:  StatusPass,
 		Label:   key,
 		Message: fmt.Sprintf("%s (%s)", entry.Value, entry.Source),
 	}
+	if err := config.ValidateSetting(key, entry.Value); err != nil && entry.Source != "default" {
+		result.Status = StatusFail
+		result.Fix = fmt.Sprintf("%v; update settings.%s", err, key)
+	}
+	return result
 }
 
 // checkDefaultAgent validates the default_agent setting.
@@ -580,11 +664,15 @@ func checkDefaultAgent(entry config.SettingEntry, cfgValue cue.Value) CheckResul
 		}
 	}
 
+	fix := fmt.Sprintf("Agent %q not found in config; check spelling or add it to agents", entry.Value)
+	if s := suggest.Closest(entry.Value, fieldNames(agents)); s != "" {
+		fix = fmt.Sprintf("Agent %q not found in config; did you mean %q?", entry.Value, s)
+	}
 	return CheckResult{
 		Status:  StatusWarn,
 		Label:   "default_agent",
 		Message: message,
-		Fix:     fmt.Sprintf("Agent %q not found in config; check spelling or add it to agents", entry.Value),
+		Fix:     fix,
 	}
 }
 
@@ -623,6 +711,226 @@ func sortedKeys[V any](m map[string]V) []string {
 	return keys
 }
 
+// CheckSecrets reports secrets detected in the default composition (required
+// and default contexts with the default role). composeErr is set when the
+// composition could not be built.
+func CheckSecrets(policy secrets.Policy, findings []secrets.Finding, composeErr error) SectionResult {
+	section := SectionResult{Name: "Secrets"}
+
+	if composeErr != nil {
+		section.Results = append(section.Results, CheckResult{
+			Status:  StatusWarn,
+			Label:   "Error",
+			Message: fmt.Sprintf("cannot scan default composition: %v", composeErr),
+		})
+		return section
+	}
+
+	if len(findings) == 0 {
+		section.Results = append(section.Results, CheckResult{
+			Status:  StatusPass,
+			Label:   "No secrets detected",
+			Message: fmt.Sprintf("(secret_policy: %s)", policy),
+		})
+		return section
+	}
+
+	// A blocking policy means start will refuse to launch.
+	status := StatusWarn
+	fix := "Remove the secret from the source, or set secret_policy to redact"
+	if policy == secrets.PolicyBlock {
+		status = StatusFail
+		fix = "Remove the secret from the source; launches are blocked until then"
+	}
+	for _, f := range findings {
+		section.Results = append(section.Results, CheckResult{
+			Status:  status,
+			Label:   f.Source,
+			Message: fmt.Sprintf("%s at line %d (%s)", f.Rule, f.Line, f.Preview),
+			Fix:     fix,
+		})
+	}
+	section.Summary = fmt.Sprintf("%d found, secret_policy: %s", len(findings), policy)
+
+	return section
+}
+
+// CheckTrust reports whether the local config may run its commands.
+func CheckTrust(paths config.Paths) SectionResult {
+	section := SectionResult{Name: "Trust"}
+
+	if !paths.LocalExists {
+		section.Results = append(section.Results, CheckResult{
+			Status: StatusInfo,
+			Label:  "No local config",
+		})
+		return section
+	}
+
+	store, err := trust.LoadDefault()
+	for _, dir := range paths.Locals() {
+		section.Results = append(section.Results, checkLayerTrust(store, err, dir, paths.Global))
+	}
+	return section
+}
+
+// checkLayerTrust reports the trust status of a single local config directory.
+func checkLayerTrust(store *trust.Store, storeErr error, dir, globalDir string) CheckResult {
+	cannotCheck := func(err error) CheckResult {
+		return CheckResult{
+			Status:  StatusWarn,
+			Label:   shortenPath(dir),
+			Message: fmt.Sprintf("cannot check trust: %v", err),
+		}
+	}
+
+	if storeErr != nil {
+		return cannotCheck(storeErr)
+	}
+	status, err := store.Check(dir)
+	if err != nil {
+		return cannotCheck(err)
+	}
+	if status == trust.StatusTrusted {
+		return CheckResult{
+			Status:  StatusPass,
+			Label:   shortenPath(dir),
+			Message: "trusted",
+		}
+	}
+
+	commands, err := trust.LocalCommands(dir, globalDir)
+	if err != nil {
+		return cannotCheck(err)
+	}
+	return trustResult(dir, status, commands)
+}
+
+// trustResult describes an untrusted or changed local config.
+func trustResult(dir string, status trust.Status, commands []trust.Command) CheckResult {
+	if len(commands) == 0 {
+		return CheckResult{
+			Status:  StatusPass,
+			Label:   shortenPath(dir),
+			Message: fmt.Sprintf("%s (no commands)", status),
+		}
+	}
+
+	details := make([]string, 0, len(commands))
+	for _, c := range commands {
+		details = append(details, c.String())
+	}
+	return CheckResult{
+		Status:  StatusWarn,
+		Label:   shortenPath(dir),
+		Message: fmt.Sprintf("%s, %d command(s) will not run", status, len(commands)),
+		Fix:     "Review the commands and run 'start trust'",
+		Details: details,
+	}
+}
+
+// CheckPolicy reports the organisation policy: each locked setting and any
+// config that tries to override it, and configured agents whose command a
+// deny rule refuses.
+func CheckPolicy(paths config.Paths, cfgValue cue.Value) SectionResult {
+	section := SectionResult{Name: "Policy"}
+
+	policy, err := config.LoadPolicy(paths)
+	if err != nil {
+		section.Results = append(section.Results, CheckResult{
+			Status:  StatusFail,
+			Label:   "Policy",
+			Message: err.Error(),
+			Fix:     "Fix the policy files; agents cannot launch until the policy loads",
+		})
+		return section
+	}
+	if policy.Empty() {
+		section.Results = append(section.Results, CheckResult{
+			Status: StatusInfo,
+			Label:  "No policy",
+		})
+		return section
+	}
+	for _, dir := range policy.Dirs {
+		section.Results = append(section.Results, CheckResult{
+			Status:  StatusInfo,
+			Label:   "Path",
+			Message: shortenPath(dir),
+		})
+	}
+
+	violations, err := config.PolicyViolations(paths, policy)
+	if err != nil {
+		section.Results = append(section.Results, CheckResult{
+			Status:  StatusWarn,
+			Label:   "Locked",
+			Message: fmt.Sprintf("cannot check config against the policy: %v", err),
+		})
+	}
+	for _, key := range sortedKeys(policy.Locked) {
+		result := CheckResult{
+			Status:  StatusPass,
+			Label:   key,
+			Message: fmt.Sprintf("locked to %q", policy.Locked[key].Value),
+		}
+		for _, v := range violations {
+			if v.Key == key {
+				result.Details = append(result.Details, v.String())
+			}
+		}
+		if len(result.Details) > 0 {
+			result.Status = StatusWarn
+			result.Message += fmt.Sprintf(", overridden in %d place(s) and ignored", len(result.Details))
+			result.Fix = fmt.Sprintf("Remove the setting with 'start config settings %s --unset' or unset %s", key, config.SettingEnvVar(key))
+		}
+		section.Results = append(section.Results, result)
+	}
+
+	if len(policy.Deny) > 0 {
+		section.Results = append(section.Results, checkDeniedAgents(policy, cfgValue)...)
+	}
+	section.Summary = fmt.Sprintf("%d locked, %d deny rule(s)", len(policy.Locked), len(policy.Deny))
+
+	return section
+}
+
+// checkDeniedAgents reports configured agents whose command template matches
+// a deny rule. The launch check also covers the substituted bin and model.
+func checkDeniedAgents(policy config.Policy, cfgValue cue.Value) []CheckResult {
+	var results []CheckResult
+	agents := cfgValue.LookupPath(cue.ParsePath(internalcue.KeyAgents))
+	if iter, err := agents.Fields(); err == nil {
+		for iter.Next() {
+			command, err := iter.Value().LookupPath(cue.ParsePath("command")).String()
+			if err != nil {
+				continue
+			}
+			var denied *config.PolicyDeniedError
+			if errors.As(policy.CheckCommand(command), &denied) {
+				result := CheckResult{
+					Status:  StatusFail,
+					Label:   iter.Selector().Unquoted(),
+					Message: fmt.Sprintf("command denied by policy: matches %q", denied.Rule.Pattern),
+					Fix:     "Change the agent command; it will not launch",
+				}
+				if denied.Rule.Reason != "" {
+					result.Details = []string{denied.Rule.Reason}
+				}
+				results = append(results, result)
+			}
+		}
+	}
+	if len(results) == 0 {
+		results = append(results, CheckResult{
+			Status:  StatusPass,
+			Label:   "Deny rules",
+			Message: "no configured agent command matches",
+		})
+	}
+	return results
+}
+
 // CheckEnvironment validates runtime environment.
 func CheckEnvironment(paths config.Paths) SectionResult {
 	section := SectionResult{Name: "Environment"}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"cuelang.org/go/cue"
@@ -14,6 +15,7 @@ import (
 	"cuelang.org/go/mod/modconfig"
 	"github.com/grantcarthew/start/internal/config"
 	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/suggest"
 )
 
 // SchemaSet holds parsed CUE schema definitions for validation.
@@ -69,21 +71,10 @@ type categorySchema struct {
 // CheckSchemaValidation validates config files against CUE schemas.
 func CheckSchemaValidation(paths config.Paths, schemas SchemaSet) SectionResult {
 	section := SectionResult{Name: "Schema Validation"}
+	categories := schemas.categories()
 
-	categories := []categorySchema{
-		{key: internalcue.KeyAgents, schema: schemas.Agent, isMap: true},
-		{key: internalcue.KeyRoles, schema: schemas.Role, isMap: true},
-		{key: internalcue.KeyContexts, schema: schemas.Context, isMap: true},
-		{key: internalcue.KeyTasks, schema: schemas.Task, isMap: true},
-		{key: internalcue.KeySettings, schema: schemas.Settings, isMap: false},
-	}
-
-	if paths.GlobalExists {
-		results := validateConfigDir(paths.Global, categories)
-		section.Results = append(section.Results, results...)
-	}
-	if paths.LocalExists {
-		results := validateConfigDir(paths.Local, categories)
+	for _, dir := range paths.ForScope(config.ScopeMerged) {
+		results := validateConfigDir(dir, categories)
 		section.Results = append(section.Results, results...)
 	}
 
@@ -136,8 +127,194 @@ func validateSingleFile(cctx *cue.Context, filePath string, categories []categor
 		return results
 	}
 
+	issues, hasKeys := validateValue(v, categories)
+	for _, issue := range issues {
+		results = append(results, CheckResult{
+			Status:  StatusWarn,
+			Label:   fileName,
+			Message: fmt.Sprintf("%s: %s", issue.path(), internalcue.ErrorSummary(issue.err)),
+			Fix:     issue.fix(),
+		})
+	}
+
+	// Extra fields are allowed; only those close to a known field are
+	// reported, as likely misspellings.
+	typos := 0
+	for _, field := range unknownFields(v, categories) {
+		if field.Suggestion == "" {
+			continue
+		}
+		typos++
+		results = append(results, CheckResult{
+			Status:  StatusWarn,
+			Label:   fileName,
+			Message: fmt.Sprintf("%s: unknown field %q", SchemaIssue{Key: field.Key, Name: field.Name}.path(), field.Field),
+			Fix:     fmt.Sprintf("Did you mean %q?", field.Suggestion),
+		})
+	}
+
+	if hasKeys && len(issues) == 0 && typos == 0 {
+		results = append(results, CheckResult{
+			Status: StatusPass,
+			Label:  fileName,
+		})
+	}
+
+	return results
+}
+
+// SchemaIssue is a config item or settings block that fails schema validation.
+type SchemaIssue struct {
+	Key  string // Top-level key (e.g. "agents" or "settings")
+	Name string // Item name; empty for settings
+	err  error
+}
+
+// path returns the config path of the issue, e.g. "agents.claude".
+func (i SchemaIssue) path() string {
+	if i.Name == "" {
+		return i.Key
+	}
+	return i.Key + "." + i.Name
+}
+
+// fix returns the suggested fix for the issue.
+func (i SchemaIssue) fix() string {
+	if i.Name == "" {
+		return fmt.Sprintf("Check %s fields match the schema", i.Key)
+	}
+	return fmt.Sprintf("Check %s.%s fields match the %s schema", i.Key, i.Name, i.Key)
+}
+
+// Error returns the issue as "path: summary".
+func (i SchemaIssue) Error() string {
+	return fmt.Sprintf("%s: %s", i.path(), internalcue.ErrorSummary(i.err))
+}
+
+// Validate checks each item and the settings in a config value against the
+// schemas. Extra fields are allowed, as for config files.
+func (s SchemaSet) Validate(v cue.Value) []SchemaIssue {
+	issues, _ := validateValue(v, s.categories())
+	return issues
+}
+
+// UnknownField is a field of a config item or of settings that the schemas
+// do not declare, such as a misspelled "requried".
+type UnknownField struct {
+	Key        string // Top-level key (e.g. "contexts" or "settings")
+	Name       string // Item name; empty for settings
+	Field      string // The undeclared field
+	Suggestion string // Closest declared field, if any
+}
+
+// Error returns the field as "path: unknown field" with any suggestion.
+func (f UnknownField) Error() string {
+	msg := fmt.Sprintf("%s: unknown field %q", SchemaIssue{Key: f.Key, Name: f.Name}.path(), f.Field)
+	if f.Suggestion != "" {
+		msg += fmt.Sprintf("; did you mean %q?", f.Suggestion)
+	}
+	return msg
+}
+
+// cliItemFields lists the item fields, by top-level key, that the CLI reads
+// but the asset schemas do not declare.
+var cliItemFields = map[string][]string{
+	internalcue.KeyAgents: {"instructions_file"},
+}
+
+// UnknownFields returns the top-level fields of each item, and the settings
+// keys, that neither the schemas nor the CLI declare. Item directives
+// (extends, merge, enabled), CLI item fields such as an agent's
+// instructions_file, and registered settings are always known.
+func (s SchemaSet) UnknownFields(v cue.Value) []UnknownField {
+	return unknownFields(v, s.categories())
+}
+
+// unknownFields returns the undeclared fields of v for each category.
+func unknownFields(v cue.Value, categories []categorySchema) []UnknownField {
+	var unknown []UnknownField
+	for _, cat := range categories {
+		if !cat.schema.Exists() {
+			continue
+		}
+		topLevel := v.LookupPath(cue.ParsePath(cat.key))
+		if !topLevel.Exists() {
+			continue
+		}
+
+		known := fieldNames(cat.schema)
+		if !cat.isMap {
+			known = append(known, config.SettingKeys()...)
+			unknown = append(unknown, undeclaredFields(topLevel, cat.schema, cat.key, "", known)...)
+			continue
+		}
+		known = append(known, internalcue.FieldExtends, internalcue.FieldMerge, internalcue.FieldEnabled)
+		known = append(known, cliItemFields[cat.key]...)
+
+		iter, err := topLevel.Fields()
+		if err != nil {
+			continue
+		}
+		for iter.Next() {
+			unknown = append(unknown, undeclaredFields(iter.Value(), cat.schema, cat.key, iter.Selector().Unquoted(), known)...)
+		}
+	}
+	return unknown
+}
+
+// fieldNames returns the regular field names of a struct value, including
+// the optional fields a schema declares.
+func fieldNames(v cue.Value) []string {
+	var names []string
+	iter, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return nil
+	}
+	for iter.Next() {
+		names = append(names, iter.Selector().Unquoted())
+	}
+	return names
+}
+
+// undeclaredFields returns the fields of v that schema does not allow and
+// that are not in known, which also supplies the suggestions.
+func undeclaredFields(v, schema cue.Value, key, name string, known []string) []UnknownField {
+	var unknown []UnknownField
+	iter, err := v.Fields()
+	if err != nil {
+		return nil
+	}
+	for iter.Next() {
+		field := iter.Selector().Unquoted()
+		if slices.Contains(known, field) || schema.Allows(iter.Selector()) {
+			continue
+		}
+		unknown = append(unknown, UnknownField{
+			Key:        key,
+			Name:       name,
+			Field:      field,
+			Suggestion: suggest.Closest(field, known),
+		})
+	}
+	return unknown
+}
+
+// categories returns the schema for each top-level config key.
+func (s SchemaSet) categories() []categorySchema {
+	return []categorySchema{
+		{key: internalcue.KeyAgents, schema: s.Agent, isMap: true},
+		{key: internalcue.KeyRoles, schema: s.Role, isMap: true},
+		{key: internalcue.KeyContexts, schema: s.Context, isMap: true},
+		{key: internalcue.KeyTasks, schema: s.Task, isMap: true},
+		{key: internalcue.KeySettings, schema: s.Settings, isMap: false},
+	}
+}
+
+// validateValue validates the top-level keys of v against their schemas.
+// Returns the issues found and whether v has any recognised keys.
+func validateValue(v cue.Value, categories []categorySchema) ([]SchemaIssue, bool) {
+	var issues []SchemaIssue
 	var hasKeys bool
-	var hasErrors bool
 
 	for _, cat := range categories {
 		if !cat.schema.Exists() {
@@ -155,13 +332,7 @@ func validateSingleFile(cctx *cue.Context, filePath string, categories []categor
 			// Settings: validate as a single struct.
 			unified := cat.schema.Unify(topLevel)
 			if err := filterAllowedFieldErrors(unified.Validate()); err != nil {
-				hasErrors = true
-				results = append(results, CheckResult{
-					Status:  StatusWarn,
-					Label:   fileName,
-					Message: fmt.Sprintf("%s: %s", cat.key, internalcue.ErrorSummary(err)),
-					Fix:     fmt.Sprintf("Check %s fields match the schema", cat.key),
-				})
+				issues = append(issues, SchemaIssue{Key: cat.key, err: err})
 			}
 			continue
 		}
@@ -174,29 +345,14 @@ func validateSingleFile(cctx *cue.Context, filePath string, categories []categor
 
 		for iter.Next() {
 			entryName := iter.Selector().Unquoted()
-			entryValue := iter.Value()
-
-			unified := cat.schema.Unify(entryValue)
+			unified := cat.schema.Unify(iter.Value())
 			if err := filterAllowedFieldErrors(unified.Validate()); err != nil {
-				hasErrors = true
-				results = append(results, CheckResult{
-					Status:  StatusWarn,
-					Label:   fileName,
-					Message: fmt.Sprintf("%s.%s: %s", cat.key, entryName, internalcue.ErrorSummary(err)),
-					Fix:     fmt.Sprintf("Check %s.%s fields match the %s schema", cat.key, entryName, cat.key),
-				})
+				issues = append(issues, SchemaIssue{Key: cat.key, Name: entryName, err: err})
 			}
 		}
 	}
 
-	if hasKeys && !hasErrors {
-		results = append(results, CheckResult{
-			Status: StatusPass,
-			Label:  fileName,
-		})
-	}
-
-	return results
+	return issues, hasKeys
 }
 
 // filterAllowedFieldErrors removes "field not allowed" errors from CUE validation
@@ -229,6 +229,30 @@ agents: "myagent": {
 	}
 }
 
+func TestCheckSchemaValidation_MisspelledField(t *testing.T) {
+	t.Parallel()
+	schemas := testSchemaSet(t)
+	tmpDir := t.TempDir()
+
+	writeConfigFile(t, tmpDir, "contexts.cue", `
+contexts: readme: {
+	file: "README.md"
+	requried: true
+	custom_field: "allowed"
+}
+`)
+
+	section := CheckSchemaValidation(config.Paths{Global: tmpDir, GlobalExists: true}, schemas)
+
+	if len(section.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d: %+v", len(section.Results), section.Results)
+	}
+	r := section.Results[0]
+	if r.Status != StatusWarn || r.Message != `contexts.readme: unknown field "requried"` || r.Fix != `Did you mean "required"?` {
+		t.Errorf("result = %+v, want misspelling warning", r)
+	}
+}
+
 func TestCheckSchemaValidation_InvalidSettings(t *testing.T) {
 	t.Parallel()
 	schemas := testSchemaSet(t)
@@ -519,3 +543,51 @@ agents: {
 		t.Errorf("expected StatusWarn for agents.bad, got results: %+v", section.Results)
 	}
 }
+
+func TestSchemaSet_Validate(t *testing.T) {
+	t.Parallel()
+	schemas := testSchemaSet(t)
+
+	v := cuecontext.New().CompileString(`
+agents: {
+	good: command: "good-cmd"
+	bad: {command: "", extra: "allowed"}
+}
+settings: timeout: 0
+`)
+	issues := schemas.Validate(v)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %v", len(issues), issues)
+	}
+	if issues[0].Key != "agents" || issues[0].Name != "bad" || !strings.HasPrefix(issues[0].Error(), "agents.bad: ") {
+		t.Errorf("issues[0] = %v, want agents.bad", issues[0])
+	}
+	if issues[1].Key != "settings" || issues[1].Name != "" {
+		t.Errorf("issues[1] = %v, want settings", issues[1])
+	}
+}
+
+func TestSchemaSet_UnknownFields(t *testing.T) {
+	t.Parallel()
+	schemas := testSchemaSet(t)
+
+	v := cuecontext.New().CompileString(`
+agents: claude: {command: "claude", bni: "claude", extends: "base", instructions_file: "AGENTS.md"}
+contexts: readme: {file: "README.md", requried: true, enabled: false}
+settings: {timeout: 30, secret_policy: "warn", strcit: true}
+`)
+	unknown := schemas.UnknownFields(v)
+	want := []string{
+		`agents.claude: unknown field "bni"; did you mean "bin"?`,
+		`contexts.readme: unknown field "requried"; did you mean "required"?`,
+		`settings: unknown field "strcit"; did you mean "strict"?`,
+	}
+	if len(unknown) != len(want) {
+		t.Fatalf("UnknownFields() = %v, want %d fields", unknown, len(want))
+	}
+	for i, f := range unknown {
+		if f.Error() != want[i] {
+			t.Errorf("unknown[%d] = %q, want %q", i, f.Error(), want[i])
+		}
+	}
+}
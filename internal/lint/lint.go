@@ -0,0 +1,385 @@
+// Package lint checks configuration for mistakes that load without error but
+// misbehave at runtime: misspelled template placeholders render empty,
+// fields nothing references are ignored, and tasks without {{.instructions}}
+// drop the instructions given on the command line.
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/orchestration"
+	"github.com/grantcarthew/start/internal/suggest"
+)
+
+// Severity is the importance of an issue.
+type Severity string
+
+const (
+	// SeverityError is a mistake that produces wrong output.
+	SeverityError Severity = "error"
+	// SeverityWarning is likely a mistake.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo is worth knowing but often intended.
+	SeverityInfo Severity = "info"
+)
+
+// Rule names identify the kind of issue.
+const (
+	RuleTemplateSyntax      = "template-syntax"
+	RuleUnknownPlaceholder  = "unknown-placeholder"
+	RuleUnusedField         = "unused-field"
+	RuleMissingInstructions = "missing-instructions"
+	RuleUnknownRole         = "unknown-role"
+	RuleUnreachableRole     = "unreachable-role"
+)
+
+// Issue is a single lint finding.
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Kind     string   `json:"kind"` // "agent", "role", "context", or "task"
+	Name     string   `json:"name"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Position returns "file:line", "file", or "" when unknown.
+func (i Issue) Position() string {
+	if i.File == "" {
+		return ""
+	}
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d", i.File, i.Line)
+	}
+	return i.File
+}
+
+// HasFailures reports whether any issue is an error or warning.
+func HasFailures(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Severity != SeverityInfo {
+			return true
+		}
+	}
+	return false
+}
+
+// Config is the configuration to lint.
+type Config struct {
+	// Value is the merged configuration.
+	Value cue.Value
+	// Sources are the per-directory configurations, highest priority first.
+	// Merged values lose file positions, so positions are looked up here.
+	Sources []cue.Value
+}
+
+// kinds maps collection keys to item kinds, in check order.
+var kinds = []struct {
+	key  string
+	kind string
+}{
+	{internalcue.KeyAgents, "agent"},
+	{internalcue.KeyRoles, "role"},
+	{internalcue.KeyContexts, "context"},
+	{internalcue.KeyTasks, "task"},
+}
+
+// Check lints every agent command and role, context, and task template.
+func Check(cfg Config) []Issue {
+	var issues []Issue
+	for _, k := range kinds {
+		items := cfg.Value.LookupPath(cue.ParsePath(k.key))
+		if !items.Exists() {
+			continue
+		}
+		iter, err := items.Fields()
+		if err != nil {
+			continue
+		}
+		for iter.Next() {
+			it := item{
+				cfg:  cfg,
+				key:  k.key,
+				kind: k.kind,
+				name: iter.Selector().Unquoted(),
+				val:  iter.Value(),
+			}
+			if k.kind == "agent" {
+				issues = append(issues, it.checkAgent()...)
+			} else {
+				issues = append(issues, it.checkUTD()...)
+			}
+		}
+	}
+	issues = append(issues, checkRoles(cfg)...)
+	return issues
+}
+
+// item is a config item being linted.
+type item struct {
+	cfg  Config
+	key  string
+	kind string
+	name string
+	val  cue.Value
+}
+
+// issue builds an issue for this item.
+func (it item) issue(sev Severity, rule, file string, line int, format string, args ...any) Issue {
+	return Issue{
+		Severity: sev,
+		Rule:     rule,
+		Kind:     it.kind,
+		Name:     it.name,
+		File:     file,
+		Line:     line,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// fieldPos returns the file and line where a field of this item is defined
+// (the item itself when field is empty), and whether the field is a
+// multi-line string whose content starts on the following line.
+func (it item) fieldPos(field string) (file string, line int, multiline bool) {
+	selectors := []cue.Selector{cue.Str(it.key), cue.Str(it.name)}
+	if field != "" {
+		selectors = append(selectors, cue.Str(field))
+	}
+	for _, src := range it.cfg.Sources {
+		v := src.LookupPath(cue.MakePath(selectors...))
+		if !v.Exists() {
+			continue
+		}
+		pos := v.Pos()
+		if !pos.IsValid() {
+			return "", 0, false
+		}
+		node := v.Source()
+		if f, ok := node.(*ast.Field); ok {
+			node = f.Value
+		}
+		if lit, ok := node.(*ast.BasicLit); ok {
+			multiline = strings.HasPrefix(lit.Value, `"""`) || strings.HasPrefix(lit.Value, `#"""`)
+		}
+		return pos.Filename(), pos.Line(), multiline
+	}
+	return "", 0, false
+}
+
+// templateSource is a template to lint and where its text is defined.
+type templateSource struct {
+	text  string
+	file  string
+	line  int    // Line of the first template line in file
+	field string // Config field name, or "" for file content
+}
+
+// lineAt returns the file line of a byte offset in the template.
+func (ts templateSource) lineAt(offset int) int {
+	if ts.line == 0 {
+		return 0
+	}
+	l, _ := lineOf(ts.text, offset)
+	return ts.line + l - 1
+}
+
+// fieldTemplate returns the template source for a string field of this item.
+func (it item) fieldTemplate(field, text string) templateSource {
+	file, line, multiline := it.fieldPos(field)
+	if multiline {
+		line++
+	}
+	return templateSource{text: text, file: file, line: line, field: field}
+}
+
+// checkAgent lints an agent's command template.
+func (it item) checkAgent() []Issue {
+	v := it.val.LookupPath(cue.ParsePath("command"))
+	command, err := v.String()
+	if err != nil || command == "" {
+		return nil
+	}
+	src := it.fieldTemplate("command", command)
+	refs, err := parsePlaceholders(src.text)
+	if err != nil {
+		return []Issue{it.syntaxIssue(src, err)}
+	}
+	return it.checkPlaceholders(src, refs, orchestration.AgentPlaceholders)
+}
+
+// checkUTD lints the template of a role, context, or task.
+func (it item) checkUTD() []Issue {
+	fields := orchestration.ExtractUTDFields(it.val)
+
+	// The template is the prompt, or the file content when there is no
+	// prompt. Command output is only known at runtime.
+	var src templateSource
+	switch {
+	case fields.Prompt != "":
+		src = it.fieldTemplate("prompt", fields.Prompt)
+	case fields.File != "":
+		content, err := orchestration.ReadFilePath(fields.File)
+		if err != nil {
+			// Missing files are reported by 'start doctor'
+			return nil
+		}
+		path, _ := orchestration.ExpandFilePath(fields.File)
+		src = templateSource{text: content, file: path, line: 1}
+	default:
+		return nil
+	}
+
+	refs, err := parsePlaceholders(src.text)
+	if err != nil {
+		return []Issue{it.syntaxIssue(src, err)}
+	}
+
+	placeholders := orchestration.UTDPlaceholders
+	if it.kind != "task" {
+		placeholders = withoutInstructions
+	}
+	issues := it.checkPlaceholders(src, refs, placeholders)
+
+	// Fields the template never references are never read or run.
+	if src.field == "prompt" && fields.File != "" && !references(refs, "file", "file_contents") {
+		file, line, _ := it.fieldPos("file")
+		issues = append(issues, it.issue(SeverityWarning, RuleUnusedField, file, line,
+			"file is never used: prompt does not reference {{.file}} or {{.file_contents}}"))
+	}
+	if fields.Command != "" && !references(refs, "command", "command_output") {
+		file, line, _ := it.fieldPos("command")
+		source := "prompt"
+		if src.field == "" {
+			source = "file"
+		}
+		issues = append(issues, it.issue(SeverityWarning, RuleUnusedField, file, line,
+			"command is never run: %s does not reference {{.command}} or {{.command_output}}", source))
+	}
+
+	// A misspelled {{.instructions}} is already reported above.
+	if it.kind == "task" && !references(refs, "instructions") && !misspells(refs, "instructions") {
+		issues = append(issues, it.issue(SeverityWarning, RuleMissingInstructions, src.file, src.line,
+			"task ignores instructions: template does not reference {{.instructions}}"))
+	}
+
+	return issues
+}
+
+// misspells reports whether a ref looks like a typo of name.
+func misspells(refs []placeholderRef, name string) bool {
+	for _, r := range refs {
+		if suggest.Closest(r.Name, []string{name}) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutInstructions is the placeholder set for roles and contexts, where
+// {{.instructions}} is always empty.
+var withoutInstructions = func() []string {
+	var names []string
+	for _, p := range orchestration.UTDPlaceholders {
+		if p != "instructions" {
+			names = append(names, p)
+		}
+	}
+	return names
+}()
+
+// syntaxIssue reports a template that does not parse.
+func (it item) syntaxIssue(src templateSource, err error) Issue {
+	msg := strings.TrimSpace(strings.TrimPrefix(err.Error(), "template: lint:"))
+	return it.issue(SeverityError, RuleTemplateSyntax, src.file, src.line, "invalid template: %s", msg)
+}
+
+// checkPlaceholders reports references to placeholders that are never set.
+func (it item) checkPlaceholders(src templateSource, refs []placeholderRef, known []string) []Issue {
+	var issues []Issue
+	seen := make(map[string]bool)
+	for _, r := range refs {
+		if !r.Root || seen[r.Name] || slices.Contains(known, r.Name) {
+			continue
+		}
+		seen[r.Name] = true
+
+		if r.Name == "instructions" {
+			issues = append(issues, it.issue(SeverityWarning, RuleUnknownPlaceholder, src.file, src.lineAt(r.Offset),
+				"{{.instructions}} is always empty in a %s; it is only set for tasks", it.kind))
+			continue
+		}
+		msg := fmt.Sprintf("unknown placeholder {{.%s}} renders empty", r.Name)
+		if s := suggest.Closest(r.Name, known); s != "" {
+			msg += fmt.Sprintf("; did you mean {{.%s}}?", s)
+		}
+		issues = append(issues, it.issue(SeverityError, RuleUnknownPlaceholder, src.file, src.lineAt(r.Offset), "%s", msg))
+	}
+	return issues
+}
+
+// checkRoles reports task roles that are not defined and roles that are
+// never selected without --role.
+func checkRoles(cfg Config) []Issue {
+	roles := cfg.Value.LookupPath(cue.ParsePath(internalcue.KeyRoles))
+	var names []string
+	reachable := make(map[string]bool)
+	var roleItems []item
+	if iter, err := roles.Fields(); err == nil {
+		// The default role is the first available role in definition order.
+		// Optional roles before it may become the default when their file
+		// appears, so all roles up to the first non-optional one are reachable.
+		defaultFound := false
+		for iter.Next() {
+			name := iter.Selector().Unquoted()
+			names = append(names, name)
+			roleItems = append(roleItems, item{cfg: cfg, key: internalcue.KeyRoles, kind: "role", name: name, val: iter.Value()})
+			if defaultFound {
+				continue
+			}
+			reachable[name] = true
+			optional, _ := iter.Value().LookupPath(cue.ParsePath("optional")).Bool()
+			if !optional {
+				defaultFound = true
+			}
+		}
+	}
+
+	var issues []Issue
+	tasks := cfg.Value.LookupPath(cue.ParsePath(internalcue.KeyTasks))
+	if iter, err := tasks.Fields(); err == nil {
+		for iter.Next() {
+			role, err := iter.Value().LookupPath(cue.ParsePath("role")).String()
+			if err != nil || role == "" || orchestration.IsFilePath(role) {
+				continue
+			}
+			reachable[role] = true
+			if slices.Contains(names, role) {
+				continue
+			}
+			it := item{cfg: cfg, key: internalcue.KeyTasks, kind: "task", name: iter.Selector().Unquoted(), val: iter.Value()}
+			file, line, _ := it.fieldPos("role")
+			msg := fmt.Sprintf("role %q is not defined; start will look for it in the registry", role)
+			if s := suggest.Closest(role, names); s != "" {
+				msg += fmt.Sprintf("; did you mean %q?", s)
+			}
+			issues = append(issues, it.issue(SeverityWarning, RuleUnknownRole, file, line, "%s", msg))
+		}
+	}
+
+	for _, it := range roleItems {
+		if reachable[it.name] {
+			continue
+		}
+		file, line, _ := it.fieldPos("")
+		issues = append(issues, it.issue(SeverityInfo, RuleUnreachableRole, file, line,
+			"role is never the default and no task uses it; it is only used with --role"))
+	}
+
+	return issues
+}
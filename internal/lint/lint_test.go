@@ -0,0 +1,215 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+)
+
+// loadConfig writes content to a config directory and loads it for linting.
+func loadConfig(t *testing.T, content string) (Config, string) {
+	t.Helper()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.cue")
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v, err := internalcue.NewLoader().LoadSingle(dir)
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	return Config{Value: v, Sources: []cue.Value{v}}, file
+}
+
+// findIssue returns the first issue with the given rule and item name.
+func findIssue(issues []Issue, rule, name string) (Issue, bool) {
+	for _, i := range issues {
+		if i.Rule == rule && i.Name == name {
+			return i, true
+		}
+	}
+	return Issue{}, false
+}
+
+func TestCheck_UnknownPlaceholder(t *testing.T) {
+	t.Parallel()
+	cfg, file := loadConfig(t, `tasks: review: {
+	prompt: """
+		Review the code.
+		{{.instruction}}
+		"""
+}
+`)
+	issues := Check(cfg)
+
+	i, ok := findIssue(issues, RuleUnknownPlaceholder, "review")
+	if !ok {
+		t.Fatalf("missing unknown-placeholder issue: %+v", issues)
+	}
+	if i.Severity != SeverityError {
+		t.Errorf("Severity = %s, want error", i.Severity)
+	}
+	if !strings.Contains(i.Message, "{{.instruction}}") || !strings.Contains(i.Message, "did you mean {{.instructions}}?") {
+		t.Errorf("Message = %q", i.Message)
+	}
+	if i.File != file || i.Line != 4 {
+		t.Errorf("Position() = %s, want %s:4", i.Position(), file)
+	}
+	if !HasFailures(issues) {
+		t.Error("HasFailures() = false, want true")
+	}
+}
+
+func TestCheck_KnownPlaceholders(t *testing.T) {
+	t.Parallel()
+	cfg, _ := loadConfig(t, `agents: claude: {
+	bin: "claude"
+	command: "{{.bin}} {{if .model}}--model {{.model}}{{end}} --append-system-prompt {{.role}} {{.prompt}}"
+}
+roles: dev: prompt: "Working in {{.cwd}} on {{.git_branch}}"
+contexts: env: {
+	command: "git status"
+	prompt: "{{range $i, $l := .command_output}}{{.whatever}}{{end}} {{$.os_name}}"
+}
+tasks: fix: prompt: "Fix: {{.instructions}}"
+`)
+	issues := Check(cfg)
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %+v", issues)
+	}
+	if HasFailures(issues) {
+		t.Error("HasFailures() = true, want false")
+	}
+}
+
+func TestCheck_AgentCommand(t *testing.T) {
+	t.Parallel()
+	cfg, _ := loadConfig(t, `agents: claude: {
+	bin: "claude"
+	command: "{{.bin}} --model {{.modle}} {{.prompt}}"
+}
+agents: broken: {
+	bin: "x"
+	command: "{{.bin"
+}
+`)
+	issues := Check(cfg)
+
+	i, ok := findIssue(issues, RuleUnknownPlaceholder, "claude")
+	if !ok || !strings.Contains(i.Message, "did you mean {{.model}}?") {
+		t.Errorf("agent placeholder issue = %+v, found %v", i, ok)
+	}
+	if i.Line != 3 {
+		t.Errorf("Line = %d, want 3", i.Line)
+	}
+	if _, ok := findIssue(issues, RuleTemplateSyntax, "broken"); !ok {
+		t.Errorf("missing template-syntax issue: %+v", issues)
+	}
+}
+
+func TestCheck_UnusedFields(t *testing.T) {
+	t.Parallel()
+	cfg, file := loadConfig(t, `contexts: status: {
+	file: "~/notes.md"
+	command: "git status"
+	prompt: "Status follows."
+}
+contexts: used: {
+	file: "~/notes.md"
+	command: "git log"
+	prompt: "See {{.file}}: {{.command_output}}"
+}
+`)
+	issues := Check(cfg)
+
+	var unused []Issue
+	for _, i := range issues {
+		if i.Rule == RuleUnusedField {
+			unused = append(unused, i)
+		}
+	}
+	if len(unused) != 2 {
+		t.Fatalf("expected 2 unused-field issues, got %+v", issues)
+	}
+	if unused[0].Name != "status" || unused[0].Line != 2 || !strings.Contains(unused[0].Message, "file is never used") {
+		t.Errorf("file issue = %+v", unused[0])
+	}
+	if unused[1].Line != 3 || !strings.Contains(unused[1].Message, "command is never run") || unused[1].File != file {
+		t.Errorf("command issue = %+v", unused[1])
+	}
+}
+
+func TestCheck_FileTemplate(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	tmpl := filepath.Join(dir, "task.md")
+	if err := os.WriteFile(tmpl, []byte("# Task\n\nRun on {{.hostnme}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := loadConfig(t, `tasks: deploy: file: "`+tmpl+`"`)
+	issues := Check(cfg)
+
+	i, ok := findIssue(issues, RuleUnknownPlaceholder, "deploy")
+	if !ok || i.File != tmpl || i.Line != 3 || !strings.Contains(i.Message, "{{.hostname}}") {
+		t.Errorf("file placeholder issue = %+v, found %v", i, ok)
+	}
+	if i, ok := findIssue(issues, RuleMissingInstructions, "deploy"); !ok || i.File != tmpl {
+		t.Errorf("missing-instructions issue = %+v, found %v", i, ok)
+	}
+}
+
+func TestCheck_InstructionsOutsideTask(t *testing.T) {
+	t.Parallel()
+	cfg, _ := loadConfig(t, `roles: dev: prompt: "Do {{.instructions}}"`)
+	i, ok := findIssue(Check(cfg), RuleUnknownPlaceholder, "dev")
+	if !ok || i.Severity != SeverityWarning || !strings.Contains(i.Message, "only set for tasks") {
+		t.Errorf("issue = %+v, found %v", i, ok)
+	}
+}
+
+func TestCheck_Roles(t *testing.T) {
+	t.Parallel()
+	cfg, _ := loadConfig(t, `roles: {
+	extra: {optional: true, file: "~/missing-role.md"}
+	dev: prompt: "Developer"
+	reviewer: prompt: "Reviewer"
+	writer: prompt: "Writer"
+}
+tasks: {
+	review: {role: "reviewer", prompt: "{{.instructions}}"}
+	docs: {role: "writr", prompt: "{{.instructions}}"}
+}
+`)
+	issues := Check(cfg)
+
+	i, ok := findIssue(issues, RuleUnknownRole, "docs")
+	if !ok || !strings.Contains(i.Message, `did you mean "writer"?`) || i.Line != 9 {
+		t.Errorf("unknown-role issue = %+v, found %v", i, ok)
+	}
+
+	var unreachable []string
+	for _, i := range issues {
+		if i.Rule == RuleUnreachableRole {
+			unreachable = append(unreachable, i.Name)
+			if i.Severity != SeverityInfo {
+				t.Errorf("unreachable-role severity = %s, want info", i.Severity)
+			}
+		}
+	}
+	if strings.Join(unreachable, ",") != "writer" {
+		t.Errorf("unreachable roles = %v, want [writer]", unreachable)
+	}
+}
+
+func TestCheck_MisspelledInstructionsReportedOnce(t *testing.T) {
+	t.Parallel()
+	cfg, _ := loadConfig(t, `tasks: fix: prompt: "Fix {{.intructions}}"`)
+	issues := Check(cfg)
+	if len(issues) != 1 || issues[0].Rule != RuleUnknownPlaceholder {
+		t.Errorf("issues = %+v, want a single unknown-placeholder", issues)
+	}
+}
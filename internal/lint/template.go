@@ -0,0 +1,103 @@
+package lint
+
+import (
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// placeholderRef is a {{.name}} reference in a template.
+type placeholderRef struct {
+	Name   string
+	Offset int  // Byte offset of the reference in the template text
+	Root   bool // Evaluated against the template data (not inside range/with)
+}
+
+// parsePlaceholders parses a template and returns its placeholder references.
+func parsePlaceholders(text string) ([]placeholderRef, error) {
+	tmpl, err := template.New("lint").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var refs []placeholderRef
+	if tmpl.Tree != nil {
+		walkNode(tmpl.Tree.Root, true, &refs)
+	}
+	return refs, nil
+}
+
+// walkNode collects field references below node. Inside range and with
+// bodies, dot is no longer the template data, so references there are
+// recorded as non-root.
+func walkNode(node parse.Node, root bool, refs *[]placeholderRef) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			walkNode(c, root, refs)
+		}
+	case *parse.ActionNode:
+		walkNode(n.Pipe, root, refs)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			walkNode(c, root, refs)
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			walkNode(a, root, refs)
+		}
+	case *parse.ChainNode:
+		walkNode(n.Node, root, refs)
+	case *parse.FieldNode:
+		*refs = append(*refs, placeholderRef{Name: n.Ident[0], Offset: int(n.Pos), Root: root})
+	case *parse.VariableNode:
+		// $.name always refers to the template data
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			*refs = append(*refs, placeholderRef{Name: n.Ident[1], Offset: int(n.Pos), Root: true})
+		}
+	case *parse.IfNode:
+		walkBranch(&n.BranchNode, root, root, refs)
+	case *parse.RangeNode:
+		walkBranch(&n.BranchNode, root, false, refs)
+	case *parse.WithNode:
+		walkBranch(&n.BranchNode, root, false, refs)
+	case *parse.TemplateNode:
+		walkNode(n.Pipe, root, refs)
+	}
+}
+
+// walkBranch walks an if/range/with node. bodyRoot reports whether dot in
+// the body is still the template data.
+func walkBranch(n *parse.BranchNode, root, bodyRoot bool, refs *[]placeholderRef) {
+	walkNode(n.Pipe, root, refs)
+	walkNode(n.List, bodyRoot && root, refs)
+	walkNode(n.ElseList, root, refs)
+}
+
+// references reports whether any ref names one of the given placeholders.
+func references(refs []placeholderRef, names ...string) bool {
+	for _, r := range refs {
+		for _, name := range names {
+			if r.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lineOf returns the 1-based line and column of a byte offset in text.
+func lineOf(text string, offset int) (line, col int) {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	before := text[:offset]
+	line = strings.Count(before, "\n") + 1
+	col = offset - strings.LastIndex(before, "\n")
+	return line, col
+}
@@ -309,14 +309,14 @@ func (a *AutoSetup) writeConfig(agent Agent) (string, error) {
 	// Write agents.cue
 	agentContent := generateAgentCUE(agent)
 	agentPath := filepath.Join(paths.Global, "agents.cue")
-	if err := os.WriteFile(agentPath, []byte(agentContent), 0644); err != nil {
+	if err := config.WriteFile(agentPath, []byte(agentContent)); err != nil {
 		return "", fmt.Errorf("writing agents file: %w", err)
 	}
 
 	// Write settings.cue with default agent with settings
 	configContent := generateSettingsCUE(agent.Name)
 	configPath := filepath.Join(paths.Global, "settings.cue")
-	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+	if err := config.WriteFile(configPath, []byte(configContent)); err != nil {
 		return "", fmt.Errorf("writing config file: %w", err)
 	}
 
@@ -50,18 +50,30 @@ type Composer struct {
 	processor   *TemplateProcessor
 	tempManager *temp.Manager
 	workingDir  string
+	stable      bool
 }
 
 // NewComposer creates a new prompt composer.
-func NewComposer(processor *TemplateProcessor, workingDir string) *Composer {
+// tempManager receives copies of file-based roles, contexts, and tasks that
+// live outside the working directory (see temp.NewSessionManager).
+func NewComposer(processor *TemplateProcessor, tempManager *temp.Manager, workingDir string) *Composer {
 	return &Composer{
 		processor:   processor,
-		tempManager: temp.NewUTDManager(workingDir),
+		tempManager: tempManager,
 		workingDir:  workingDir,
 	}
 }
 
-// resolveFileToTemp reads a source file and writes it to .start/temp/.
+// SetStable makes composition reproducible, for output that outlives the
+// session such as synced instruction files. Files are referenced at their
+// source paths rather than copied to the session directory, nothing is
+// written to it, and {{.datetime}} renders empty.
+func (c *Composer) SetStable() {
+	c.stable = true
+	c.processor.stable = true
+}
+
+// resolveFileToTemp reads a source file and writes it to the session temp directory.
 // Returns the temp file path, or empty string if no file to resolve.
 // The entityType is "task", "role", or "context".
 // The name is the entity name (e.g., "code-review", "start/create-task").
@@ -125,6 +137,8 @@ type ComposeResult struct {
 	RoleResolutions []RoleResolution
 	// Warnings contains any non-fatal issues.
 	Warnings []string
+	// Secrets records secrets detected before launch (set by the caller).
+	Secrets SecretReport
 }
 
 // Compose builds the final prompt from configuration.
@@ -448,9 +462,10 @@ func (c *Composer) resolveContext(cfg cue.Value, name string) (ProcessResult, er
 
 	// Write file to temp for agent access (only for external files).
 	// Files within cwd are already accessible - no temp copy needed.
+	// Stable composition keeps every file at its source path.
 	var tempPath string
 	if fields.File != "" {
-		if c.isCwdPath(fields.File) {
+		if c.stable || c.isCwdPath(fields.File) {
 			// Expand tilde and validate cwd file exists (don't copy, just check)
 			expandedPath, err := ExpandFilePath(fields.File)
 			if err != nil {
@@ -512,7 +527,7 @@ func (c *Composer) resolveRole(cfg cue.Value, name string) (content, filePath st
 	var roleFilePath string
 
 	if fields.File != "" {
-		if c.isCwdPath(fields.File) {
+		if c.stable || c.isCwdPath(fields.File) {
 			// Expand tilde and validate cwd file exists (don't copy, just check)
 			expandedPath, err := ExpandFilePath(fields.File)
 			if err != nil {
@@ -540,7 +555,7 @@ func (c *Composer) resolveRole(cfg cue.Value, name string) (content, filePath st
 
 	// For inline roles (no source file), write resolved content to temp.
 	// This ensures {{.role_file}} always has a valid path for agents that need it.
-	if roleFilePath == "" && result.Content != "" {
+	if roleFilePath == "" && result.Content != "" && !c.stable {
 		tempPath, err := c.tempManager.WriteUTDFile("role", name, result.Content)
 		if err != nil {
 			return "", "", fmt.Errorf("writing role temp file: %w", err)
@@ -689,9 +704,10 @@ func (c *Composer) ResolveTask(cfg cue.Value, name, instructions string) (Proces
 
 	// Write file to temp for agent access (only for external files).
 	// Files within cwd are already accessible - no temp copy needed.
+	// Stable composition keeps every file at its source path.
 	var tempPath string
 	if fields.File != "" {
-		if c.isCwdPath(fields.File) {
+		if c.stable || c.isCwdPath(fields.File) {
 			// Expand tilde and validate cwd file exists (don't copy, just check)
 			expandedPath, err := ExpandFilePath(fields.File)
 			if err != nil {
@@ -9,8 +9,15 @@ import (
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
+	"github.com/grantcarthew/start/internal/temp"
 )
 
+// testTempManager returns a session temp manager rooted in a test directory.
+func testTempManager(t *testing.T) *temp.Manager {
+	t.Helper()
+	return temp.NewSessionManager(t.TempDir())
+}
+
 func TestComposer_Compose(t *testing.T) {
 	t.Parallel()
 	ctx := cuecontext.New()
@@ -262,7 +269,7 @@ func TestComposer_Compose(t *testing.T) {
 			}
 
 			processor := NewTemplateProcessor(nil, nil, "")
-			composer := NewComposer(processor, "")
+			composer := NewComposer(processor, testTempManager(t), "")
 
 			result, err := composer.Compose(cfg, tt.selection, tt.customText)
 			if err != nil {
@@ -335,7 +342,7 @@ func TestComposer_ComposeWithRole(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, "")
-	composer := NewComposer(processor, "")
+	composer := NewComposer(processor, testTempManager(t), "")
 
 	t.Run("uses default role", func(t *testing.T) {
 		result, err := composer.ComposeWithRole(cfg, ContextSelection{IncludeRequired: true}, "", "")
@@ -404,7 +411,7 @@ func TestComposer_ResolveTask(t *testing.T) {
 
 	runner := &mockShellRunner{output: "diff output here"}
 	processor := NewTemplateProcessor(nil, runner, tmpDir)
-	composer := NewComposer(processor, tmpDir)
+	composer := NewComposer(processor, testTempManager(t), tmpDir)
 
 	t.Run("task with command and instructions", func(t *testing.T) {
 		result, err := composer.ResolveTask(cfg, "code-review", "focus on security")
@@ -464,7 +471,7 @@ func TestComposer_ContextWithFile(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, tmpDir)
-	composer := NewComposer(processor, tmpDir)
+	composer := NewComposer(processor, testTempManager(t), tmpDir)
 
 	result, err := composer.Compose(cfg, ContextSelection{IncludeRequired: true}, "")
 	if err != nil {
@@ -608,7 +615,7 @@ func TestGetDefaultRole(t *testing.T) {
 			}
 
 			processor := NewTemplateProcessor(nil, nil, "")
-			composer := NewComposer(processor, "")
+			composer := NewComposer(processor, testTempManager(t), "")
 
 			result, _, err := composer.selectDefaultRole(cfg)
 			if err != nil {
@@ -643,7 +650,7 @@ func TestSelectDefaultRole(t *testing.T) {
 		}
 
 		processor := NewTemplateProcessor(nil, nil, "")
-		composer := NewComposer(processor, "")
+		composer := NewComposer(processor, testTempManager(t), "")
 
 		roleName, resolutions, err := composer.selectDefaultRole(cfg)
 		if err != nil {
@@ -681,7 +688,7 @@ func TestSelectDefaultRole(t *testing.T) {
 		}
 
 		processor := NewTemplateProcessor(nil, nil, "")
-		composer := NewComposer(processor, "")
+		composer := NewComposer(processor, testTempManager(t), "")
 
 		_, resolutions, err := composer.selectDefaultRole(cfg)
 		if err == nil {
@@ -721,7 +728,7 @@ func TestSelectDefaultRole(t *testing.T) {
 		}
 
 		processor := NewTemplateProcessor(nil, nil, "")
-		composer := NewComposer(processor, "")
+		composer := NewComposer(processor, testTempManager(t), "")
 
 		roleName, resolutions, err := composer.selectDefaultRole(cfg)
 		if err != nil {
@@ -757,7 +764,7 @@ func TestSelectDefaultRole(t *testing.T) {
 		}
 
 		processor := NewTemplateProcessor(nil, nil, "")
-		composer := NewComposer(processor, "")
+		composer := NewComposer(processor, testTempManager(t), "")
 
 		_, _, err := composer.selectDefaultRole(cfg)
 		if err == nil {
@@ -786,7 +793,7 @@ func TestSelectDefaultRole(t *testing.T) {
 		}
 
 		processor := NewTemplateProcessor(nil, nil, "")
-		composer := NewComposer(processor, "")
+		composer := NewComposer(processor, testTempManager(t), "")
 
 		roleName, resolutions, err := composer.selectDefaultRole(cfg)
 		if err != nil {
@@ -835,7 +842,7 @@ func TestComposeWithRole_OptionalBehavior(t *testing.T) {
 		}
 
 		processor := NewTemplateProcessor(nil, nil, tmpDir)
-		composer := NewComposer(processor, tmpDir)
+		composer := NewComposer(processor, testTempManager(t), tmpDir)
 
 		// Explicit role selection should error even if optional: true
 		_, err := composer.ComposeWithRole(cfg, ContextSelection{}, "optional-missing", "")
@@ -852,7 +859,7 @@ func TestComposeWithRole_OptionalBehavior(t *testing.T) {
 		}
 
 		processor := NewTemplateProcessor(nil, nil, tmpDir)
-		composer := NewComposer(processor, tmpDir)
+		composer := NewComposer(processor, testTempManager(t), tmpDir)
 
 		result, err := composer.ComposeWithRole(cfg, ContextSelection{}, roleFile, "")
 		if err != nil {
@@ -910,7 +917,7 @@ func TestComposer_ResolveContext_Errors(t *testing.T) {
 			}
 
 			processor := NewTemplateProcessor(nil, nil, "")
-			composer := NewComposer(processor, "")
+			composer := NewComposer(processor, testTempManager(t), "")
 
 			_, err := composer.resolveContext(cfg, tt.contextName)
 			if err == nil {
@@ -967,7 +974,7 @@ func TestComposer_ResolveRole_Errors(t *testing.T) {
 			}
 
 			processor := NewTemplateProcessor(nil, nil, "")
-			composer := NewComposer(processor, "")
+			composer := NewComposer(processor, testTempManager(t), "")
 
 			_, _, err := composer.resolveRole(cfg, tt.roleName)
 			if err == nil {
@@ -1013,7 +1020,7 @@ func TestComposer_ResolveTask_TempFile(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	result, err := composer.ResolveTask(cfg, "test-task", "")
 	if err != nil {
@@ -1021,7 +1028,7 @@ func TestComposer_ResolveTask_TempFile(t *testing.T) {
 	}
 
 	// Verify temp file was created (because source is outside working directory)
-	expectedTempPath := filepath.Join(workingDir, ".start", "temp", "task-test-task.md")
+	expectedTempPath := filepath.Join(composer.tempManager.BaseDir, "task-test-task.md")
 	if result.TempFile != expectedTempPath {
 		t.Errorf("TempFile = %q, want %q", result.TempFile, expectedTempPath)
 	}
@@ -1073,7 +1080,7 @@ func TestComposer_ResolveTask_TempFile_WithSlashInName(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	result, err := composer.ResolveTask(cfg, "start/create-task", "")
 	if err != nil {
@@ -1081,7 +1088,7 @@ func TestComposer_ResolveTask_TempFile_WithSlashInName(t *testing.T) {
 	}
 
 	// Verify filename derivation handles slashes (converted to dashes)
-	expectedTempPath := filepath.Join(workingDir, ".start", "temp", "task-start-create-task.md")
+	expectedTempPath := filepath.Join(composer.tempManager.BaseDir, "task-start-create-task.md")
 	if result.TempFile != expectedTempPath {
 		t.Errorf("TempFile = %q, want %q", result.TempFile, expectedTempPath)
 	}
@@ -1106,7 +1113,7 @@ func TestComposer_ResolveTask_NoFile_NoTempFile(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, tmpDir)
-	composer := NewComposer(processor, tmpDir)
+	composer := NewComposer(processor, testTempManager(t), tmpDir)
 
 	result, err := composer.ResolveTask(cfg, "prompt-only", "")
 	if err != nil {
@@ -1149,7 +1156,7 @@ func TestComposer_ResolveContext_TempFile(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	result, err := composer.resolveContext(cfg, "project-info")
 	if err != nil {
@@ -1157,7 +1164,7 @@ func TestComposer_ResolveContext_TempFile(t *testing.T) {
 	}
 
 	// Verify temp file was created (because source is outside working directory)
-	expectedTempPath := filepath.Join(workingDir, ".start", "temp", "context-project-info.md")
+	expectedTempPath := filepath.Join(composer.tempManager.BaseDir, "context-project-info.md")
 	if result.TempFile != expectedTempPath {
 		t.Errorf("TempFile = %q, want %q", result.TempFile, expectedTempPath)
 	}
@@ -1194,7 +1201,7 @@ func TestComposer_CwdFile_NoTempFile(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	result, err := composer.resolveContext(cfg, "agents")
 	if err != nil {
@@ -1207,7 +1214,7 @@ func TestComposer_CwdFile_NoTempFile(t *testing.T) {
 	}
 
 	// Verify temp directory was not created
-	tempDir := filepath.Join(workingDir, ".start", "temp")
+	tempDir := composer.tempManager.BaseDir
 	if _, err := os.Stat(tempDir); !os.IsNotExist(err) {
 		t.Errorf("temp directory should not exist for cwd files, but found: %s", tempDir)
 	}
@@ -1218,6 +1225,52 @@ func TestComposer_CwdFile_NoTempFile(t *testing.T) {
 	}
 }
 
+func TestComposer_SetStable(t *testing.T) {
+	t.Parallel()
+	ctx := cuecontext.New()
+	workingDir := t.TempDir()
+	externalDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(workingDir, "guide.md"), []byte("Guide"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sourceFile := filepath.Join(externalDir, "shared.md")
+	if err := os.WriteFile(sourceFile, []byte("Shared"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := ctx.CompileString(fmt.Sprintf(`
+		contexts: {
+			guide: {required: true, file: %q, prompt: "Read {{.file}}{{.datetime}}"}
+			shared: {required: true, file: %q, prompt: "Read {{.file}}"}
+		}
+		roles: dev: prompt: "Engineer"
+	`, filepath.Join(workingDir, "guide.md"), sourceFile))
+	if err := cfg.Err(); err != nil {
+		t.Fatalf("compile config: %v", err)
+	}
+
+	processor := NewTemplateProcessor(nil, nil, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
+	composer.SetStable()
+
+	result, err := composer.ComposeWithRole(cfg, ContextSelection{IncludeRequired: true}, "dev", "")
+	if err != nil {
+		t.Fatalf("ComposeWithRole() error = %v", err)
+	}
+	want := map[string]string{"guide": "Read guide.md", "shared": "Read " + sourceFile}
+	for _, c := range result.Contexts {
+		if c.Content != want[c.Name] {
+			t.Errorf("context %s = %q, want %q", c.Name, c.Content, want[c.Name])
+		}
+	}
+
+	// Nothing is written to the session directory
+	if _, err := os.Stat(composer.tempManager.BaseDir); !os.IsNotExist(err) {
+		t.Errorf("session directory should not be created, got %v", err)
+	}
+}
+
 func TestComposer_resolveFileToTemp(t *testing.T) {
 	t.Parallel()
 	tmpDir := t.TempDir()
@@ -1229,7 +1282,7 @@ func TestComposer_resolveFileToTemp(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, tmpDir)
-	composer := NewComposer(processor, tmpDir)
+	composer := NewComposer(processor, testTempManager(t), tmpDir)
 
 	t.Run("creates temp file with correct content", func(t *testing.T) {
 		tempPath, err := composer.resolveFileToTemp("task", "test", sourceFile)
@@ -1268,7 +1321,7 @@ func TestComposer_isCwdPath(t *testing.T) {
 	t.Parallel()
 	workingDir := "/home/user/project"
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	tests := []struct {
 		name     string
@@ -1373,7 +1426,7 @@ func TestComposer_TildeExpansion_Context(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	result, err := composer.resolveContext(cfg, "tilde-test")
 	if err != nil {
@@ -1416,7 +1469,7 @@ func TestComposer_TildeExpansion_Role(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	content, _, err := composer.resolveRole(cfg, "tilde-test")
 	if err != nil {
@@ -1459,7 +1512,7 @@ func TestComposer_TildeExpansion_Task(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	result, err := composer.ResolveTask(cfg, "tilde-test", "test instructions")
 	if err != nil {
@@ -1492,7 +1545,7 @@ func TestComposer_TildeExpansion_FileNotFound(t *testing.T) {
 	}
 
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	_, err := composer.resolveContext(cfg, "missing")
 	if err == nil {
@@ -1534,7 +1587,7 @@ func TestComposer_ProcessContent(t *testing.T) {
 			t.Parallel()
 
 			processor := NewTemplateProcessor(nil, nil, "")
-			composer := NewComposer(processor, "")
+			composer := NewComposer(processor, testTempManager(t), "")
 
 			result, err := composer.ProcessContent(tt.content, tt.instructions)
 			if err != nil {
@@ -1790,7 +1843,7 @@ func TestComposer_ModulePathResolutionError(t *testing.T) {
 	cctx := cuecontext.New()
 	workingDir := t.TempDir()
 	processor := NewTemplateProcessor(nil, nil, workingDir)
-	composer := NewComposer(processor, workingDir)
+	composer := NewComposer(processor, testTempManager(t), workingDir)
 
 	t.Run("context module path error captured in status", func(t *testing.T) {
 		cfg := cctx.CompileString(`
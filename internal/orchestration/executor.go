@@ -3,6 +3,7 @@ package orchestration
 import (
 	"bytes"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"regexp"
@@ -12,9 +13,14 @@ import (
 	"time"
 
 	"cuelang.org/go/cue"
+	"github.com/grantcarthew/start/internal/config"
 	internalcue "github.com/grantcarthew/start/internal/cue"
+	"github.com/grantcarthew/start/internal/suggest"
 )
 
+// AgentPlaceholders are the placeholder names set when rendering an agent command.
+var AgentPlaceholders = []string{"bin", "model", "role", "role_file", "prompt", "datetime"}
+
 // quotedPlaceholderPattern detects placeholders that are incorrectly wrapped in quotes.
 // Since escapeForShell wraps all placeholder values in single quotes, templates should NOT
 // include quotes around any placeholder.
@@ -32,6 +38,10 @@ type Agent struct {
 	DefaultModel string
 	Models       map[string]string
 	Description  string
+
+	// InstructionsFile is the agent's native instruction file (e.g. CLAUDE.md)
+	// written by start sync-files. Empty uses the default for the agent binary.
+	InstructionsFile string
 }
 
 // ExecuteConfig holds the configuration for agent execution.
@@ -53,6 +63,7 @@ type CommandData map[string]string
 // Executor handles agent command execution.
 type Executor struct {
 	workingDir string
+	policy     config.Policy
 }
 
 // NewExecutor creates a new agent executor.
@@ -60,6 +71,11 @@ func NewExecutor(workingDir string) *Executor {
 	return &Executor{workingDir: workingDir}
 }
 
+// SetPolicy sets the policy whose deny rules BuildCommand enforces.
+func (e *Executor) SetPolicy(policy config.Policy) {
+	e.policy = policy
+}
+
 // ValidateCommandTemplate checks for common template errors.
 // Returns an error if the template contains quoted placeholders like '{{.prompt}}'
 // since escapeForShell already wraps values in single quotes.
@@ -166,6 +182,10 @@ Check your agent's 'bin' field or ensure the executable is in PATH`, cfg.Agent.B
 
 	cmdStr := buf.String()
 
+	if err := e.checkPolicy(tmpl, data); err != nil {
+		return "", err
+	}
+
 	// Validate that the command starts with an executable
 	if err := validateCommandExecutable(cmdStr, cfg.Agent.Command); err != nil {
 		return "", err
@@ -174,6 +194,25 @@ Check your agent's 'bin' field or ensure the executable is in PATH`, cfg.Agent.B
 	return cmdStr, nil
 }
 
+// checkPolicy checks the command against the policy deny rules. The prompt
+// and role are left out of the checked command, so a prompt that mentions a
+// denied flag does not block the launch; they reach the agent as single
+// quoted arguments and cannot add flags of their own.
+func (e *Executor) checkPolicy(tmpl *template.Template, data CommandData) error {
+	if len(e.policy.Deny) == 0 {
+		return nil
+	}
+	masked := maps.Clone(data)
+	for _, key := range []string{"role", "role_file", "prompt"} {
+		masked[key] = "''"
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, masked); err != nil {
+		return fmt.Errorf("executing command template: %w", err)
+	}
+	return e.policy.CheckCommand(buf.String())
+}
+
 // validateCommandExecutable checks that the first token of the built command
 // is a valid executable (either in PATH or a direct path).
 // Skips leading environment variable assignments (VAR=value patterns).
@@ -338,8 +377,18 @@ func escapeForShell(s string) string {
 
 // ExtractAgent extracts agent configuration from CUE value.
 func ExtractAgent(cfg cue.Value, name string) (Agent, error) {
-	agentVal := cfg.LookupPath(cue.ParsePath(internalcue.KeyAgents)).LookupPath(cue.MakePath(cue.Str(name)))
+	agents := cfg.LookupPath(cue.ParsePath(internalcue.KeyAgents))
+	agentVal := agents.LookupPath(cue.MakePath(cue.Str(name)))
 	if !agentVal.Exists() {
+		var names []string
+		if iter, err := agents.Fields(); err == nil {
+			for iter.Next() {
+				names = append(names, iter.Selector().Unquoted())
+			}
+		}
+		if s := suggest.Closest(name, names); s != "" {
+			return Agent{}, fmt.Errorf("agent %q not found; did you mean %q?", name, s)
+		}
 		return Agent{}, fmt.Errorf("agent %q not found", name)
 	}
 
@@ -363,6 +412,9 @@ func extractAgentFields(agentVal cue.Value, name string) Agent {
 	if desc := agentVal.LookupPath(cue.ParsePath("description")); desc.Exists() {
 		agent.Description, _ = desc.String()
 	}
+	if f := agentVal.LookupPath(cue.ParsePath("instructions_file")); f.Exists() {
+		agent.InstructionsFile, _ = f.String()
+	}
 
 	if models := agentVal.LookupPath(cue.ParsePath("models")); models.Exists() {
 		agent.Models = make(map[string]string)
@@ -1,11 +1,13 @@
 package orchestration
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
 
 	"cuelang.org/go/cue/cuecontext"
+	"github.com/grantcarthew/start/internal/config"
 )
 
 func TestExecutor_BuildCommand(t *testing.T) {
@@ -372,6 +374,13 @@ func TestExtractAgent(t *testing.T) {
 			t.Error("expected error for nonexistent agent")
 		}
 	})
+
+	t.Run("misspelled agent", func(t *testing.T) {
+		_, err := ExtractAgent(cfg, "cluade")
+		if err == nil || err.Error() != `agent "cluade" not found; did you mean "claude"?` {
+			t.Errorf("ExtractAgent() error = %v, want suggestion", err)
+		}
+	})
 }
 
 func TestGenerateDryRunCommand(t *testing.T) {
@@ -651,3 +660,41 @@ func TestBuildCommand_WithEnvVarPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildCommand_PolicyDeny(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/policy.cue", []byte(`deny: [{pattern: "--permission-mode[= ]+bypass"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := config.LoadPolicy(config.Paths{Policy: []string{dir}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor := NewExecutor("")
+	executor.SetPolicy(policy)
+
+	tests := []struct {
+		name    string
+		command string
+		prompt  string
+		wantErr bool
+	}{
+		{"denied flag in command", "{{.bin}} --permission-mode bypass {{.prompt}}", "hello", true},
+		{"denied flag in prompt", "{{.bin}} {{.prompt}}", "run with --permission-mode bypass", false},
+		{"allowed command", "{{.bin}} --permission-mode plan {{.prompt}}", "hello", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := executor.BuildCommand(ExecuteConfig{
+				Agent:  Agent{Bin: "sh", Command: tt.command},
+				Prompt: tt.prompt,
+			})
+			var denied *config.PolicyDeniedError
+			if got := errors.As(err, &denied); got != tt.wantErr {
+				t.Errorf("BuildCommand() error = %v, want denied %t", err, tt.wantErr)
+			}
+		})
+	}
+}
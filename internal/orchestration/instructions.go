@@ -0,0 +1,114 @@
+package orchestration
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Managed block markers delimit the section of an agent instruction file
+// that start sync-files owns. Content outside the block is never modified.
+const (
+	ManagedBlockBegin = "<!-- start:begin (managed by 'start sync-files'; edits inside this block are overwritten) -->"
+	ManagedBlockEnd   = "<!-- start:end -->"
+)
+
+// managedBlockPrefix matches the begin marker regardless of its trailing note.
+const managedBlockPrefix = "<!-- start:begin"
+
+// defaultInstructionFiles maps agent binaries to the instruction file they
+// read natively. Agents not listed read AGENTS.md.
+var defaultInstructionFiles = map[string]string{
+	"claude": "CLAUDE.md",
+	"gemini": "GEMINI.md",
+}
+
+// DefaultInstructionsFile is the instruction file for agents without a known
+// native file.
+const DefaultInstructionsFile = "AGENTS.md"
+
+// InstructionsFile returns the instruction file path for an agent: its
+// instructions_file field when set, otherwise the native file for its binary.
+func InstructionsFile(agent Agent) string {
+	if agent.InstructionsFile != "" {
+		return agent.InstructionsFile
+	}
+	if f, ok := defaultInstructionFiles[filepath.Base(agent.Bin)]; ok {
+		return f
+	}
+	return DefaultInstructionsFile
+}
+
+// RenderInstructions renders a composed role and contexts as the body of a
+// managed block for the instruction file at target. Contexts read from target
+// itself are left out, and managed blocks are stripped from context content,
+// so syncing is stable when contexts include instruction files.
+func RenderInstructions(result ComposeResult, target string) string {
+	var parts []string
+	if role := strings.TrimSpace(StripManagedBlock(result.Role)); role != "" {
+		parts = append(parts, role)
+	}
+	for _, ctx := range result.Contexts {
+		if ctx.Status != "loaded" || samePath(ctx.File, target) {
+			continue
+		}
+		if content := strings.TrimSpace(StripManagedBlock(ctx.Content)); content != "" {
+			parts = append(parts, content)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ReplaceManagedBlock returns existing with its managed block replaced by
+// body. If existing has no managed block, the block is appended after the
+// existing content.
+func ReplaceManagedBlock(existing, body string) string {
+	block := ManagedBlockBegin + "\n"
+	if body != "" {
+		block += body + "\n"
+	}
+	block += ManagedBlockEnd + "\n"
+
+	start, end, ok := findManagedBlock(existing)
+	if !ok {
+		if strings.TrimSpace(existing) == "" {
+			return block
+		}
+		return strings.TrimRight(existing, "\n") + "\n\n" + block
+	}
+	return existing[:start] + block + strings.TrimPrefix(existing[end:], "\n")
+}
+
+// StripManagedBlock removes the managed block from content, leaving any
+// hand-written content around it.
+func StripManagedBlock(content string) string {
+	start, end, ok := findManagedBlock(content)
+	if !ok {
+		return content
+	}
+	return content[:start] + strings.TrimPrefix(content[end:], "\n")
+}
+
+// findManagedBlock returns the byte range of the managed block in content,
+// from the start of the begin marker to the end of the end marker.
+func findManagedBlock(content string) (start, end int, ok bool) {
+	start = strings.Index(content, managedBlockPrefix)
+	if start < 0 {
+		return 0, 0, false
+	}
+	rel := strings.Index(content[start:], ManagedBlockEnd)
+	if rel < 0 {
+		return 0, 0, false
+	}
+	return start, start + rel + len(ManagedBlockEnd), true
+}
+
+// samePath reports whether two file paths refer to the same location after
+// expanding tilde and making them absolute.
+func samePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	absA, errA := ExpandFilePath(a)
+	absB, errB := ExpandFilePath(b)
+	return errA == nil && errB == nil && absA == absB
+}
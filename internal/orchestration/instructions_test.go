@@ -0,0 +1,105 @@
+package orchestration
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstructionsFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		agent Agent
+		want  string
+	}{
+		{"claude", Agent{Bin: "claude"}, "CLAUDE.md"},
+		{"gemini full path", Agent{Bin: "/usr/local/bin/gemini"}, "GEMINI.md"},
+		{"other agent", Agent{Bin: "codex"}, "AGENTS.md"},
+		{"override", Agent{Bin: "claude", InstructionsFile: "docs/CLAUDE.md"}, "docs/CLAUDE.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := InstructionsFile(tt.agent); got != tt.want {
+				t.Errorf("InstructionsFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceManagedBlock(t *testing.T) {
+	t.Parallel()
+
+	block := ManagedBlockBegin + "\nnew\n" + ManagedBlockEnd + "\n"
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{"empty file", "", block},
+		{"append to hand-written", "# Notes\n", "# Notes\n\n" + block},
+		{
+			"replace existing block",
+			"# Notes\n\n" + ManagedBlockBegin + "\nold\n" + ManagedBlockEnd + "\n\nFooter\n",
+			"# Notes\n\n" + block + "\nFooter\n",
+		},
+		{
+			"begin marker with different note",
+			"<!-- start:begin -->\nold\n" + ManagedBlockEnd + "\n",
+			block,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ReplaceManagedBlock(tt.existing, "new")
+			if got != tt.want {
+				t.Errorf("ReplaceManagedBlock() =\n%q\nwant\n%q", got, tt.want)
+			}
+			// Replacing again is stable
+			if again := ReplaceManagedBlock(got, "new"); again != got {
+				t.Errorf("second replace changed content:\n%q", again)
+			}
+		})
+	}
+}
+
+func TestStripManagedBlock(t *testing.T) {
+	t.Parallel()
+
+	content := "# Notes\n" + ManagedBlockBegin + "\ngenerated\n" + ManagedBlockEnd + "\nFooter\n"
+	if got, want := StripManagedBlock(content), "# Notes\nFooter\n"; got != want {
+		t.Errorf("StripManagedBlock() = %q, want %q", got, want)
+	}
+	if got := StripManagedBlock("no block"); got != "no block" {
+		t.Errorf("StripManagedBlock() = %q, want unchanged", got)
+	}
+}
+
+func TestRenderInstructions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "CLAUDE.md")
+	result := ComposeResult{
+		Role: "Role text\n",
+		Contexts: []Context{
+			{Name: "rules", Content: "Use tabs.\n", Status: "loaded"},
+			{Name: "self", File: target, Content: "Self content", Status: "loaded"},
+			{Name: "agents", File: filepath.Join(dir, "AGENTS.md"), Status: "loaded",
+				Content: "Hand written\n" + ManagedBlockBegin + "\nold\n" + ManagedBlockEnd + "\n"},
+			{Name: "skipped", Content: "Skipped", Status: "skipped"},
+		},
+	}
+
+	got := RenderInstructions(result, target)
+	want := "Role text\n\nUse tabs.\n\nHand written"
+	if got != want {
+		t.Errorf("RenderInstructions() =\n%q\nwant\n%q", got, want)
+	}
+	if strings.Contains(got, "Self content") {
+		t.Error("context reading the target file should be left out")
+	}
+}
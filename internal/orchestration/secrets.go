@@ -0,0 +1,57 @@
+package orchestration
+
+import (
+	"github.com/grantcarthew/start/internal/secrets"
+)
+
+// SecretReport records the secrets detected in a composition and the
+// secret_policy applied to them.
+type SecretReport struct {
+	Policy   secrets.Policy
+	Findings []secrets.Finding
+}
+
+// ScanSecrets scans the composed role and prompt for secrets. Findings are
+// attributed to the role ("role:<name>"), the context whose content contains
+// them, or "prompt" for custom text and task content.
+func ScanSecrets(scanner *secrets.Scanner, result ComposeResult) []secrets.Finding {
+	var findings []secrets.Finding
+	seen := make(map[string]bool)
+
+	if result.Role != "" {
+		source := "role"
+		if result.RoleName != "" {
+			source = "role:" + result.RoleName
+		}
+		findings = append(findings, scanner.Scan(source, result.Role)...)
+	}
+
+	for _, ctx := range result.Contexts {
+		if ctx.Status != "loaded" {
+			continue
+		}
+		for _, f := range scanner.Scan(ctx.Name, ctx.Content) {
+			findings = append(findings, f)
+			seen[f.Secret()] = true
+		}
+	}
+
+	// Anything left in the prompt came from custom text or a task.
+	for _, f := range scanner.Scan("prompt", result.Prompt) {
+		if !seen[f.Secret()] {
+			findings = append(findings, f)
+		}
+	}
+
+	return findings
+}
+
+// RedactSecrets replaces detected secrets in the role, prompt, and context
+// content of a composition.
+func RedactSecrets(scanner *secrets.Scanner, result *ComposeResult) {
+	result.Role = scanner.Redact(result.Role)
+	result.Prompt = scanner.Redact(result.Prompt)
+	for i := range result.Contexts {
+		result.Contexts[i].Content = scanner.Redact(result.Contexts[i].Content)
+	}
+}
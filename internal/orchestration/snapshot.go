@@ -0,0 +1,299 @@
+package orchestration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grantcarthew/start/internal/diff"
+)
+
+// Dry-run file names. A dry-run directory doubles as a saved composition
+// snapshot that can be compared with start diff.
+const (
+	DryRunRoleFile     = "role.md"
+	DryRunPromptFile   = "prompt.md"
+	DryRunCommandFile  = "command.txt"
+	DryRunContextsFile = "contexts.json"
+)
+
+// SnapshotContext records a context included in a composition.
+type SnapshotContext struct {
+	Name   string `json:"name"`
+	Digest string `json:"digest,omitempty"` // SHA-256 of the resolved content
+}
+
+// Snapshot captures the inputs handed to an agent for later comparison.
+type Snapshot struct {
+	Source   string // Directory path or "current composition"
+	Agent    string
+	Model    string
+	RoleName string
+	Role     string
+	Prompt   string
+	Command  string
+	Contexts []SnapshotContext
+	// HasDigests indicates context digests are available for change detection.
+	// Dry-run directories written before contexts.json existed only carry names.
+	HasDigests bool
+}
+
+// NewSnapshot builds a snapshot from a composition result and built command.
+func NewSnapshot(agent Agent, model string, result ComposeResult, cmdStr string) Snapshot {
+	return Snapshot{
+		Source:     "current composition",
+		Agent:      agent.Name,
+		Model:      model,
+		RoleName:   result.RoleName,
+		Role:       result.Role,
+		Prompt:     result.Prompt,
+		Command:    cmdStr,
+		Contexts:   loadedContexts(result.Contexts),
+		HasDigests: true,
+	}
+}
+
+// loadedContexts returns snapshot entries for contexts that contributed content.
+func loadedContexts(contexts []Context) []SnapshotContext {
+	var entries []SnapshotContext
+	for _, ctx := range contexts {
+		if ctx.Status != "loaded" {
+			continue
+		}
+		sum := sha256.Sum256([]byte(ctx.Content))
+		entries = append(entries, SnapshotContext{
+			Name:   ctx.Name,
+			Digest: hex.EncodeToString(sum[:]),
+		})
+	}
+	return entries
+}
+
+// GenerateDryRunContexts generates the contexts.json content for dry-run.
+func GenerateDryRunContexts(contexts []Context) (string, error) {
+	entries := loadedContexts(contexts)
+	if entries == nil {
+		entries = []SnapshotContext{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// ReadSnapshot reads a snapshot from a dry-run directory.
+func ReadSnapshot(dir string) (Snapshot, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("reading snapshot %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return Snapshot{}, fmt.Errorf("snapshot %s is not a directory", dir)
+	}
+
+	snap := Snapshot{Source: dir}
+
+	cmdData, err := os.ReadFile(filepath.Join(dir, DryRunCommandFile))
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("%s is not a dry-run directory: %w", dir, err)
+	}
+	var headerNames []string
+	snap.Agent, snap.Model, snap.RoleName, headerNames, snap.Command = parseDryRunCommand(string(cmdData))
+
+	if data, err := os.ReadFile(filepath.Join(dir, DryRunRoleFile)); err == nil {
+		snap.Role = string(data)
+	} else if !os.IsNotExist(err) {
+		return Snapshot{}, fmt.Errorf("reading %s: %w", DryRunRoleFile, err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, DryRunPromptFile)); err == nil {
+		snap.Prompt = string(data)
+	} else if !os.IsNotExist(err) {
+		return Snapshot{}, fmt.Errorf("reading %s: %w", DryRunPromptFile, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, DryRunContextsFile))
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &snap.Contexts); err != nil {
+			return Snapshot{}, fmt.Errorf("parsing %s: %w", DryRunContextsFile, err)
+		}
+		snap.HasDigests = true
+	case os.IsNotExist(err):
+		for _, name := range headerNames {
+			snap.Contexts = append(snap.Contexts, SnapshotContext{Name: name})
+		}
+	default:
+		return Snapshot{}, fmt.Errorf("reading %s: %w", DryRunContextsFile, err)
+	}
+
+	return snap, nil
+}
+
+// parseDryRunCommand parses command.txt content written by GenerateDryRunCommand.
+// Header lines start with "# "; the command follows the first blank line.
+func parseDryRunCommand(content string) (agent, model, roleName string, contexts []string, cmdStr string) {
+	header, body, found := strings.Cut(content, "\n\n")
+	if !found {
+		return "", "", "", nil, strings.TrimSpace(content)
+	}
+
+	for _, line := range strings.Split(header, "\n") {
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "# "), ": ")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Agent":
+			agent = value
+		case "Model":
+			model = value
+		case "Role":
+			roleName = value
+		case "Contexts":
+			for _, name := range strings.Split(value, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					contexts = append(contexts, name)
+				}
+			}
+		}
+	}
+
+	return agent, model, roleName, contexts, body
+}
+
+// SnapshotDiff describes the differences between two snapshots.
+type SnapshotDiff struct {
+	Old, New Snapshot
+
+	// Role and Prompt are unified diffs (empty when unchanged).
+	Role   string
+	Prompt string
+
+	AddedContexts   []string
+	RemovedContexts []string
+	ChangedContexts []string
+
+	// Args is the edit script over command arguments, with role and prompt
+	// values replaced by their placeholders.
+	Args []diff.Edit
+}
+
+// HasChanges reports whether any part of the composition differs.
+func (d SnapshotDiff) HasChanges() bool {
+	return d.Old.Agent != d.New.Agent ||
+		d.Old.Model != d.New.Model ||
+		d.Old.RoleName != d.New.RoleName ||
+		d.Role != "" || d.Prompt != "" ||
+		len(d.AddedContexts) > 0 || len(d.RemovedContexts) > 0 || len(d.ChangedContexts) > 0 ||
+		diff.HasChanges(d.Args)
+}
+
+// CompareSnapshots compares two snapshots.
+func CompareSnapshots(oldSnap, newSnap Snapshot) SnapshotDiff {
+	d := SnapshotDiff{Old: oldSnap, New: newSnap}
+
+	d.Role = diff.Unified("a/"+DryRunRoleFile, "b/"+DryRunRoleFile, oldSnap.Role, newSnap.Role, diff.DefaultContext)
+	d.Prompt = diff.Unified("a/"+DryRunPromptFile, "b/"+DryRunPromptFile, oldSnap.Prompt, newSnap.Prompt, diff.DefaultContext)
+
+	oldDigests := make(map[string]string, len(oldSnap.Contexts))
+	for _, c := range oldSnap.Contexts {
+		oldDigests[c.Name] = c.Digest
+	}
+	newNames := make(map[string]bool, len(newSnap.Contexts))
+	compareDigests := oldSnap.HasDigests && newSnap.HasDigests
+	for _, c := range newSnap.Contexts {
+		newNames[c.Name] = true
+		digest, ok := oldDigests[c.Name]
+		switch {
+		case !ok:
+			d.AddedContexts = append(d.AddedContexts, c.Name)
+		case compareDigests && digest != c.Digest:
+			d.ChangedContexts = append(d.ChangedContexts, c.Name)
+		}
+	}
+	for _, c := range oldSnap.Contexts {
+		if !newNames[c.Name] {
+			d.RemovedContexts = append(d.RemovedContexts, c.Name)
+		}
+	}
+
+	d.Args = diff.Lines(commandArgs(oldSnap), commandArgs(newSnap))
+	return d
+}
+
+// commandArgs splits a snapshot command into arguments, replacing the role
+// and prompt values with placeholders so content changes (already shown in
+// the role and prompt diffs) do not appear as argument changes.
+func commandArgs(s Snapshot) []string {
+	args := SplitCommandArgs(s.Command)
+	role := strings.TrimRight(s.Role, "\n")
+	prompt := strings.TrimRight(s.Prompt, "\n")
+	for i, arg := range args {
+		trimmed := strings.TrimRight(arg, "\n")
+		switch {
+		case prompt != "" && trimmed == prompt:
+			args[i] = "{{.prompt}}"
+		case role != "" && trimmed == role:
+			args[i] = "{{.role}}"
+		}
+	}
+	return args
+}
+
+// SplitCommandArgs splits a shell command string into arguments.
+// Handles single quotes, double quotes, and backslash escapes, which covers
+// the quoting produced by escapeForShell. It is not a full shell parser.
+func SplitCommandArgs(cmd string) []string {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+
+	for i := 0; i < len(cmd); i++ {
+		c := cmd[i]
+		switch {
+		case c == '\'':
+			inArg = true
+			end := strings.IndexByte(cmd[i+1:], '\'')
+			if end == -1 {
+				cur.WriteString(cmd[i+1:])
+				i = len(cmd)
+			} else {
+				cur.WriteString(cmd[i+1 : i+1+end])
+				i += end + 1
+			}
+		case c == '"':
+			inArg = true
+			for i++; i < len(cmd) && cmd[i] != '"'; i++ {
+				if cmd[i] == '\\' && i+1 < len(cmd) {
+					i++
+				}
+				cur.WriteByte(cmd[i])
+			}
+		case c == '\\' && i+1 < len(cmd):
+			inArg = true
+			i++
+			if cmd[i] != '\n' {
+				cur.WriteByte(cmd[i])
+			}
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			inArg = true
+			cur.WriteByte(c)
+		}
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+
+	return args
+}
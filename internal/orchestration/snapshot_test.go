@@ -0,0 +1,194 @@
+package orchestration
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grantcarthew/start/internal/diff"
+)
+
+// writeSnapshotDir writes a dry-run directory for snapshot tests.
+func writeSnapshotDir(t *testing.T, role, prompt, command, contexts string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		DryRunRoleFile:    role,
+		DryRunPromptFile:  prompt,
+		DryRunCommandFile: command,
+	}
+	if contexts != "" {
+		files[DryRunContextsFile] = contexts
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestReadSnapshot(t *testing.T) {
+	t.Parallel()
+
+	agent := Agent{Name: "claude"}
+	contexts := []Context{
+		{Name: "env", Status: "loaded", Content: "Environment"},
+		{Name: "skipped", Status: "skipped"},
+	}
+	cmdStr := "claude --model 'sonnet' 'prompt text'"
+	command := GenerateDryRunCommand(agent, "sonnet", "reviewer", []string{"env", "skipped"}, "/work", cmdStr)
+	manifest, err := GenerateDryRunContexts(contexts)
+	if err != nil {
+		t.Fatalf("GenerateDryRunContexts() error = %v", err)
+	}
+	dir := writeSnapshotDir(t, "Role text", "prompt text", command, manifest)
+
+	snap, err := ReadSnapshot(dir)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+
+	if snap.Agent != "claude" || snap.Model != "sonnet" || snap.RoleName != "reviewer" {
+		t.Errorf("header = %q/%q/%q, want claude/sonnet/reviewer", snap.Agent, snap.Model, snap.RoleName)
+	}
+	if snap.Command != cmdStr {
+		t.Errorf("Command = %q, want %q", snap.Command, cmdStr)
+	}
+	if snap.Role != "Role text" || snap.Prompt != "prompt text" {
+		t.Errorf("Role/Prompt = %q/%q", snap.Role, snap.Prompt)
+	}
+	if !snap.HasDigests {
+		t.Error("HasDigests = false, want true")
+	}
+	if len(snap.Contexts) != 1 || snap.Contexts[0].Name != "env" {
+		t.Errorf("Contexts = %+v, want only loaded context env", snap.Contexts)
+	}
+}
+
+func TestReadSnapshot_WithoutManifest(t *testing.T) {
+	t.Parallel()
+
+	command := GenerateDryRunCommand(Agent{Name: "a"}, "", "", []string{"one", "two"}, "/work", "a")
+	dir := writeSnapshotDir(t, "", "", command, "")
+
+	snap, err := ReadSnapshot(dir)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+	if snap.HasDigests {
+		t.Error("HasDigests = true, want false")
+	}
+	var names []string
+	for _, c := range snap.Contexts {
+		names = append(names, c.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"one", "two"}) {
+		t.Errorf("context names = %v, want [one two]", names)
+	}
+}
+
+func TestReadSnapshot_NotDryRunDir(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ReadSnapshot(t.TempDir()); err == nil {
+		t.Fatal("ReadSnapshot() expected error for directory without command.txt")
+	}
+	if _, err := ReadSnapshot(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("ReadSnapshot() expected error for missing directory")
+	}
+}
+
+func TestCompareSnapshots(t *testing.T) {
+	t.Parallel()
+
+	oldSnap := NewSnapshot(Agent{Name: "claude"}, "sonnet", ComposeResult{
+		RoleName: "reviewer",
+		Role:     "You review code.",
+		Prompt:   "Project A\n\nShared",
+		Contexts: []Context{
+			{Name: "project", Status: "loaded", Content: "Project A"},
+			{Name: "shared", Status: "loaded", Content: "Shared"},
+			{Name: "removed", Status: "loaded", Content: "Gone"},
+		},
+	}, "claude --model sonnet 'Project A\n\nShared'")
+
+	newSnap := NewSnapshot(Agent{Name: "claude"}, "opus", ComposeResult{
+		RoleName: "reviewer",
+		Role:     "You review code.",
+		Prompt:   "Project B\n\nShared\n\nNew",
+		Contexts: []Context{
+			{Name: "project", Status: "loaded", Content: "Project B"},
+			{Name: "shared", Status: "loaded", Content: "Shared"},
+			{Name: "added", Status: "loaded", Content: "New"},
+		},
+	}, "claude --model opus 'Project B\n\nShared\n\nNew'")
+
+	d := CompareSnapshots(oldSnap, newSnap)
+
+	if !d.HasChanges() {
+		t.Fatal("HasChanges() = false, want true")
+	}
+	if !reflect.DeepEqual(d.AddedContexts, []string{"added"}) {
+		t.Errorf("AddedContexts = %v", d.AddedContexts)
+	}
+	if !reflect.DeepEqual(d.RemovedContexts, []string{"removed"}) {
+		t.Errorf("RemovedContexts = %v", d.RemovedContexts)
+	}
+	if !reflect.DeepEqual(d.ChangedContexts, []string{"project"}) {
+		t.Errorf("ChangedContexts = %v", d.ChangedContexts)
+	}
+	if d.Role != "" {
+		t.Errorf("Role diff = %q, want empty", d.Role)
+	}
+	if !strings.Contains(d.Prompt, "-Project A") || !strings.Contains(d.Prompt, "+Project B") {
+		t.Errorf("Prompt diff missing changes:\n%s", d.Prompt)
+	}
+
+	var removed, added []string
+	for _, e := range d.Args {
+		switch e.Op {
+		case diff.Delete:
+			removed = append(removed, e.Text)
+		case diff.Insert:
+			added = append(added, e.Text)
+		}
+	}
+	if !reflect.DeepEqual(removed, []string{"sonnet"}) || !reflect.DeepEqual(added, []string{"opus"}) {
+		t.Errorf("arg changes = -%v +%v, want -[sonnet] +[opus] (prompt replaced by placeholder)", removed, added)
+	}
+}
+
+func TestCompareSnapshots_Identical(t *testing.T) {
+	t.Parallel()
+
+	snap := NewSnapshot(Agent{Name: "a"}, "", ComposeResult{Prompt: "p"}, "a 'p'")
+	if CompareSnapshots(snap, snap).HasChanges() {
+		t.Error("HasChanges() = true for identical snapshots")
+	}
+}
+
+func TestSplitCommandArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cmd  string
+		want []string
+	}{
+		{"claude --model sonnet", []string{"claude", "--model", "sonnet"}},
+		{"claude 'two words'", []string{"claude", "two words"}},
+		{`claude "double \"quoted\""`, []string{"claude", `double "quoted"`}},
+		{"echo " + escapeForShell("it's"), []string{"echo", "it's"}},
+		{"a\\ b c", []string{"a b", "c"}},
+		{"claude ''", []string{"claude", ""}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := SplitCommandArgs(tt.cmd); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitCommandArgs(%q) = %q, want %q", tt.cmd, got, tt.want)
+		}
+	}
+}
@@ -18,6 +18,14 @@ import (
 // Uses lowercase keys to match documented placeholder names (e.g., {{.file}}, {{.instructions}}).
 type TemplateData map[string]string
 
+// UTDPlaceholders are the placeholder names set when rendering a UTD template.
+// {{.instructions}} is only non-empty for tasks.
+var UTDPlaceholders = []string{
+	"file", "file_contents", "command", "command_output", "datetime", "instructions",
+	"cwd", "home", "user", "hostname", "os", "os_name", "shell",
+	"git_branch", "git_root", "git_user", "git_email",
+}
+
 // UTDFields represents the raw Unified Template Design (UTD) fields extracted from CUE configuration.
 type UTDFields struct {
 	// File is the path to read content from.
@@ -62,6 +70,9 @@ type TemplateProcessor struct {
 	fileReader  FileReader
 	shellRunner ShellRunner
 	workingDir  string
+	// stable renders {{.file}} relative to workingDir when inside it and
+	// leaves {{.datetime}} empty (see Composer.SetStable).
+	stable bool
 }
 
 // NewTemplateProcessor creates a new template processor.
@@ -135,6 +146,15 @@ func (p *TemplateProcessor) Process(fields UTDFields, instructions string) (Proc
 	data["file"] = fields.File
 	data["command"] = fields.Command
 	data["datetime"] = time.Now().Format(time.RFC3339)
+	if p.stable {
+		// The output outlives the session: refer to project files
+		// relatively and leave out the time
+		if rel, err := filepath.Rel(p.workingDir, fields.File); err == nil && filepath.IsAbs(fields.File) &&
+			rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			data["file"] = rel
+		}
+		data["datetime"] = ""
+	}
 	data["instructions"] = instructions
 
 	// Lazy evaluation: only read file if needed
@@ -166,7 +186,7 @@ func (p *TemplateProcessor) Process(fields UTDFields, instructions string) (Proc
 	// Parse and execute template
 	// Use Option("missingkey=zero") to handle unknown placeholders gracefully.
 	// This allows file-only contexts to contain template-like syntax (e.g., in code examples)
-	// without causing errors.
+	// without causing errors. 'start lint' reports unknown placeholders instead.
 	tmpl, err := template.New("utd").Option("missingkey=zero").Parse(templateStr)
 	if err != nil {
 		return result, fmt.Errorf("parsing template: %w", err)
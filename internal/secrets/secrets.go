@@ -0,0 +1,254 @@
+// Package secrets detects and redacts credentials in composed prompts.
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Policy controls what happens when secrets are detected before launch.
+type Policy string
+
+const (
+	// PolicyWarn lists findings and launches unchanged.
+	PolicyWarn Policy = "warn"
+	// PolicyRedact replaces each secret with a placeholder before launch.
+	PolicyRedact Policy = "redact"
+	// PolicyBlock refuses to launch.
+	PolicyBlock Policy = "block"
+)
+
+// DefaultPolicy is used when settings.secret_policy is not set.
+const DefaultPolicy = PolicyWarn
+
+// ParsePolicy parses a secret_policy setting value. Empty means DefaultPolicy.
+func ParsePolicy(s string) (Policy, error) {
+	switch p := Policy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return DefaultPolicy, nil
+	case PolicyWarn, PolicyRedact, PolicyBlock:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid secret_policy %q: must be warn, redact, or block", s)
+	}
+}
+
+// Rule is a named secret pattern. When the pattern has a capturing group,
+// the first group is the secret; otherwise the whole match is.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// builtinRules are the credential formats detected by every scanner.
+var builtinRules = []Rule{
+	{"aws-access-key", regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{"aws-secret-key", regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`)},
+	{"github-token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,255})\b`)},
+	{"github-token", regexp.MustCompile(`\b(github_pat_[A-Za-z0-9_]{22,255})\b`)},
+	{"slack-token", regexp.MustCompile(`\b(xox[abposr]-[A-Za-z0-9-]{10,})\b`)},
+	{"slack-webhook", regexp.MustCompile(`https://hooks\.slack\.com/services/[A-Za-z0-9/_-]+`)},
+	{"private-key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----(?:[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----)?`)},
+}
+
+// highEntropyRule names findings from the entropy heuristic.
+const highEntropyRule = "high-entropy"
+
+// tokenRe matches candidate tokens for the entropy heuristic. Slashes and
+// dots are excluded so file paths and hostnames are not candidates, and "="
+// only as base64 padding so KEY=value assignments split at the "=".
+var tokenRe = regexp.MustCompile(`[A-Za-z0-9+_-]{32,}={0,2}`)
+
+// minEntropy is the Shannon entropy (bits per character) above which a
+// mixed-case alphanumeric token is reported. Hex strings such as commit
+// hashes top out at 4.0 and never mix cases, so they are not reported.
+const minEntropy = 4.0
+
+// Finding is a detected secret.
+type Finding struct {
+	Rule    string `json:"rule"`
+	Source  string `json:"source"`  // Context name, "role:<name>", or "prompt"
+	Line    int    `json:"line"`    // 1-based line within the source text
+	Preview string `json:"preview"` // Masked secret for display
+	secret  string
+}
+
+// Scanner detects secrets using built-in rules, custom patterns, and an
+// entropy heuristic.
+type Scanner struct {
+	rules []Rule
+}
+
+// NewScanner creates a scanner with the built-in rules plus custom patterns.
+// Each custom pattern is a Go regular expression; its findings are reported
+// under the rule name "custom".
+func NewScanner(patterns []string) (*Scanner, error) {
+	rules := append([]Rule(nil), builtinRules...)
+	for _, p := range patterns {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret pattern %q: %w", p, err)
+		}
+		rules = append(rules, Rule{Name: "custom", Pattern: re})
+	}
+	return &Scanner{rules: rules}, nil
+}
+
+// ParsePatterns splits a secret_patterns setting into one pattern per line.
+func ParsePatterns(s string) []string {
+	var patterns []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns
+}
+
+// span is a secret's byte range within scanned text.
+type span struct {
+	start, end int
+	rule       string
+}
+
+// spans returns the non-overlapping secret ranges in text, in order.
+// Named rules take precedence over the entropy heuristic.
+func (s *Scanner) spans(text string) []span {
+	var named []span
+	for _, rule := range s.rules {
+		for _, m := range rule.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := m[0], m[1]
+			if len(m) >= 4 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			if start < end {
+				named = append(named, span{start, end, rule.Name})
+			}
+		}
+	}
+	merged := mergeSpans(named)
+
+	found := merged
+	for _, m := range tokenRe.FindAllStringIndex(text, -1) {
+		if isHighEntropy(text[m[0]:m[1]]) && !overlaps(merged, m[0], m[1]) {
+			found = append(found, span{m[0], m[1], highEntropyRule})
+		}
+	}
+	return mergeSpans(found)
+}
+
+// mergeSpans sorts spans and drops any that overlap an earlier one.
+// On equal starts the longer span wins.
+func mergeSpans(spans []span) []span {
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+		return spans[i].end > spans[j].end
+	})
+	var merged []span
+	for _, sp := range spans {
+		if len(merged) > 0 && sp.start < merged[len(merged)-1].end {
+			continue
+		}
+		merged = append(merged, sp)
+	}
+	return merged
+}
+
+// overlaps reports whether [start, end) intersects any span.
+func overlaps(spans []span, start, end int) bool {
+	for _, sp := range spans {
+		if start < sp.end && sp.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan returns the secrets found in text, attributed to source.
+func (s *Scanner) Scan(source, text string) []Finding {
+	var findings []Finding
+	for _, sp := range s.spans(text) {
+		secret := text[sp.start:sp.end]
+		findings = append(findings, Finding{
+			Rule:    sp.rule,
+			Source:  source,
+			Line:    strings.Count(text[:sp.start], "\n") + 1,
+			Preview: mask(secret),
+			secret:  secret,
+		})
+	}
+	return findings
+}
+
+// Redact replaces every detected secret in text with a placeholder naming the rule.
+func (s *Scanner) Redact(text string) string {
+	spans := s.spans(text)
+	if len(spans) == 0 {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, sp := range spans {
+		b.WriteString(text[last:sp.start])
+		fmt.Fprintf(&b, "[REDACTED:%s]", sp.rule)
+		last = sp.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// Secret returns the raw matched secret. Used to attribute findings across
+// texts; never display it.
+func (f Finding) Secret() string {
+	return f.secret
+}
+
+// isHighEntropy reports whether a token looks like a random credential.
+func isHighEntropy(token string) bool {
+	var upper, lower, digit bool
+	for _, r := range token {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= '0' && r <= '9':
+			digit = true
+		}
+	}
+	return upper && lower && digit && entropy(token) > minEntropy
+}
+
+// entropy returns the Shannon entropy of s in bits per character.
+func entropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// mask shows the first four characters of a secret and hides the rest.
+func mask(secret string) string {
+	secret = strings.SplitN(secret, "\n", 2)[0]
+	runes := []rune(secret)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:4]) + strings.Repeat("*", min(len(runes)-4, 8))
+}
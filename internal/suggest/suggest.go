@@ -0,0 +1,61 @@
+// Package suggest finds likely intended names for misspelled input.
+package suggest
+
+import "strings"
+
+// Closest returns the candidate nearest to name by edit distance, or "" if
+// none is close enough to be a plausible typo. Matching is case-insensitive.
+// A candidate qualifies when its distance is at most a third of the longer
+// name's length (minimum 1), so short names only match near-exact typos.
+func Closest(name string, candidates []string) string {
+	lower := strings.ToLower(name)
+	best := ""
+	bestDist := -1
+	for _, c := range candidates {
+		if c == name {
+			continue
+		}
+		d := Distance(lower, strings.ToLower(c))
+		if d > maxDistance(name, c) {
+			continue
+		}
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// maxDistance is the largest edit distance accepted between a and b.
+func maxDistance(a, b string) int {
+	n := max(len([]rune(a)), len([]rune(b)))
+	return max(n/3, 1)
+}
+
+// Distance returns the Levenshtein distance between a and b, counting an
+// adjacent transposition as a single edit.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// Three rows: two back (for transpositions), previous, and current.
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
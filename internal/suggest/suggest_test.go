@@ -0,0 +1,46 @@
+package suggest
+
+import "testing"
+
+func TestDistance(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"instruction", "instructions", 1},
+		{"kitten", "sitting", 3},
+		{"fiel", "file", 1}, // transposition
+		{"same", "same", 0},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosest(t *testing.T) {
+	t.Parallel()
+	candidates := []string{"file", "file_contents", "command", "command_output", "instructions", "os", "os_name"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"instruction", "instructions"},
+		{"Instructions", "instructions"},
+		{"comand", "command"},
+		{"file_content", "file_contents"},
+		{"fiel", "file"},
+		{"xyz", ""},
+		{"ox", "os"},
+		{"prompt", ""},
+	}
+	for _, tt := range tests {
+		if got := Closest(tt.name, candidates); got != tt.want {
+			t.Errorf("Closest(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,14 +2,26 @@
 package temp
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 )
 
+// sessionDirPrefix is the name prefix of per-session UTD directories.
+const sessionDirPrefix = "session-"
+
+// DefaultSessionMaxAge is the age after which session directories are
+// garbage collected. Sessions are kept alive for at least this long because
+// the agent replaces the start process and may read the files at any time.
+const DefaultSessionMaxAge = 7 * 24 * time.Hour
+
 // unsafeCharsRe matches characters not safe for filenames.
 var unsafeCharsRe = regexp.MustCompile(`[^a-zA-Z0-9-_.]`)
 
@@ -20,7 +32,7 @@ var consecutiveDashesRe = regexp.MustCompile(`-{2,}`)
 type Manager struct {
 	// BaseDir is the base directory for temp files.
 	// For dry-run: /tmp
-	// For UTD: .start/temp
+	// For UTD: <session base>/session-YYYYMMDDHHmmss-<pid>
 	BaseDir string
 }
 
@@ -30,10 +42,137 @@ func NewDryRunManager() *Manager {
 	return &Manager{BaseDir: os.TempDir()}
 }
 
-// NewUTDManager creates a manager for UTD temp files.
-// Files are written to .start/temp/
-func NewUTDManager(workingDir string) *Manager {
-	return &Manager{BaseDir: filepath.Join(workingDir, ".start", "temp")}
+// NewSessionManager creates a manager for UTD temp files of a single session.
+// Files are written to <baseDir>/session-YYYYMMDDHHmmss-<pid>/, so concurrent
+// sessions never overwrite each other. The directory is created on first write.
+func NewSessionManager(baseDir string) *Manager {
+	name := fmt.Sprintf("%s%s-%d", sessionDirPrefix, time.Now().Format("20060102150405"), os.Getpid())
+	return &Manager{BaseDir: filepath.Join(baseDir, name)}
+}
+
+// SessionBaseDir returns the directory holding per-session UTD workspaces.
+// A non-empty override (settings.temp_dir) takes precedence, then
+// $XDG_RUNTIME_DIR/start, then <os.TempDir>/start-<uid>.
+func SessionBaseDir(override string) string {
+	if override != "" {
+		if strings.HasPrefix(override, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				override = filepath.Join(home, override[2:])
+			}
+		}
+		return override
+	}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "start")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("start-%d", os.Getuid()))
+}
+
+// LegacyUTDDir returns the in-project temp directory used by earlier versions.
+func LegacyUTDDir(workingDir string) string {
+	return filepath.Join(workingDir, ".start", "temp")
+}
+
+// Session describes a per-session UTD directory.
+type Session struct {
+	Path    string
+	ModTime time.Time
+}
+
+// Running reports whether the process that created the session is still
+// alive. The pid is taken from the directory name (session-<ts>-<pid>);
+// the agent keeps it, since it replaces the start process.
+func (s Session) Running() bool {
+	name := filepath.Base(s.Path)
+	pid, err := strconv.Atoi(name[strings.LastIndex(name, "-")+1:])
+	if err != nil || pid <= 0 {
+		return false
+	}
+	// Signal 0 checks for the process without signalling it. EPERM means
+	// it exists but belongs to another user.
+	err = syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// ListSessions returns the session directories under baseDir, oldest first.
+// A missing baseDir yields no sessions.
+func ListSessions(baseDir string) ([]Session, error) {
+	entries, err := os.ReadDir(baseDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading session directory: %w", err)
+	}
+
+	var sessions []Session
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), sessionDirPrefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed concurrently
+		}
+		sessions = append(sessions, Session{
+			Path:    filepath.Join(baseDir, entry.Name()),
+			ModTime: info.ModTime(),
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ModTime.Before(sessions[j].ModTime)
+	})
+	return sessions, nil
+}
+
+// StaleSessions returns the session directories under baseDir last modified
+// more than maxAge ago. A maxAge of zero returns every session.
+func StaleSessions(baseDir string, maxAge time.Duration) ([]Session, error) {
+	sessions, err := ListSessions(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	var stale []Session
+	for _, s := range sessions {
+		if maxAge == 0 || s.ModTime.Before(cutoff) {
+			stale = append(stale, s)
+		}
+	}
+	return stale, nil
+}
+
+// RemoveSessions deletes the given session directories.
+func RemoveSessions(sessions []Session) error {
+	for _, s := range sessions {
+		if err := os.RemoveAll(s.Path); err != nil {
+			return fmt.Errorf("removing %s: %w", s.Path, err)
+		}
+	}
+	return nil
+}
+
+// CollectGarbage removes session directories under baseDir older than maxAge.
+// Sessions whose process is still running are kept, however old: a
+// long-lived agent may still read its files. Returns the number of
+// directories removed.
+func CollectGarbage(baseDir string, maxAge time.Duration) (int, error) {
+	sessions, err := StaleSessions(baseDir, maxAge)
+	if err != nil {
+		return 0, err
+	}
+	var stale []Session
+	for _, s := range sessions {
+		if !s.Running() {
+			stale = append(stale, s)
+		}
+	}
+	if err := RemoveSessions(stale); err != nil {
+		return 0, err
+	}
+	return len(stale), nil
 }
 
 // DryRunDir creates a timestamped directory for dry-run output.
@@ -85,9 +224,18 @@ func (m *Manager) WriteDryRunFiles(dir string, role, prompt, command string) err
 	return nil
 }
 
+// WriteDryRunFile writes an additional named file into a dry-run directory.
+func (m *Manager) WriteDryRunFile(dir, name, content string) error {
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
 // EnsureUTDDir ensures the UTD temp directory exists.
+// The directory is private to the user since it may hold copies of config files.
 func (m *Manager) EnsureUTDDir() error {
-	if err := os.MkdirAll(m.BaseDir, 0755); err != nil {
+	if err := os.MkdirAll(m.BaseDir, 0700); err != nil {
 		return fmt.Errorf("creating UTD temp directory: %w", err)
 	}
 	return nil
@@ -155,26 +303,3 @@ func (m *Manager) Clean() error {
 
 	return nil
 }
-
-// CheckGitignore checks if .start/temp is in .gitignore.
-// Returns true if it appears to be ignored.
-func CheckGitignore(workingDir string) bool {
-	gitignorePath := filepath.Join(workingDir, ".gitignore")
-	content, err := os.ReadFile(gitignorePath)
-	if err != nil {
-		return false
-	}
-
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == ".start/temp" ||
-			line == ".start/temp/" ||
-			line == ".start/" ||
-			line == ".start" {
-			return true
-		}
-	}
-
-	return false
-}
@@ -1,10 +1,12 @@
 package temp
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewDryRunManager(t *testing.T) {
@@ -22,17 +24,57 @@ func TestNewDryRunManager(t *testing.T) {
 	}
 }
 
-func TestNewUTDManager(t *testing.T) {
+func TestNewSessionManager(t *testing.T) {
 	t.Parallel()
-	m := NewUTDManager("/project")
+	m := NewSessionManager("/run/start")
 
 	if m == nil {
-		t.Fatal("NewUTDManager() returned nil")
+		t.Fatal("NewSessionManager() returned nil")
 	}
-	want := filepath.Join("/project", ".start", "temp")
-	if m.BaseDir != want {
-		t.Errorf("NewUTDManager() BaseDir = %q, want %q", m.BaseDir, want)
+	if filepath.Dir(m.BaseDir) != "/run/start" {
+		t.Errorf("NewSessionManager() BaseDir = %q, want under /run/start", m.BaseDir)
 	}
+	name := filepath.Base(m.BaseDir)
+	if !strings.HasPrefix(name, sessionDirPrefix) || !strings.HasSuffix(name, fmt.Sprintf("-%d", os.Getpid())) {
+		t.Errorf("session directory name = %q, want session-<timestamp>-<pid>", name)
+	}
+	if _, err := os.Stat(m.BaseDir); !os.IsNotExist(err) {
+		t.Error("NewSessionManager() should not create the directory before first write")
+	}
+}
+
+func TestSessionBaseDir(t *testing.T) {
+	t.Run("override wins", func(t *testing.T) {
+		t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+		if got := SessionBaseDir("/custom"); got != "/custom" {
+			t.Errorf("SessionBaseDir() = %q, want /custom", got)
+		}
+	})
+
+	t.Run("override expands home", func(t *testing.T) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			t.Skip("no home directory")
+		}
+		if got := SessionBaseDir("~/tmp/start"); got != filepath.Join(home, "tmp", "start") {
+			t.Errorf("SessionBaseDir() = %q", got)
+		}
+	})
+
+	t.Run("XDG_RUNTIME_DIR", func(t *testing.T) {
+		t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+		if got := SessionBaseDir(""); got != filepath.Join("/run/user/1000", "start") {
+			t.Errorf("SessionBaseDir() = %q", got)
+		}
+	})
+
+	t.Run("falls back to TempDir", func(t *testing.T) {
+		t.Setenv("XDG_RUNTIME_DIR", "")
+		want := filepath.Join(os.TempDir(), fmt.Sprintf("start-%d", os.Getuid()))
+		if got := SessionBaseDir(""); got != want {
+			t.Errorf("SessionBaseDir() = %q, want %q", got, want)
+		}
+	})
 }
 
 func TestManager_DryRunDir(t *testing.T) {
@@ -131,8 +173,7 @@ func TestManager_WriteDryRunFiles(t *testing.T) {
 
 func TestManager_WriteUTDFile(t *testing.T) {
 	t.Parallel()
-	tmpDir := t.TempDir()
-	m := NewUTDManager(tmpDir)
+	m := NewSessionManager(t.TempDir())
 
 	tests := []struct {
 		entityType   string
@@ -260,8 +301,7 @@ func TestManager_EnsureUTDDir(t *testing.T) {
 
 func TestManager_Clean(t *testing.T) {
 	t.Parallel()
-	tmpDir := t.TempDir()
-	m := NewUTDManager(tmpDir)
+	m := NewSessionManager(t.TempDir())
 
 	// Create some files
 	_, err := m.WriteUTDFile("role", "test1", "content1")
@@ -307,42 +347,86 @@ func TestManager_Clean_NonexistentDir(t *testing.T) {
 	}
 }
 
-func TestCheckGitignore(t *testing.T) {
+func TestSessions_GarbageCollection(t *testing.T) {
 	t.Parallel()
-	tmpDir := t.TempDir()
-
-	t.Run("returns false when no gitignore", func(t *testing.T) {
-		if CheckGitignore(tmpDir) {
-			t.Error("expected false when no .gitignore")
+	base := t.TempDir()
+
+	// A pid above the kernel's maximum is never running
+	oldSession := filepath.Join(base, "session-20200101000000-99999999")
+	newSession := filepath.Join(base, "session-20990101000000-2")
+	unrelated := filepath.Join(base, "keep-me")
+	for _, dir := range []string{oldSession, newSession, unrelated} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
 		}
-	})
+	}
+	past := time.Now().Add(-2 * DefaultSessionMaxAge)
+	if err := os.Chtimes(oldSession, past, past); err != nil {
+		t.Fatal(err)
+	}
 
-	t.Run("returns true when .start/temp is ignored", func(t *testing.T) {
-		gitignore := filepath.Join(tmpDir, ".gitignore")
-		content := "node_modules/\n.start/temp\n*.log"
-		if err := os.WriteFile(gitignore, []byte(content), 0644); err != nil {
-			t.Fatalf("writing .gitignore: %v", err)
-		}
+	sessions, err := ListSessions(base)
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].Path != oldSession {
+		t.Fatalf("ListSessions() = %+v, want two sessions, oldest first", sessions)
+	}
 
-		if !CheckGitignore(tmpDir) {
-			t.Error("expected true when .start/temp in .gitignore")
+	removed, err := CollectGarbage(base, DefaultSessionMaxAge)
+	if err != nil {
+		t.Fatalf("CollectGarbage() error = %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("CollectGarbage() removed %d, want 1", removed)
+	}
+	if _, err := os.Stat(oldSession); !os.IsNotExist(err) {
+		t.Error("old session should be removed")
+	}
+	for _, dir := range []string{newSession, unrelated} {
+		if _, err := os.Stat(dir); err != nil {
+			t.Errorf("%s should be kept: %v", dir, err)
 		}
-	})
+	}
 
-	t.Run("returns true when .start/ is ignored", func(t *testing.T) {
-		subDir := filepath.Join(tmpDir, "sub")
-		if err := os.MkdirAll(subDir, 0755); err != nil {
-			t.Fatalf("creating subdir: %v", err)
-		}
+	all, err := StaleSessions(base, 0)
+	if err != nil {
+		t.Fatalf("StaleSessions() error = %v", err)
+	}
+	if len(all) != 1 || all[0].Path != newSession {
+		t.Errorf("StaleSessions(0) = %+v, want the remaining session", all)
+	}
+}
 
-		gitignore := filepath.Join(subDir, ".gitignore")
-		content := ".start/"
-		if err := os.WriteFile(gitignore, []byte(content), 0644); err != nil {
-			t.Fatalf("writing .gitignore: %v", err)
-		}
+func TestCollectGarbage_KeepsRunningSessions(t *testing.T) {
+	t.Parallel()
+	base := t.TempDir()
 
-		if !CheckGitignore(subDir) {
-			t.Error("expected true when .start/ in .gitignore")
-		}
-	})
+	running := filepath.Join(base, fmt.Sprintf("session-20200101000000-%d", os.Getpid()))
+	if err := os.MkdirAll(running, 0700); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-2 * DefaultSessionMaxAge)
+	if err := os.Chtimes(running, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := CollectGarbage(base, DefaultSessionMaxAge)
+	if err != nil {
+		t.Fatalf("CollectGarbage() error = %v", err)
+	}
+	if removed != 0 {
+		t.Errorf("CollectGarbage() removed %d, want 0", removed)
+	}
+	if _, err := os.Stat(running); err != nil {
+		t.Errorf("running session should be kept: %v", err)
+	}
+}
+
+func TestListSessions_MissingBaseDir(t *testing.T) {
+	t.Parallel()
+	sessions, err := ListSessions(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || sessions != nil {
+		t.Errorf("ListSessions() = %v, %v; want nil, nil", sessions, err)
+	}
 }
@@ -0,0 +1,311 @@
+// Package trust tracks which project-local configurations may run commands.
+//
+// A cloned repository can ship ./.start/ config whose command fields would
+// otherwise run on the next launch. Like direnv, local config directories must
+// be approved with 'start trust'; the approval records a hash of the config
+// files, so any later change requires approval again.
+package trust
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"cuelang.org/go/cue"
+	"github.com/grantcarthew/start/internal/config"
+	internalcue "github.com/grantcarthew/start/internal/cue"
+)
+
+// storeFile is the trust store filename under the data directory.
+const storeFile = "trust.json"
+
+// Status is the trust state of a local config directory.
+type Status int
+
+const (
+	// StatusUntrusted means the directory has never been trusted.
+	StatusUntrusted Status = iota
+	// StatusChanged means the directory was trusted but its files changed since.
+	StatusChanged
+	// StatusTrusted means the directory is trusted and unchanged.
+	StatusTrusted
+)
+
+// String returns the string representation of a Status.
+func (s Status) String() string {
+	switch s {
+	case StatusUntrusted:
+		return "untrusted"
+	case StatusChanged:
+		return "changed"
+	case StatusTrusted:
+		return "trusted"
+	default:
+		return "unknown"
+	}
+}
+
+// Entry records an approved local config directory.
+type Entry struct {
+	Hash      string    `json:"hash"`
+	TrustedAt time.Time `json:"trusted_at"`
+}
+
+// Store is the set of trusted local config directories, keyed by absolute path.
+type Store struct {
+	path    string
+	Entries map[string]Entry `json:"entries"`
+}
+
+// DefaultPath returns the trust store path, respecting XDG_DATA_HOME.
+// Defaults to ~/.local/share/start/trust.json.
+func DefaultPath() (string, error) {
+	base := os.Getenv("XDG_DATA_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolving data directory: %w", err)
+		}
+		base = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(base, "start", storeFile), nil
+}
+
+// Load reads the trust store at path. A missing file yields an empty store.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path, Entries: make(map[string]Entry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading trust store: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parsing trust store %s: %w", path, err)
+	}
+	if s.Entries == nil {
+		s.Entries = make(map[string]Entry)
+	}
+	return s, nil
+}
+
+// LoadDefault reads the trust store at DefaultPath.
+func LoadDefault() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Save writes the trust store to disk.
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("creating data directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding trust store: %w", err)
+	}
+	if err := os.WriteFile(s.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing trust store: %w", err)
+	}
+	return nil
+}
+
+// Check returns the trust status of a local config directory.
+func (s *Store) Check(dir string) (Status, error) {
+	key, err := filepath.Abs(dir)
+	if err != nil {
+		return StatusUntrusted, err
+	}
+	entry, ok := s.Entries[key]
+	if !ok {
+		return StatusUntrusted, nil
+	}
+	hash, err := HashDir(dir)
+	if err != nil {
+		return StatusUntrusted, err
+	}
+	if hash != entry.Hash {
+		return StatusChanged, nil
+	}
+	return StatusTrusted, nil
+}
+
+// Trust records the current contents of dir as trusted.
+func (s *Store) Trust(dir string) error {
+	key, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	hash, err := HashDir(dir)
+	if err != nil {
+		return err
+	}
+	s.Entries[key] = Entry{Hash: hash, TrustedAt: time.Now().UTC().Truncate(time.Second)}
+	return nil
+}
+
+// Revoke removes dir from the store. Returns false if it was not trusted.
+func (s *Store) Revoke(dir string) (bool, error) {
+	key, err := filepath.Abs(dir)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := s.Entries[key]; !ok {
+		return false, nil
+	}
+	delete(s.Entries, key)
+	return true, nil
+}
+
+// Dirs returns the trusted directories in sorted order.
+func (s *Store) Dirs() []string {
+	dirs := make([]string, 0, len(s.Entries))
+	for dir := range s.Entries {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// HashDir returns a SHA-256 over the names and contents of the CUE files in dir.
+func HashDir(dir string) (string, error) {
+	files, err := config.CUEFilesInDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("reading config directory: %w", err)
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", file, err)
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.Base(file), len(data))
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Command is a config item that runs something: a command, an agent binary,
+// or a shell.
+type Command struct {
+	Kind    string // "agent", "role", "context", or "task"
+	Name    string
+	Command string // empty when the item only sets bin or shell
+	Bin     string // agent binary
+	Shell   string // shell that runs the command
+}
+
+// Hash identifies the item and everything it would run. Two items match only
+// when kind, name, command, bin, and shell are all the same.
+func (c Command) Hash() string {
+	sum := sha256.Sum256([]byte(c.Kind + "\x00" + c.Name + "\x00" + c.Command + "\x00" + c.Bin + "\x00" + c.Shell))
+	return hex.EncodeToString(sum[:])
+}
+
+// String describes the item and what it runs.
+func (c Command) String() string {
+	var parts []string
+	if c.Command != "" {
+		parts = append(parts, strings.TrimSpace(c.Command))
+	}
+	if c.Bin != "" {
+		parts = append(parts, "bin: "+c.Bin)
+	}
+	if c.Shell != "" {
+		parts = append(parts, "shell: "+c.Shell)
+	}
+	return fmt.Sprintf("%s %s: %s", c.Kind, c.Name, strings.Join(parts, ", "))
+}
+
+// commandKinds maps collection keys to item kinds, in display order.
+var commandKinds = []struct {
+	key  string
+	kind string
+}{
+	{internalcue.KeyAgents, "agent"},
+	{internalcue.KeyRoles, "role"},
+	{internalcue.KeyContexts, "context"},
+	{internalcue.KeyTasks, "task"},
+}
+
+// LocalCommands returns the items in the local config directory that run
+// something and are not defined identically in the global config directory.
+// An item the user already runs from their own global config needs no extra
+// approval, but reusing its command text under another name, bin, or shell
+// does.
+func LocalCommands(localDir, globalDir string) ([]Command, error) {
+	local, err := internalcue.NewLoader().Load([]string{localDir})
+	if err != nil {
+		if errors.Is(err, internalcue.ErrNoCUEFiles) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	if globalDir != "" {
+		if global, err := internalcue.NewLoader().Load([]string{globalDir}); err == nil {
+			for _, c := range collectCommands(global.Value) {
+				known[c.Hash()] = true
+			}
+		}
+	}
+
+	var commands []Command
+	for _, c := range collectCommands(local.Value) {
+		if !known[c.Hash()] {
+			commands = append(commands, c)
+		}
+	}
+	return commands, nil
+}
+
+// collectCommands returns the items in a config value that set a command,
+// bin, or shell field.
+func collectCommands(v cue.Value) []Command {
+	var commands []Command
+	for _, k := range commandKinds {
+		items := v.LookupPath(cue.ParsePath(k.key))
+		if !items.Exists() {
+			continue
+		}
+		iter, err := items.Fields()
+		if err != nil {
+			continue
+		}
+		for iter.Next() {
+			c := Command{
+				Kind:    k.kind,
+				Name:    iter.Selector().Unquoted(),
+				Command: stringField(iter.Value(), "command"),
+				Bin:     stringField(iter.Value(), "bin"),
+				Shell:   stringField(iter.Value(), "shell"),
+			}
+			if c.Command != "" || c.Bin != "" || c.Shell != "" {
+				commands = append(commands, c)
+			}
+		}
+	}
+	return commands
+}
+
+// stringField returns the string value of a field, or "" if it is missing or
+// not a string.
+func stringField(v cue.Value, name string) string {
+	s, _ := v.LookupPath(cue.ParsePath(name)).String()
+	return s
+}
@@ -11,6 +11,7 @@ import (
 	internalcue "github.com/grantcarthew/start/internal/cue"
 	"github.com/grantcarthew/start/internal/orchestration"
 	"github.com/grantcarthew/start/internal/shell"
+	"github.com/grantcarthew/start/internal/temp"
 )
 
 // chdir changes to the given directory and registers a cleanup to restore the original.
@@ -113,7 +114,7 @@ func TestIntegration_CUELoaderWithComposer(t *testing.T) {
 	// Create composer with shell runner
 	shellRunner := shell.NewRunner()
 	processor := orchestration.NewTemplateProcessor(nil, shellRunner, tmpDir)
-	composer := orchestration.NewComposer(processor, tmpDir)
+	composer := orchestration.NewComposer(processor, temp.NewSessionManager(t.TempDir()), tmpDir)
 
 	// Test composing with required contexts
 	selection := orchestration.ContextSelection{
@@ -153,7 +154,7 @@ func TestIntegration_CUELoaderWithComposer_DefaultContexts(t *testing.T) {
 
 	shellRunner := shell.NewRunner()
 	processor := orchestration.NewTemplateProcessor(nil, shellRunner, tmpDir)
-	composer := orchestration.NewComposer(processor, tmpDir)
+	composer := orchestration.NewComposer(processor, temp.NewSessionManager(t.TempDir()), tmpDir)
 
 	// Test composing with required and default contexts
 	selection := orchestration.ContextSelection{
@@ -202,7 +203,7 @@ func TestIntegration_CUELoaderWithComposer_TaggedContexts(t *testing.T) {
 
 	shellRunner := shell.NewRunner()
 	processor := orchestration.NewTemplateProcessor(nil, shellRunner, tmpDir)
-	composer := orchestration.NewComposer(processor, tmpDir)
+	composer := orchestration.NewComposer(processor, temp.NewSessionManager(t.TempDir()), tmpDir)
 
 	// Test composing with tagged contexts
 	selection := orchestration.ContextSelection{
@@ -244,7 +245,7 @@ func TestIntegration_ComposeWithRole(t *testing.T) {
 
 	shellRunner := shell.NewRunner()
 	processor := orchestration.NewTemplateProcessor(nil, shellRunner, tmpDir)
-	composer := orchestration.NewComposer(processor, tmpDir)
+	composer := orchestration.NewComposer(processor, temp.NewSessionManager(t.TempDir()), tmpDir)
 
 	selection := orchestration.ContextSelection{
 		IncludeRequired: true,
@@ -277,7 +278,7 @@ func TestIntegration_ResolveTask(t *testing.T) {
 
 	shellRunner := shell.NewRunner()
 	processor := orchestration.NewTemplateProcessor(nil, shellRunner, tmpDir)
-	composer := orchestration.NewComposer(processor, tmpDir)
+	composer := orchestration.NewComposer(processor, temp.NewSessionManager(t.TempDir()), tmpDir)
 
 	// Test resolving task with instructions
 	taskResult, err := composer.ResolveTask(result.Value, "code-review", "focus on security")